@@ -20,12 +20,15 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/falcosecurity/falcoctl/cmd/artifact/audit"
+	"github.com/falcosecurity/falcoctl/cmd/artifact/build"
 	artifactconfig "github.com/falcosecurity/falcoctl/cmd/artifact/config"
 	"github.com/falcosecurity/falcoctl/cmd/artifact/follow"
 	"github.com/falcosecurity/falcoctl/cmd/artifact/info"
 	"github.com/falcosecurity/falcoctl/cmd/artifact/install"
 	"github.com/falcosecurity/falcoctl/cmd/artifact/list"
 	"github.com/falcosecurity/falcoctl/cmd/artifact/manifest"
+	"github.com/falcosecurity/falcoctl/cmd/artifact/owner"
 	"github.com/falcosecurity/falcoctl/cmd/artifact/search"
 	"github.com/falcosecurity/falcoctl/internal/config"
 	"github.com/falcosecurity/falcoctl/pkg/index/cache"
@@ -73,6 +76,9 @@ func NewArtifactCmd(ctx context.Context, opt *commonoptions.Common) *cobra.Comma
 	cmd.AddCommand(follow.NewArtifactFollowCmd(ctx, opt))
 	cmd.AddCommand(artifactconfig.NewArtifactConfigCmd(ctx, opt))
 	cmd.AddCommand(manifest.NewArtifactManifestCmd(ctx, opt))
+	cmd.AddCommand(build.NewArtifactBuildCmd(ctx, opt))
+	cmd.AddCommand(audit.NewArtifactAuditCmd(ctx, opt))
+	cmd.AddCommand(owner.NewArtifactOwnerCmd(ctx, opt))
 
 	return cmd
 }
@@ -0,0 +1,121 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/pkg/advisory"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/installed"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const longAudit = `Match the installed artifacts against the advisories published by the indexes and by the
+advisory feeds configured under "advisories.sources".
+
+The installed artifacts are the ones recorded by "falcoctl artifact install" and "falcoctl artifact follow".
+The command exits with a non-zero code when at least one advisory affects them.
+
+Example - Audit the installed artifacts:
+	falcoctl artifact audit
+
+Example - Report only the high and critical advisories:
+	falcoctl artifact audit --severity high
+`
+
+type artifactAuditOptions struct {
+	*options.Common
+	severity  string
+	stateFile string
+}
+
+// NewArtifactAuditCmd returns the artifact audit command.
+func NewArtifactAuditCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := artifactAuditOptions{
+		Common:    opt,
+		stateFile: config.InstalledFile,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "audit [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Match the installed artifacts against the advisories",
+		Long:                  longAudit,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunArtifactAudit(ctx)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.severity, "severity", "",
+		"Minimum severity of the reported advisories, one of low, medium, high, critical (default: the configured one)")
+
+	return cmd
+}
+
+// RunArtifactAudit executes the business logic for the artifact audit command.
+func (o *artifactAuditOptions) RunArtifactAudit(ctx context.Context) error {
+	logger := o.Printer.Logger
+
+	conf, err := config.AdvisoriesConfig()
+	if err != nil {
+		return err
+	}
+	if o.severity == "" {
+		o.severity = conf.Severity
+	}
+	if err := advisory.ValidatePolicy(conf.Policy, o.severity); err != nil {
+		return err
+	}
+
+	state, err := installed.Load(o.stateFile)
+	if err != nil {
+		return err
+	}
+	if len(state.Artifacts) == 0 {
+		logger.Info("No installed artifacts recorded", logger.Args("file", o.stateFile))
+		return nil
+	}
+
+	feed, err := advisory.Fetch(ctx, conf.Sources)
+	if err != nil {
+		return err
+	}
+	db := advisory.NewDatabase(o.IndexCache.EntriesWithAdvisories(), feed)
+
+	var data [][]string
+	for i := range state.Artifacts {
+		a := &state.Artifacts[i]
+		for _, f := range db.Findings(a.Ref, a.Name, a.Version, o.severity) {
+			data = append(data, []string{f.Artifact, f.Version, f.Advisory.ID, f.Advisory.Severity, f.Advisory.Fixed, f.Advisory.Description})
+		}
+	}
+
+	if len(data) == 0 {
+		logger.Info("No advisories affect the installed artifacts",
+			logger.Args("artifacts", len(state.Artifacts), "advisories", db.Len()))
+		return nil
+	}
+	if err := o.Printer.PrintTable(output.ArtifactAudit, data); err != nil {
+		return err
+	}
+	return errdefs.Errorf(errdefs.ErrAdvisory, "%d advisories affect the installed artifacts", len(data))
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package audit
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/index/cache"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/installed"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const testFeed = `
+- id: FALCO-2025-002
+  artifact: falco-rules
+  affected: ">=3.0.0 <3.2.1"
+  severity: medium
+  fixed: 3.2.1
+  description: Missing detection of reverse shells
+`
+
+func newOptions(t *testing.T, buf *bytes.Buffer, artifacts ...installed.Artifact) *artifactAuditOptions {
+	t.Helper()
+	dir := t.TempDir()
+
+	feed := filepath.Join(dir, "advisories.yaml")
+	require.NoError(t, os.WriteFile(feed, []byte(testFeed), 0o600))
+	viper.Set(config.AdvisoriesSourcesKey, "feed,file://"+feed)
+	t.Cleanup(func() { viper.Set(config.AdvisoriesSourcesKey, nil) })
+
+	state := &installed.State{}
+	for _, a := range artifacts {
+		state.Set(a)
+	}
+	stateFile := filepath.Join(dir, "installed.yaml")
+	require.NoError(t, state.Write(stateFile))
+
+	idx := index.New("falcosecurity")
+	idx.Upsert(&index.Entry{
+		Name:       "cloudtrail",
+		Type:       "plugin",
+		Registry:   "ghcr.io",
+		Repository: "falcosecurity/plugins/plugin/cloudtrail",
+		Advisories: []index.Advisory{
+			{ID: "FALCO-2025-001", Affected: ">=0.9.0 <0.9.2", Severity: index.SeverityHigh, Fixed: "0.9.2"},
+		},
+	})
+	indexes := index.NewMergedIndexes()
+	indexes.Merge(idx)
+
+	opt := options.NewOptions()
+	opt.Initialize(options.WithWriter(buf), options.WithIndexCache(cache.NewFromMergedIndexes(indexes)))
+	return &artifactAuditOptions{Common: opt, stateFile: stateFile}
+}
+
+func TestAudit(t *testing.T) {
+	rules := installed.Artifact{Ref: "ghcr.io/falcosecurity/rules/falco-rules:3", Name: "falco-rules", Version: "3.1.0"}
+	plugin := installed.Artifact{Ref: "ghcr.io/falcosecurity/plugins/plugin/cloudtrail:0.9.1", Name: "cloudtrail", Version: "0.9.1"}
+
+	var buf bytes.Buffer
+	o := newOptions(t, &buf, rules, plugin)
+	err := o.RunArtifactAudit(context.Background())
+	assert.True(t, errors.Is(err, errdefs.ErrAdvisory))
+	assert.EqualError(t, err, "2 advisories affect the installed artifacts")
+	assert.Contains(t, buf.String(), "FALCO-2025-001")
+	assert.Contains(t, buf.String(), "Missing detection of reverse shells")
+
+	buf.Reset()
+	o = newOptions(t, &buf, rules, plugin)
+	o.severity = index.SeverityHigh
+	assert.EqualError(t, o.RunArtifactAudit(context.Background()), "1 advisories affect the installed artifacts")
+	assert.NotContains(t, buf.String(), "FALCO-2025-002")
+
+	buf.Reset()
+	rules.Version = "3.2.1"
+	plugin.Version = "0.9.2"
+	o = newOptions(t, &buf, rules, plugin)
+	assert.NoError(t, o.RunArtifactAudit(context.Background()))
+	assert.Contains(t, buf.String(), "No advisories affect the installed artifacts")
+}
+
+func TestAuditInvalidSeverity(t *testing.T) {
+	var buf bytes.Buffer
+	o := newOptions(t, &buf)
+	o.severity = "urgent"
+	assert.EqualError(t, o.RunArtifactAudit(context.Background()),
+		`invalid advisory severity "urgent", allowed values: low, medium, high, critical`)
+}
+
+func TestAuditNothingInstalled(t *testing.T) {
+	var buf bytes.Buffer
+	o := newOptions(t, &buf)
+	assert.NoError(t, o.RunArtifactAudit(context.Background()))
+	assert.Contains(t, buf.String(), "No installed artifacts recorded")
+}
@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"github.com/spf13/cobra"
+	"oras.land/oras-go/v2"
+	ocilayout "oras.land/oras-go/v2/content/oci"
+
+	"github.com/falcosecurity/falcoctl/cmd/registry/push"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
+	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const (
+	longBuild = `Build a Falco OCI artifact from its spec file.
+
+The spec file (falcoctl-artifact.yaml by default) describes the artifact: its name, type,
+version (or versionFromGitTag to take it from the git tag pointing at HEAD), the files to be packaged
+(one per platform for plugins), dependencies, requirements, annotations and tags.
+File paths are relative to the directory containing the spec file.
+
+The artifact is stored in a local OCI layout. Building the same inputs always produces the same digest;
+the creation time annotation is taken from the SOURCE_DATE_EPOCH environment variable, when set.
+
+Example - Build the artifact described in ./falcoctl-artifact.yaml:
+	falcoctl artifact build
+
+Example - Build the artifact and push it to a remote registry:
+	falcoctl artifact build --spec plugins/cloudtrail/falcoctl-artifact.yaml --push ghcr.io/myorg/plugins/cloudtrail
+`
+	// sourceDateEpochEnv is the environment variable used to set a reproducible creation time.
+	sourceDateEpochEnv = "SOURCE_DATE_EPOCH"
+)
+
+type artifactBuildOptions struct {
+	*options.Common
+	*options.Registry
+	specFile  string
+	outputDir string
+	pushRef   string
+}
+
+// NewArtifactBuildCmd returns the artifact build command.
+func NewArtifactBuildCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := artifactBuildOptions{
+		Common:   opt,
+		Registry: &options.Registry{},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "build [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Build a Falco OCI artifact from its spec file",
+		Long:                  longBuild,
+		Args:                  cobra.NoArgs,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			if o.pushRef == "" {
+				return nil
+			}
+			_, err := utils.GetRegistryFromRef(o.pushRef)
+			return err
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := o.RunArtifactBuild(ctx)
+			return err
+		},
+	}
+
+	o.Registry.AddFlags(cmd)
+	cmd.Flags().StringVar(&o.specFile, "spec", DefaultSpecFile, "path of the artifact spec file")
+	cmd.Flags().StringVarP(&o.outputDir, "output-dir", "o", "build", "directory of the OCI layout where the artifact is stored")
+	cmd.Flags().StringVar(&o.pushRef, "push", "",
+		"push the built artifact to the given reference, in the form hostname/repo[:tag] (the version is used if no tag is given)")
+
+	return cmd
+}
+
+// RunArtifactBuild implements the artifact build command. It returns the result of the build.
+func (o *artifactBuildOptions) RunArtifactBuild(ctx context.Context) (*oci.RegistryResult, error) {
+	logger := o.Printer.Logger
+
+	spec, err := LoadSpec(o.specFile)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := spec.ResolveVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+	artifactOptions := spec.artifactOptions(version)
+
+	logger.Info("Building artifact", logger.Args("name", spec.Name, "type", spec.Type, "version", version))
+
+	config, archives, tmpDirs, err := push.PrepareArtifact(logger, "", spec.Paths(), artifactOptions)
+	defer func() {
+		for _, dir := range tmpDirs {
+			if err := os.RemoveAll(dir); err != nil {
+				logger.Warn("Unable to remove temporary dir", logger.Args("name", dir, "error", err.Error()))
+			}
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := push.PusherOptions(artifactOptions, config, archives)
+	if err != nil {
+		return nil, err
+	}
+	annotations, err := spec.manifestAnnotations()
+	if err != nil {
+		return nil, err
+	}
+	opts = append(opts, ocipusher.WithAnnotations(annotations))
+
+	store, err := ocilayout.New(o.outputDir)
+	if err != nil {
+		return nil, errdefs.Classify(fmt.Errorf("unable to open OCI layout %q: %w", o.outputDir, err))
+	}
+
+	res, err := ocipusher.NewPusher(nil, false, nil).PushToTarget(ctx, spec.Type, store, version, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Info("Artifact built", logger.Args("name", spec.Name, "version", version, "digest", res.RootDigest, "layout", o.outputDir))
+
+	if o.pushRef == "" {
+		return res, nil
+	}
+
+	if err := o.pushLayout(ctx, store, res, version); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+// pushLayout copies the artifact built in the local OCI layout to the remote reference, together with its tags.
+func (o *artifactBuildOptions) pushLayout(ctx context.Context, store *ocilayout.Store, res *oci.RegistryResult, version string) error {
+	logger := o.Printer.Logger
+
+	registry, err := utils.GetRegistryFromRef(o.pushRef)
+	if err != nil {
+		return err
+	}
+
+	client, err := ociutils.Client(true)
+	if err != nil {
+		return err
+	}
+
+	if err := ociutils.CheckConnectionForRegistry(ctx, client, o.PlainHTTP, registry); err != nil {
+		return err
+	}
+
+	repo, err := repository.NewRepository(o.pushRef, repository.WithClient(client), repository.WithPlainHTTP(o.PlainHTTP))
+	if err != nil {
+		return err
+	}
+	if repo.Reference.Reference == "" {
+		repo.Reference.Reference = version
+	}
+
+	copyOpts := oras.DefaultCopyOptions
+	copyOpts.Concurrency = 1
+	if _, err := oras.Copy(ctx, store, res.RootDigest, repo, repo.Reference.Reference, copyOpts); err != nil {
+		return errdefs.Classify(fmt.Errorf("unable to push artifact to %q: %w", o.pushRef, err))
+	}
+
+	tags, err := layoutTags(ctx, store, res.RootDigest)
+	if err != nil {
+		return err
+	}
+	if len(tags) > 0 {
+		tagNOptions := oras.DefaultTagNOptions
+		tagNOptions.Concurrency = 1
+		if _, err = oras.TagN(ctx, repo, repo.Reference.Reference, tags, tagNOptions); err != nil {
+			return errdefs.Classify(err)
+		}
+	}
+
+	logger.Info("Artifact pushed", logger.Args("ref", repo.Reference.String(), "tags", tags, "digest", res.RootDigest))
+	return nil
+}
+
+// layoutTags returns the tags in the OCI layout pointing to the given digest.
+func layoutTags(ctx context.Context, store *ocilayout.Store, digest string) ([]string, error) {
+	var tags []string
+	err := store.Tags(ctx, "", func(page []string) error {
+		for _, tag := range page {
+			desc, err := store.Resolve(ctx, tag)
+			if err != nil {
+				return err
+			}
+			if desc.Digest.String() == digest {
+				tags = append(tags, tag)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list tags of the OCI layout: %w", err)
+	}
+	return tags, nil
+}
+
+// manifestAnnotations returns the annotations of the spec together with a reproducible creation time,
+// unless one has been explicitly set.
+func (s *Spec) manifestAnnotations() (map[string]string, error) {
+	annotations := make(map[string]string, len(s.Annotations)+1)
+	for k, v := range s.Annotations {
+		annotations[k] = v
+	}
+	if _, ok := annotations[v1.AnnotationCreated]; ok {
+		return annotations, nil
+	}
+
+	created := time.Unix(0, 0).UTC()
+	if epoch, ok := os.LookupEnv(sourceDateEpochEnv); ok {
+		seconds, err := strconv.ParseInt(epoch, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s %q: %w", sourceDateEpochEnv, epoch, err)
+		}
+		created = time.Unix(seconds, 0).UTC()
+	}
+	annotations[v1.AnnotationCreated] = created.Format(time.RFC3339)
+
+	return annotations, nil
+}
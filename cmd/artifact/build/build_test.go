@@ -0,0 +1,181 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	ocilayout "oras.land/oras-go/v2/content/oci"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	commonoptions "github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+// writeSpec copies the test rulesfile in dir and writes there a spec file with the given content.
+func writeSpec(dir, content string) string {
+	data, err := os.ReadFile(testRules)
+	Expect(err).ToNot(HaveOccurred())
+	Expect(os.WriteFile(filepath.Join(dir, "rules.yaml"), data, 0o600)).To(Succeed())
+	specFile := filepath.Join(dir, DefaultSpecFile)
+	Expect(os.WriteFile(specFile, []byte(content), 0o600)).To(Succeed())
+	return specFile
+}
+
+var _ = Describe("Spec", func() {
+	var (
+		dir string
+		err error
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+	})
+
+	DescribeTable("validation",
+		func(content, expectedErr string) {
+			_, err = LoadSpec(writeSpec(dir, content))
+			if expectedErr == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			}
+		},
+		Entry("valid rulesfile", "name: my-rules\ntype: rulesfile\nversion: 0.1.0\nfiles:\n  - path: rules.yaml\n", ""),
+		Entry("missing version", "name: my-rules\ntype: rulesfile\nfiles:\n  - path: rules.yaml\n", "version"),
+		Entry("both version sources", "name: my-rules\ntype: rulesfile\nversion: 0.1.0\nversionFromGitTag: true\nfiles:\n  - path: rules.yaml\n",
+			"versionFromGitTag"),
+		Entry("invalid version", "name: my-rules\ntype: rulesfile\nversion: one\nfiles:\n  - path: rules.yaml\n", "version"),
+		Entry("invalid type", "name: my-rules\ntype: unknown\nversion: 0.1.0\nfiles:\n  - path: rules.yaml\n", "unknown"),
+		Entry("platform on rulesfile", "name: my-rules\ntype: rulesfile\nversion: 0.1.0\nfiles:\n  - path: rules.yaml\n    platform: linux/amd64\n",
+			"platform"),
+		Entry("plugin without platform", "name: my-plugin\ntype: plugin\nversion: 0.1.0\nfiles:\n  - path: rules.yaml\n", "platform"),
+	)
+})
+
+var _ = Describe("RunArtifactBuild", func() {
+	const spec = `name: my-rules
+type: rulesfile
+version: 0.1.0
+files:
+  - path: rules.yaml
+dependencies:
+  - k8saudit:0.1.0
+tags:
+  - latest
+`
+
+	var (
+		dir string
+		o   *artifactBuildOptions
+		res *oci.RegistryResult
+		err error
+	)
+
+	BeforeEach(func() {
+		dir = GinkgoT().TempDir()
+		o = &artifactBuildOptions{
+			Common:    opt,
+			Registry:  &commonoptions.Registry{},
+			specFile:  writeSpec(dir, spec),
+			outputDir: filepath.Join(dir, "layout"),
+		}
+	})
+
+	JustBeforeEach(func() {
+		res, err = o.RunArtifactBuild(ctx)
+	})
+
+	It("stores the artifact in the OCI layout", func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(res.Type).To(Equal(oci.Rulesfile))
+		Expect(filepath.Join(o.outputDir, "index.json")).To(BeAnExistingFile())
+
+		store, err := ocilayout.New(o.outputDir)
+		Expect(err).ToNot(HaveOccurred())
+		tags, err := layoutTags(ctx, store, res.RootDigest)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(tags).To(ConsistOf("0.1.0", "latest"))
+	})
+
+	It("produces the same digest when built twice", func() {
+		Expect(err).ToNot(HaveOccurred())
+		o.outputDir = filepath.Join(dir, "layout2")
+		again, err := o.RunArtifactBuild(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(again.RootDigest).To(Equal(res.RootDigest))
+	})
+
+	When("pushing to a registry", func() {
+		var repoName string
+
+		BeforeEach(func() {
+			repoName = "rules/my-rules"
+			o.pushRef = registry + "/" + repoName
+			o.PlainHTTP = true
+		})
+
+		It("pushes the artifact with the version and all its tags", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			repo, err := orasRegistry.Repository(ctx, repoName)
+			Expect(err).ToNot(HaveOccurred())
+			var tags []string
+			Expect(repo.Tags(ctx, "", func(page []string) error {
+				tags = append(tags, page...)
+				return nil
+			})).To(Succeed())
+			Expect(tags).To(ConsistOf("0.1.0", "latest"))
+
+			for _, tag := range tags {
+				desc, err := repo.Resolve(ctx, tag)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(desc.Digest.String()).To(Equal(res.RootDigest))
+			}
+		})
+
+		When("the reference has a tag", func() {
+			BeforeEach(func() {
+				repoName = "rules/tagged"
+				o.pushRef = registry + "/" + repoName + ":edge"
+			})
+
+			It("uses it instead of the version", func() {
+				Expect(err).ToNot(HaveOccurred())
+
+				repo, err := orasRegistry.Repository(ctx, repoName)
+				Expect(err).ToNot(HaveOccurred())
+				desc, err := repo.Resolve(ctx, "edge")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(desc.Digest.String()).To(Equal(res.RootDigest))
+				_, err = repo.Resolve(ctx, "latest")
+				Expect(err).ToNot(HaveOccurred())
+			})
+		})
+	})
+
+	When("SOURCE_DATE_EPOCH is invalid", func() {
+		BeforeEach(func() {
+			GinkgoT().Setenv(sourceDateEpochEnv, "yesterday")
+		})
+
+		It("fails", func() {
+			Expect(err).To(MatchError(ContainSubstring(sourceDateEpochEnv)))
+		})
+	})
+})
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package build
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"gopkg.in/yaml.v3"
+
+	"github.com/falcosecurity/falcoctl/pkg/artifact"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+// DefaultSpecFile is the name of the spec file looked up when none is given.
+const DefaultSpecFile = "falcoctl-artifact.yaml"
+
+// Spec is the declarative description of an artifact, as found in the spec file.
+//
+// Example:
+//
+//	name: cloudtrail
+//	type: plugin
+//	versionFromGitTag: true
+//	files:
+//	  - path: build/libcloudtrail-x86_64.so
+//	    platform: linux/amd64
+//	  - path: build/libcloudtrail-aarch64.so
+//	    platform: linux/arm64
+//	requirements:
+//	  - plugin_api_version:3.0.0
+//	annotations:
+//	  org.opencontainers.image.source: https://github.com/falcosecurity/plugins
+//	tags:
+//	  - latest
+//	floatingTags: true
+type Spec struct {
+	Name              string            `yaml:"name"`
+	Type              oci.ArtifactType  `yaml:"type"`
+	Version           string            `yaml:"version,omitempty"`
+	VersionFromGitTag bool              `yaml:"versionFromGitTag,omitempty"`
+	Files             []File            `yaml:"files"`
+	Dependencies      []string          `yaml:"dependencies,omitempty"`
+	Requirements      []string          `yaml:"requirements,omitempty"`
+	Annotations       map[string]string `yaml:"annotations,omitempty"`
+	Tags              []string          `yaml:"tags,omitempty"`
+	FloatingTags      bool              `yaml:"floatingTags,omitempty"`
+
+	// dir is the directory containing the spec file, relative paths are resolved against it.
+	dir string
+}
+
+// File is a file to be packaged in the artifact. Platform is required for plugins only.
+type File struct {
+	Path     string `yaml:"path"`
+	Platform string `yaml:"platform,omitempty"`
+}
+
+// LoadSpec reads and validates the spec file at the given path.
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read spec file %q: %w", path, err)
+	}
+
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal spec file %q: %w", path, err)
+	}
+	spec.dir = filepath.Dir(path)
+
+	if err := spec.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid spec file %q: %w", path, err)
+	}
+
+	return &spec, nil
+}
+
+// Validate checks that the spec describes an artifact that can be built.
+func (s *Spec) Validate() error {
+	if err := artifact.ValidateName(s.Name); err != nil {
+		return err
+	}
+
+	if err := s.Type.Set(s.Type.String()); err != nil {
+		return fmt.Errorf("invalid type %q: %w", s.Type, err)
+	}
+
+	switch {
+	case s.Version != "" && s.VersionFromGitTag:
+		return fmt.Errorf("version and versionFromGitTag are mutually exclusive")
+	case s.Version == "" && !s.VersionFromGitTag:
+		return fmt.Errorf("either version or versionFromGitTag must be set")
+	case s.Version != "":
+		if err := artifact.ValidateVersion(s.Version); err != nil {
+			return err
+		}
+	}
+
+	if len(s.Files) == 0 {
+		return fmt.Errorf("at least one file must be set")
+	}
+	if s.Type != oci.Plugin && len(s.Files) != 1 {
+		return fmt.Errorf("expecting exactly 1 file for artifacts of type %q, got %d", s.Type, len(s.Files))
+	}
+	for _, f := range s.Files {
+		if f.Path == "" {
+			return fmt.Errorf("file path cannot be empty")
+		}
+		if s.Type == oci.Plugin && f.Platform == "" {
+			return fmt.Errorf("platform must be set for file %q since artifacts of type %q are platform specific", f.Path, s.Type)
+		}
+		if s.Type != oci.Plugin && f.Platform != "" {
+			return fmt.Errorf("platform cannot be set for file %q since artifacts of type %q are not platform specific", f.Path, s.Type)
+		}
+	}
+
+	// Let the parsers used at build time check dependencies and requirements.
+	var cfg oci.ArtifactConfig
+	if err := cfg.ParseDependencies(s.Dependencies...); err != nil {
+		return err
+	}
+	if err := cfg.ParseRequirements(s.Requirements...); err != nil {
+		return err
+	}
+
+	return s.artifactOptions(s.Version).Validate()
+}
+
+// ResolveVersion returns the version of the artifact. When versionFromGitTag is set,
+// the version is taken from the git tag pointing at HEAD in the directory of the spec file,
+// stripping the leading "v" if present.
+func (s *Spec) ResolveVersion(ctx context.Context) (string, error) {
+	if !s.VersionFromGitTag {
+		return s.Version, nil
+	}
+
+	cmd := exec.CommandContext(ctx, "git", "describe", "--tags", "--exact-match")
+	cmd.Dir = s.dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("unable to get the version from the git tag in %q: %w", s.dir, err)
+	}
+
+	version := strings.TrimPrefix(strings.TrimSpace(string(out)), "v")
+	if err := artifact.ValidateVersion(version); err != nil {
+		return "", fmt.Errorf("git tag %q: %w", strings.TrimSpace(string(out)), err)
+	}
+
+	return version, nil
+}
+
+// Paths returns the paths of the files, resolved against the directory of the spec file.
+func (s *Spec) Paths() []string {
+	paths := make([]string, len(s.Files))
+	for i, f := range s.Files {
+		if filepath.IsAbs(f.Path) {
+			paths[i] = f.Path
+		} else {
+			paths[i] = filepath.Join(s.dir, f.Path)
+		}
+	}
+	return paths
+}
+
+// artifactOptions converts the spec in the same options used by the push command.
+func (s *Spec) artifactOptions(version string) *options.Artifact {
+	art := &options.Artifact{
+		ArtifactType:     s.Type,
+		Name:             s.Name,
+		Version:          version,
+		Dependencies:     s.Dependencies,
+		Requirements:     s.Requirements,
+		Tags:             s.Tags,
+		AutoFloatingTags: s.FloatingTags,
+		AnnotationSource: s.Annotations[v1.AnnotationSource],
+	}
+	if s.Type == oci.Plugin {
+		for _, f := range s.Files {
+			art.Platforms = append(art.Platforms, f.Platform)
+		}
+	}
+	return art
+}
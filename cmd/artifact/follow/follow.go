@@ -22,18 +22,26 @@ import (
 	"io"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/robfig/cron/v3"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"k8s.io/client-go/kubernetes"
+	"oras.land/oras-go/v2/registry"
 
 	"github.com/falcosecurity/falcoctl/cmd/artifact/install"
 	"github.com/falcosecurity/falcoctl/internal/config"
 	"github.com/falcosecurity/falcoctl/internal/follower"
+	"github.com/falcosecurity/falcoctl/pkg/advisory"
 	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/installed"
+	"github.com/falcosecurity/falcoctl/pkg/nodestatus"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
 	"github.com/falcosecurity/falcoctl/pkg/options"
 	"github.com/falcosecurity/falcoctl/pkg/output"
 )
@@ -41,6 +49,9 @@ import (
 const (
 	timeout = time.Second * 5
 
+	// FlagNodeArtifacts is the name of the flag to follow the artifacts desired on the node.
+	FlagNodeArtifacts = "node-artifacts"
+
 	longFollow = `This command allows you to keep up-to-date one or more given artifacts.
 It checks for updates on a periodic basis and then downloads and installs the latest version, 
 as specified by the passed tags. 
@@ -75,6 +86,13 @@ Example - Install and follow all updates from "k8saudit-rules" 0.5.x release ser
 
 Example - Install and follow "cloudtrail" plugins using a fully qualified reference:
 	falcoctl artifact follow ghcr.io/falcosecurity/plugins/ruleset/k8saudit:latest
+
+With --node-artifacts, the command also follows the artifacts set for the node by the controller in its
+"falco.org/desired-artifacts" annotation, as digest references, and reports them once installed. It requires
+--publish-node-status.
+
+Example - Follow the artifacts desired on the node by the controller:
+	falcoctl artifact follow --node-artifacts --publish-node-status --node-name worker-1
 `
 )
 
@@ -82,15 +100,19 @@ type artifactFollowOptions struct {
 	*options.Common
 	*options.Registry
 	*options.Directory
-	tmpDir        string
-	every         time.Duration
-	cron          string
-	falcoVersions string
-	versions      config.FalcoVersions
-	timeout       time.Duration
-	closeChan     chan bool
-	allowedTypes  oci.ArtifactTypeSlice
-	noVerify      bool
+	tmpDir         string
+	every          time.Duration
+	cron           string
+	falcoVersions  string
+	versions       config.FalcoVersions
+	timeout        time.Duration
+	closeChan      chan bool
+	allowedTypes   oci.ArtifactTypeSlice
+	noVerify       bool
+	conflictPolicy string
+	allowOverwrite bool
+	nodeStatus     options.NodeStatus
+	nodeArtifacts  bool
 }
 
 // NewArtifactFollowCmd returns the artifact follow command.
@@ -221,6 +243,52 @@ func NewArtifactFollowCmd(ctx context.Context, opt *options.Common) *cobra.Comma
 				}
 			}
 
+			// Override "conflict-policy" flag with viper config if not set by user.
+			f = cmd.Flags().Lookup(install.FlagConflictPolicy)
+			if f == nil {
+				// should never happen
+				return fmt.Errorf("unable to retrieve flag %s", install.FlagConflictPolicy)
+			} else if !f.Changed && viper.IsSet(config.ArtifactConflictPolicyKey) {
+				val := viper.Get(config.ArtifactConflictPolicyKey)
+				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
+					return fmt.Errorf("unable to overwrite %q flag: %w", install.FlagConflictPolicy, err)
+				}
+			}
+			if err := installed.ValidateConflictPolicy(o.conflictPolicy); err != nil {
+				return err
+			}
+
+			// Override "allow-overwrite" flag with viper config if not set by user.
+			f = cmd.Flags().Lookup(install.FlagAllowOverwrite)
+			if f == nil {
+				// should never happen
+				return fmt.Errorf("unable to retrieve flag %s", install.FlagAllowOverwrite)
+			} else if !f.Changed && viper.IsSet(config.ArtifactAllowOverwriteKey) {
+				val := viper.Get(config.ArtifactAllowOverwriteKey)
+				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
+					return fmt.Errorf("unable to overwrite %q flag: %w", install.FlagAllowOverwrite, err)
+				}
+			}
+
+			if err := o.nodeStatus.OverrideFromConfig(cmd); err != nil {
+				return err
+			}
+
+			// Override "node-artifacts" flag with viper config if not set by user.
+			f = cmd.Flags().Lookup(FlagNodeArtifacts)
+			if f == nil {
+				// should never happen
+				return fmt.Errorf("unable to retrieve flag %s", FlagNodeArtifacts)
+			} else if !f.Changed && viper.IsSet(config.ArtifactFollowNodeArtifactsKey) {
+				val := viper.Get(config.ArtifactFollowNodeArtifactsKey)
+				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
+					return fmt.Errorf("unable to overwrite %q flag: %w", FlagNodeArtifacts, err)
+				}
+			}
+			if o.nodeArtifacts && !o.nodeStatus.Publish {
+				return fmt.Errorf("--%s requires --%s, to report the installed artifacts", FlagNodeArtifacts, options.FlagPublishNodeStatus)
+			}
+
 			// Get Falco versions via HTTP endpoint
 			if err := o.retrieveFalcoVersions(ctx); err != nil {
 				return fmt.Errorf("unable to retrieve Falco versions, please check if it is running "+
@@ -252,6 +320,14 @@ Examples:
 	--%s=rulesfile --%s=plugin`, install.FlagAllowedTypes, install.FlagAllowedTypes, install.FlagAllowedTypes))
 	cmd.Flags().BoolVar(&o.noVerify, install.FlagNoVerify, false,
 		"whether this command should skip signature verification")
+	cmd.Flags().StringVar(&o.conflictPolicy, install.FlagConflictPolicy, installed.ConflictBackup,
+		fmt.Sprintf("policy applied to the installed files modified locally, allowed values: %s", strings.Join(installed.ConflictPolicies, ", ")))
+	cmd.Flags().BoolVar(&o.allowOverwrite, install.FlagAllowOverwrite, false,
+		"whether this command should overwrite the files owned by other installed artifacts")
+	o.nodeStatus.AddFlags(cmd)
+	cmd.Flags().BoolVar(&o.nodeArtifacts, FlagNodeArtifacts, false,
+		fmt.Sprintf("whether this command should also follow the artifacts set for the node by the controller in its %q annotation",
+			nodestatus.AnnotationDesired))
 	cmd.MarkFlagsMutuallyExclusive("cron", "every")
 
 	return cmd
@@ -268,7 +344,7 @@ func (o *artifactFollowOptions) RunArtifactFollow(ctx context.Context, args []st
 
 	// Set args as configured if no arg was passed
 	if len(args) == 0 {
-		if len(configuredFollower.Artifacts) == 0 {
+		if len(configuredFollower.Artifacts) == 0 && !o.nodeArtifacts {
 			return fmt.Errorf("no artifacts to follow, please configure artifacts or pass them as arguments to this command")
 		}
 		args = configuredFollower.Artifacts
@@ -284,7 +360,49 @@ func (o *artifactFollowOptions) RunArtifactFollow(ctx context.Context, args []st
 		sched = scheduledDuration{o.every}
 	}
 
+	publisher, err := o.nodeStatus.Publisher(o.Printer)
+	if err != nil {
+		return err
+	}
+
+	advisories, err := config.AdvisoriesConfig()
+	if err != nil {
+		return err
+	}
+	checker, err := advisory.NewChecker(ctx, logger, o.IndexCache.EntriesWithAdvisories(), &advisories)
+	if err != nil {
+		return err
+	}
+
 	var wg sync.WaitGroup
+	newFollower := func(ref string, sig *index.Signature, closeChan <-chan bool) (*follower.Follower, error) {
+		cfg := &follower.Config{
+			WaitGroup:         &wg,
+			Resync:            sched,
+			RulesfilesDir:     o.RulesfilesDir,
+			PluginsDir:        o.PluginsDir,
+			AssetsDir:         o.AssetsDir,
+			ArtifactReference: ref,
+			PlainHTTP:         o.PlainHTTP,
+			CloseChan:         closeChan,
+			TmpDir:            o.tmpDir,
+			FalcoVersions:     o.versions,
+			AllowedTypes:      o.allowedTypes,
+			Signature:         sig,
+			NodeStatus:        publisher,
+			StateFile:         config.InstalledFile,
+			ConflictPolicy:    o.conflictPolicy,
+			AllowOverwrite:    o.allowOverwrite,
+			Advisories:        checker,
+			Entry:             o.entry(ref),
+		}
+		fol, err := follower.New(ref, o.Printer, cfg)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the follower for ref %q: %w", ref, err)
+		}
+		return fol, nil
+	}
+
 	// For each artifact create a follower.
 	var followers = make(map[string]*follower.Follower, 0)
 	for _, a := range args {
@@ -303,23 +421,9 @@ func (o *artifactFollowOptions) RunArtifactFollow(ctx context.Context, args []st
 			sig = o.IndexCache.SignatureForIndexRef(a)
 		}
 
-		cfg := &follower.Config{
-			WaitGroup:         &wg,
-			Resync:            sched,
-			RulesfilesDir:     o.RulesfilesDir,
-			PluginsDir:        o.PluginsDir,
-			AssetsDir:         o.AssetsDir,
-			ArtifactReference: ref,
-			PlainHTTP:         o.PlainHTTP,
-			CloseChan:         o.closeChan,
-			TmpDir:            o.tmpDir,
-			FalcoVersions:     o.versions,
-			AllowedTypes:      o.allowedTypes,
-			Signature:         sig,
-		}
-		fol, err := follower.New(ref, o.Printer, cfg)
+		fol, err := newFollower(ref, sig, o.closeChan)
 		if err != nil {
-			return fmt.Errorf("unable to create the follower for ref %q: %w", ref, err)
+			return err
 		}
 		wg.Add(1)
 		followers[ref] = fol
@@ -330,6 +434,28 @@ func (o *artifactFollowOptions) RunArtifactFollow(ctx context.Context, args []st
 		go f.Follow(ctx)
 	}
 
+	if o.nodeArtifacts {
+		client, err := nodestatus.NewClient("")
+		if err != nil {
+			return err
+		}
+		// The digest references have been verified by the controller, and have no index signature.
+		start := func(ref string, closeChan <-chan bool) error {
+			fol, err := newFollower(ref, nil, closeChan)
+			if err != nil {
+				return err
+			}
+			wg.Add(1)
+			go fol.Follow(ctx)
+			return nil
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			o.followNodeArtifacts(ctx, client, sched, start)
+		}()
+	}
+
 	// Wait until we receive a signal to be terminated
 	<-ctx.Done()
 
@@ -352,14 +478,77 @@ func (o *artifactFollowOptions) RunArtifactFollow(ctx context.Context, args []st
 		logger.Info("Timed out waiting for followers to exit")
 	}
 
+	// Publish the last updates, if any, before exiting.
+	if err := publisher.Flush(context.WithoutCancel(ctx)); err != nil {
+		logger.Warn("Unable to publish node status", logger.Args("reason", err.Error()))
+	}
+
 	return nil
 }
 
+// followNodeArtifacts follows the artifacts set for the node by the controller in its desired artifacts annotation,
+// as digest references, until the context is canceled. The annotation is read at every resync: a follower is started
+// for each new reference, and the ones of the references no longer desired are stopped, leaving their files in place.
+func (o *artifactFollowOptions) followNodeArtifacts(ctx context.Context, client kubernetes.Interface, sched cron.Schedule,
+	start func(ref string, closeChan <-chan bool) error) {
+	logger := o.Printer.Logger
+	running := make(map[string]chan bool)
+	for {
+		refs, err := nodestatus.DesiredRefs(ctx, client, o.nodeStatus.NodeName)
+		if err != nil {
+			logger.Warn("Unable to get the artifacts desired on the node", logger.Args("node", o.nodeStatus.NodeName, "reason", err.Error()))
+		} else {
+			for ref, closeChan := range running {
+				if !slices.Contains(refs, ref) {
+					logger.Info("Stopping follower of artifact no longer desired on the node", logger.Args("artifact", ref))
+					close(closeChan)
+					delete(running, ref)
+				}
+			}
+			for _, ref := range refs {
+				if _, ok := running[ref]; ok {
+					continue
+				}
+				logger.Info("Starting follower of artifact desired on the node", logger.Args("artifact", ref))
+				closeChan := make(chan bool)
+				if err := start(ref, closeChan); err != nil {
+					logger.Warn("Unable to follow artifact desired on the node", logger.Args("artifact", ref, "reason", err.Error()))
+					continue
+				}
+				running[ref] = closeChan
+			}
+		}
+
+		now := time.Now()
+		select {
+		case <-ctx.Done():
+			for _, closeChan := range running {
+				close(closeChan)
+			}
+			return
+		case <-time.After(sched.Next(now).Sub(now)):
+		}
+	}
+}
+
+// entry returns the index entry of the repository of ref, if any.
+func (o *artifactFollowOptions) entry(ref string) *index.Entry {
+	parsedRef, err := registry.ParseReference(ref)
+	if err != nil {
+		return nil
+	}
+	entry, _ := o.IndexCache.EntryByRepository(parsedRef.Registry, parsedRef.Repository)
+	return entry
+}
+
 func (o *artifactFollowOptions) retrieveFalcoVersions(ctx context.Context) error {
 	_, err := url.ParseRequestURI(o.falcoVersions)
 	if err != nil {
 		return fmt.Errorf("unable to parse URI: %w", err)
 	}
+	if offline.Enabled() {
+		return offline.Errorf("Falco versions from %q", o.falcoVersions)
+	}
 
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, o.falcoVersions, http.NoBody)
 	if err != nil {
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package follow
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	"github.com/falcosecurity/falcoctl/pkg/nodestatus"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+func TestFollowNodeArtifacts(t *testing.T) {
+	const (
+		nodeName = "node-1"
+		rules    = "ghcr.io/falcosecurity/rules/falco-rules@sha256:aaa"
+		plugin   = "ghcr.io/falcosecurity/plugins/k8saudit@sha256:bbb"
+	)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	client := fake.NewSimpleClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        nodeName,
+			Annotations: map[string]string{nodestatus.AnnotationDesired: `{"falco/rules":["` + rules + `","` + plugin + `"]}`},
+		},
+	})
+	o := artifactFollowOptions{
+		Common:     &options.Common{Printer: output.NewPrinter(pterm.LogLevelDebug, pterm.LogFormatterJSON, os.Stdout)},
+		nodeStatus: options.NodeStatus{NodeName: nodeName},
+	}
+
+	var mu sync.Mutex
+	started := make(map[string]<-chan bool)
+	startedRefs := func() map[string]<-chan bool {
+		mu.Lock()
+		defer mu.Unlock()
+		result := make(map[string]<-chan bool, len(started))
+		for ref, closeChan := range started {
+			result[ref] = closeChan
+		}
+		return result
+	}
+	start := func(ref string, closeChan <-chan bool) error {
+		mu.Lock()
+		defer mu.Unlock()
+		started[ref] = closeChan
+		return nil
+	}
+	done := make(chan struct{})
+	go func() {
+		o.followNodeArtifacts(ctx, client, scheduledDuration{10 * time.Millisecond}, start)
+		close(done)
+	}()
+
+	// A follower is started for each desired reference.
+	require.Eventually(t, func() bool { return len(startedRefs()) == 2 }, 5*time.Second, 10*time.Millisecond)
+
+	// The follower of a reference no longer desired is stopped, the other one keeps running.
+	node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	node.Annotations[nodestatus.AnnotationDesired] = `{"falco/rules":["` + rules + `"]}`
+	_, err = client.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	refs := startedRefs()
+	require.Eventually(t, func() bool { return closed(refs[plugin]) }, 5*time.Second, 10*time.Millisecond)
+	assert.False(t, closed(refs[rules]))
+	assert.Len(t, startedRefs(), 2)
+
+	// The remaining followers are stopped with the context.
+	cancel()
+	<-done
+	assert.True(t, closed(refs[rules]))
+}
+
+func closed(c <-chan bool) bool {
+	select {
+	case <-c:
+		return true
+	default:
+		return false
+	}
+}
@@ -24,12 +24,20 @@ import (
 	"github.com/spf13/cobra"
 	"oras.land/oras-go/v2/registry"
 
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
 	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
 	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
 	"github.com/falcosecurity/falcoctl/pkg/options"
 	"github.com/falcosecurity/falcoctl/pkg/output"
 )
 
+const longInfo = `Retrieve all available versions of a given artifact.
+
+When the index entry of the artifact carries a rule catalog, generated by "falcoctl index generate",
+the rules contained in the artifact are listed too. When it lists the released versions, they are
+listed with their digest, release date, requirements and changelog, deprecated ones being marked.
+`
+
 type artifactInfoOptions struct {
 	*options.Common
 	*options.Registry
@@ -46,7 +54,7 @@ func NewArtifactInfoCmd(ctx context.Context, opt *options.Common) *cobra.Command
 		Use:                   "info [ref1 [ref2 ...]] [flags]",
 		DisableFlagsInUseLine: true,
 		Short:                 "Retrieve all available versions of a given artifact",
-		Long:                  "Retrieve all available versions of a given artifact",
+		Long:                  longInfo,
 		Args:                  cobra.MinimumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return o.RunArtifactInfo(ctx, args)
@@ -59,7 +67,7 @@ func NewArtifactInfoCmd(ctx context.Context, opt *options.Common) *cobra.Command
 }
 
 func (o *artifactInfoOptions) RunArtifactInfo(ctx context.Context, args []string) error {
-	var data [][]string
+	var data, rules, versions [][]string
 	logger := o.Printer.Logger
 
 	client, err := ociutils.Client(true)
@@ -72,15 +80,21 @@ func (o *artifactInfoOptions) RunArtifactInfo(ctx context.Context, args []string
 		var ref string
 		parsedRef, err := registry.ParseReference(name)
 		if err != nil {
-			entry, ok := o.IndexCache.MergedIndexes.EntryByName(name)
+			entry, ok := o.IndexCache.EntryByName(name)
 			if !ok {
 				logger.Warn("Cannot find artifact, skipping", logger.Args("name", name))
 				continue
 			}
 			ref = fmt.Sprintf("%s/%s", entry.Registry, entry.Repository)
+			rules = append(rules, ruleRows(entry)...)
+			versions = append(versions, versionRows(entry)...)
 		} else {
 			parsedRef.Reference = ""
 			ref = parsedRef.String()
+			if entry, ok := o.IndexCache.EntryByRepository(parsedRef.Registry, parsedRef.Repository); ok {
+				rules = append(rules, ruleRows(entry)...)
+				versions = append(versions, versionRows(entry)...)
+			}
 		}
 
 		repo, err := repository.NewRepository(ref,
@@ -105,12 +119,46 @@ func (o *artifactInfoOptions) RunArtifactInfo(ctx context.Context, args []string
 
 	// Print the table header + data only if there is data.
 	if len(data) > 0 {
-		return o.Printer.PrintTable(output.ArtifactInfo, data)
+		if err := o.Printer.PrintTable(output.ArtifactInfo, data); err != nil {
+			return err
+		}
+	}
+	if len(versions) > 0 {
+		if err := o.Printer.PrintTable(output.ArtifactVersions, versions); err != nil {
+			return err
+		}
+	}
+	if len(rules) > 0 {
+		return o.Printer.PrintTable(output.ArtifactRules, rules)
 	}
 
 	return nil
 }
 
+// ruleRows returns the rows describing the rules in the catalog of the entry.
+func ruleRows(entry *index.Entry) [][]string {
+	rows := make([][]string, 0, len(entry.Rules))
+	for _, r := range entry.Rules {
+		rows = append(rows, []string{entry.Name, r.Name, r.Priority, r.Source, strings.Join(r.Tags, ", ")})
+	}
+	return rows
+}
+
+// versionRows returns the rows describing the versions listed by the entry.
+func versionRows(entry *index.Entry) [][]string {
+	rows := make([][]string, 0, len(entry.Versions))
+	for i := range entry.Versions {
+		v := &entry.Versions[i]
+		version := v.Version
+		if v.Deprecated {
+			version += " (deprecated)"
+		}
+		// Only the first line of the changelog text fits in the table.
+		rows = append(rows, []string{entry.Name, version, v.Digest, v.ReleaseDate, v.Requirements, v.ChangelogSummary()})
+	}
+	return rows
+}
+
 func filterOutSigTags(tags []string) []string {
 	// Iterate the slice in reverse to avoid index shifting when deleting
 	for i := len(tags) - 1; i >= 0; i-- {
@@ -27,4 +27,10 @@ const (
 
 	// FlagNoVerify is the name of the flag to disable signature verification.
 	FlagNoVerify = "no-verify"
+
+	// FlagConflictPolicy is the name of the flag setting the policy applied to the installed files modified locally.
+	FlagConflictPolicy = "conflict-policy"
+
+	// FlagAllowOverwrite is the name of the flag allowing to overwrite the files owned by other artifacts.
+	FlagAllowOverwrite = "allow-overwrite"
 )
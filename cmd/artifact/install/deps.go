@@ -21,6 +21,7 @@ import (
 
 	"github.com/blang/semver"
 
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
 )
 
@@ -29,7 +30,7 @@ type depsMapType map[string]*depInfo
 
 var (
 	// ErrCannotSatisfyDependencies is the error returned when we cannot correctly resolve dependencies.
-	ErrCannotSatisfyDependencies = errors.New("cannot satisfy dependencies")
+	ErrCannotSatisfyDependencies = errdefs.Wrap(errdefs.ErrDependencyConflict, errors.New("cannot satisfy dependencies"))
 )
 
 type depInfo struct {
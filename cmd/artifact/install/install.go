@@ -22,17 +22,24 @@ import (
 	"path/filepath"
 	"runtime"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"oras.land/oras-go/v2/registry"
 
 	"github.com/falcosecurity/falcoctl/internal/config"
 	"github.com/falcosecurity/falcoctl/internal/signature"
 	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/advisory"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/installed"
+	"github.com/falcosecurity/falcoctl/pkg/nodestatus"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
 	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
 	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/tracing"
 )
 
 const (
@@ -80,6 +87,11 @@ type artifactInstallOptions struct {
 	platformOS   string // OS portion of parsed platform string
 	resolveDeps  bool
 	noVerify     bool
+	// conflictPolicy is the policy applied to the installed files modified locally.
+	conflictPolicy string
+	// allowOverwrite allows to overwrite the files owned by other artifacts.
+	allowOverwrite bool
+	nodeStatus     options.NodeStatus
 }
 
 // NewArtifactInstallCmd returns the artifact install command.
@@ -169,6 +181,31 @@ func NewArtifactInstallCmd(ctx context.Context, opt *options.Common) *cobra.Comm
 				}
 			}
 
+			f = cmd.Flags().Lookup(FlagConflictPolicy)
+			if f == nil {
+				// should never happen
+				return fmt.Errorf("unable to retrieve flag %q", FlagConflictPolicy)
+			} else if !f.Changed && viper.IsSet(config.ArtifactConflictPolicyKey) {
+				val := viper.Get(config.ArtifactConflictPolicyKey)
+				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
+					return fmt.Errorf("unable to overwrite %q flag: %w", FlagConflictPolicy, err)
+				}
+			}
+			if err := installed.ValidateConflictPolicy(o.conflictPolicy); err != nil {
+				return err
+			}
+
+			f = cmd.Flags().Lookup(FlagAllowOverwrite)
+			if f == nil {
+				// should never happen
+				return fmt.Errorf("unable to retrieve flag %q", FlagAllowOverwrite)
+			} else if !f.Changed && viper.IsSet(config.ArtifactAllowOverwriteKey) {
+				val := viper.Get(config.ArtifactAllowOverwriteKey)
+				if err := cmd.Flags().Set(f.Name, fmt.Sprintf("%v", val)); err != nil {
+					return fmt.Errorf("unable to overwrite %q flag: %w", FlagAllowOverwrite, err)
+				}
+			}
+
 			// Parse "platform" into OS and Arch
 			if len(o.platform) > 0 {
 				parts := strings.Split(o.platform, "/")
@@ -178,9 +215,11 @@ func NewArtifactInstallCmd(ctx context.Context, opt *options.Common) *cobra.Comm
 				o.platformOS, o.platformArch = parts[0], parts[1]
 			}
 
-			return nil
+			return o.nodeStatus.OverrideFromConfig(cmd)
 		},
-		RunE: func(cmd *cobra.Command, args []string) error {
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx, span := tracing.Start(ctx, "artifact install")
+			defer func() { tracing.End(span, err) }()
 			return o.RunArtifactInstall(ctx, args)
 		},
 	}
@@ -199,6 +238,11 @@ Examples:
 		"whether this command should resolve dependencies or not")
 	cmd.Flags().BoolVar(&o.noVerify, FlagNoVerify, false,
 		"whether this command should skip signature verification")
+	cmd.Flags().StringVar(&o.conflictPolicy, FlagConflictPolicy, installed.ConflictBackup,
+		fmt.Sprintf("policy applied to the installed files modified locally, allowed values: %s", strings.Join(installed.ConflictPolicies, ", ")))
+	cmd.Flags().BoolVar(&o.allowOverwrite, FlagAllowOverwrite, false,
+		"whether this command should overwrite the files owned by other installed artifacts")
+	o.nodeStatus.AddFlags(cmd)
 
 	return cmd
 }
@@ -220,6 +264,20 @@ func (o *artifactInstallOptions) RunArtifactInstall(ctx context.Context, args []
 		args = configuredInstaller.Artifacts
 	}
 
+	publisher, err := o.nodeStatus.Publisher(o.Printer)
+	if err != nil {
+		return err
+	}
+
+	advisories, err := config.AdvisoriesConfig()
+	if err != nil {
+		return err
+	}
+	checker, err := advisory.NewChecker(ctx, logger, o.IndexCache.EntriesWithAdvisories(), &advisories)
+	if err != nil {
+		return err
+	}
+
 	// Create temp dir where to put pulled artifacts
 	tmpDir, err := os.MkdirTemp("", "falcoctl")
 	if err != nil {
@@ -302,19 +360,42 @@ func (o *artifactInstallOptions) RunArtifactInstall(ctx context.Context, args []
 			return err
 		}
 
+		// The digests listed by the index are an integrity check independent from the signatures.
+		if version, ok := o.IndexCache.VersionForRef(resolvedRef); ok {
+			if version.Deprecated {
+				logger.Warn("Installing a deprecated version", logger.Args("ref", resolvedRef))
+			}
+			if version.Digest != "" && version.Digest != result.RootDigest {
+				return errdefs.Errorf(errdefs.ErrDigestMismatch, "digest %s of %q does not match the digest %s listed by the index",
+					result.RootDigest, resolvedRef, version.Digest)
+			}
+		}
+		for _, v := range o.IndexCache.NewerVersionsForRef(resolvedRef) {
+			logger.Info("Newer version listed by the index", logger.Args("ref", resolvedRef, "version", v.Version,
+				"release date", v.ReleaseDate, "changelog", v.ChangelogSummary()))
+		}
+
+		repo, err := utils.RepositoryFromRef(resolvedRef)
+		if err != nil {
+			return err
+		}
+		// The config of the pulled digest, which is the one of the installed content even if the tag moved.
+		digestRef := fmt.Sprintf("%s@%s", repo, result.RootDigest)
+		artifactConfig, err := puller.ArtifactConfig(ctx, digestRef, o.platformOS, o.platformArch)
+		if err != nil {
+			return err
+		}
+		name, version := artifactNameVersion(resolvedRef, artifactConfig)
+		if err := checker.Check(ctx, logger, resolvedRef, name, version); err != nil {
+			return err
+		}
+
 		sig := signatures[resolvedRef]
 
 		if sig != nil && !o.noVerify {
-			repo, err := utils.RepositoryFromRef(resolvedRef)
-			if err != nil {
-				return err
-			}
-
 			// In order to prevent TOCTOU issues we'll perform signature verification after we complete a pull
 			// and obtained a digest but before files are written to disk. This way we ensure that we're verifying
 			// the exact digest that we just pulled, even if the tag gets overwritten in the meantime.
-			digestRef := fmt.Sprintf("%s@%s", repo, result.RootDigest)
-
 			logger.Info("Verifying signature for artifact", logger.Args("digest", digestRef))
 			err = signature.Verify(ctx, digestRef, sig)
 			if err != nil {
@@ -353,8 +434,12 @@ func (o *artifactInstallOptions) RunArtifactInstall(ctx context.Context, args []
 		if err != nil {
 			return err
 		}
-		// Extract artifact and move it to its destination directory
-		_, err = utils.ExtractTarGz(ctx, f, destDir, 0)
+		// Extract artifact and move it to its destination directory, protecting the files modified locally.
+		extractDir, err := os.MkdirTemp(tmpDir, "extract-")
+		if err != nil {
+			return err
+		}
+		extracted, err := utils.ExtractTarGz(ctx, f, extractDir, 0)
 		if err != nil {
 			return fmt.Errorf("cannot extract %q to %q: %w", result.Filename, destDir, err)
 		}
@@ -364,11 +449,86 @@ func (o *artifactInstallOptions) RunArtifactInstall(ctx context.Context, args []
 			return err
 		}
 
+		// The state is needed to protect the files from being overwritten by the next installations.
+		artifact := installed.Artifact{
+			Ref:          resolvedRef,
+			Name:         name,
+			Version:      version,
+			Type:         result.Type.String(),
+			Digest:       result.RootDigest,
+			Dependencies: artifactConfig.Dependencies,
+		}
+		if err := o.installFiles(artifact, extracted, extractDir, destDir); err != nil {
+			return err
+		}
+
 		if o.Printer.Spinner != nil {
 			_ = o.Printer.Spinner.Stop()
 		}
 		logger.Info("Artifact successfully installed", logger.Args("name", resolvedRef, "type", result.Type, "digest", result.Digest, "directory", destDir))
+		publisher.SetArtifact(ctx, nodestatus.Artifact{
+			Ref:         resolvedRef,
+			Type:        result.Type.String(),
+			Version:     result.Config.Version,
+			Digest:      result.Digest,
+			RootDigest:  result.RootDigest,
+			InstalledAt: time.Now(),
+		})
 	}
 
+	if err := publisher.Flush(ctx); err != nil {
+		logger.Warn("Unable to publish node status", logger.Args("reason", err.Error()))
+	}
 	return nil
 }
+
+// installFiles moves the files of the artifact extracted in extractDir to destDir, checking the files owned by
+// other artifacts and applying the conflict policy to the files modified locally, and records the artifact with
+// its installed files in the state file, while holding its lock.
+func (o *artifactInstallOptions) installFiles(artifact installed.Artifact, extracted []string, extractDir, destDir string) error {
+	logger := o.Printer.Logger
+
+	destDir, err := filepath.Abs(destDir)
+	if err != nil {
+		return err
+	}
+	moves := make([]installed.Move, 0, len(extracted))
+	for _, path := range extracted {
+		relPath, err := filepath.Rel(extractDir, path)
+		if err != nil {
+			return err
+		}
+		moves = append(moves, installed.Move{Src: path, Dst: filepath.Join(destDir, relPath)})
+	}
+
+	install := func(installer *installed.Installer) (installed.Artifact, error) {
+		files, conflicts, err := installer.Install(artifact.Ref, moves)
+		for i := range conflicts {
+			if conflicts[i].Owner != "" {
+				logger.Warn("File owned by another artifact", logger.Args("path", conflicts[i].Path, "owner", conflicts[i].Owner))
+			}
+			if conflicts[i].Policy != "" {
+				logger.Warn("File modified locally", logger.Args("path", conflicts[i].Path, "policy", conflicts[i].Policy, "saved", conflicts[i].Saved))
+			}
+		}
+		artifact.InstalledAt = time.Now().UTC()
+		artifact.Files = files
+		return artifact, err
+	}
+	return installed.Transaction(config.InstalledFile, o.conflictPolicy, o.allowOverwrite, install)
+}
+
+// artifactNameVersion returns the name and the version of an artifact from its config, falling back to
+// the repository name and to the tag of its reference.
+func artifactNameVersion(ref string, artifactConfig *oci.ArtifactConfig) (name, version string) {
+	name, version = artifactConfig.Name, artifactConfig.Version
+	if name == "" {
+		name, _ = utils.NameFromRef(ref)
+	}
+	if version == "" {
+		if parsedRef, err := registry.ParseReference(ref); err == nil && parsedRef.ValidateReferenceAsTag() == nil {
+			version = parsedRef.Reference
+		}
+	}
+	return name, version
+}
@@ -59,13 +59,18 @@ func NewArtifactListCmd(ctx context.Context, opt *options.Common) *cobra.Command
 }
 
 func (o *artifactListOptions) RunArtifactList(_ context.Context, _ []string) error {
+	merged, err := o.IndexCache.Merged()
+	if err != nil {
+		return err
+	}
+
 	var data [][]string
-	for _, entry := range o.IndexCache.MergedIndexes.Entries {
+	for _, entry := range merged.Entries {
 		if o.artifactType != "" && o.artifactType != oci.ArtifactType(entry.Type) {
 			continue
 		}
 
-		indexName := o.IndexCache.MergedIndexes.IndexByEntry(entry).Name
+		indexName := merged.IndexByEntry(entry).Name
 		if o.index != "" && o.index != indexName {
 			continue
 		}
@@ -38,6 +38,7 @@ Global Flags:
       --config string       config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
       --log-format string   Set formatting for logs (color, text, json) (default "color")
       --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --offline             Use only local sources and fail immediately on anything that would need the network
 `
 
 var help = `Get the manifest layer of an artifact
@@ -54,6 +55,7 @@ Global Flags:
       --config string       config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
       --log-format string   Set formatting for logs (color, text, json) (default "color")
       --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --offline             Use only local sources and fail immediately on anything that would need the network
 `
 
 var _ = Describe("Manifest", func() {
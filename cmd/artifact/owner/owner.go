@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/installed"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const longOwner = `Print the installed artifacts owning the given files.
+
+A file is owned by the artifact that last wrote it through "falcoctl artifact install" or
+"falcoctl artifact follow". The command exits with a non-zero code when a file is not owned by any artifact.
+
+Example - Find the artifact owning a rules file:
+	falcoctl artifact owner /etc/falco/falco_rules.yaml
+`
+
+type artifactOwnerOptions struct {
+	*options.Common
+	stateFile string
+}
+
+// NewArtifactOwnerCmd returns the artifact owner command.
+func NewArtifactOwnerCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := artifactOwnerOptions{
+		Common:    opt,
+		stateFile: config.InstalledFile,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "owner path [path...] [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Print the installed artifacts owning the given files",
+		Long:                  longOwner,
+		Args:                  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunArtifactOwner(ctx, args)
+		},
+	}
+
+	return cmd
+}
+
+// RunArtifactOwner executes the business logic for the artifact owner command.
+func (o *artifactOwnerOptions) RunArtifactOwner(_ context.Context, args []string) error {
+	state, err := installed.Load(o.stateFile)
+	if err != nil {
+		return err
+	}
+
+	var data [][]string
+	var missing []string
+	for _, arg := range args {
+		// The files are recorded with their absolute path.
+		path, err := filepath.Abs(arg)
+		if err != nil {
+			return err
+		}
+		a, ok := state.Owner(path)
+		if !ok {
+			missing = append(missing, arg)
+			continue
+		}
+		data = append(data, []string{path, a.Name, a.Version, a.Type, a.Ref})
+	}
+
+	if len(data) > 0 {
+		if err := o.Printer.PrintTable(output.ArtifactOwner, data); err != nil {
+			return err
+		}
+	}
+	if len(missing) > 0 {
+		return errdefs.Errorf(errdefs.ErrNotFound, "no installed artifact owns %s", strings.Join(missing, ", "))
+	}
+	return nil
+}
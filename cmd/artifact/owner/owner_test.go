@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package owner
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/installed"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+func TestOwner(t *testing.T) {
+	dir := t.TempDir()
+	rulesFile := filepath.Join(dir, "falco_rules.yaml")
+	macrosFile := filepath.Join(dir, "macros.yaml")
+
+	state := &installed.State{}
+	state.Set(installed.Artifact{Ref: "ghcr.io/falcosecurity/rules/falco-rules:3", Name: "falco-rules", Version: "3.1.0",
+		Type: "rulesfile", Files: []installed.File{{Path: rulesFile}, {Path: macrosFile}}})
+	state.Set(installed.Artifact{Ref: "ghcr.io/example/rules/my-rules:1", Name: "my-rules", Version: "1.0.0",
+		Type: "rulesfile", Files: []installed.File{{Path: macrosFile}}})
+	stateFile := filepath.Join(dir, "installed.yaml")
+	require.NoError(t, state.Write(stateFile))
+
+	var buf bytes.Buffer
+	opt := options.NewOptions()
+	opt.Initialize(options.WithWriter(&buf))
+	o := &artifactOwnerOptions{Common: opt, stateFile: stateFile}
+
+	require.NoError(t, o.RunArtifactOwner(context.Background(), []string{rulesFile, macrosFile}))
+	assert.Contains(t, buf.String(), "falco-rules")
+	assert.Contains(t, buf.String(), "ghcr.io/example/rules/my-rules:1")
+
+	buf.Reset()
+	err := o.RunArtifactOwner(context.Background(), []string{rulesFile, filepath.Join(dir, "missing.yaml")})
+	assert.True(t, errors.Is(err, errdefs.ErrNotFound))
+	assert.Contains(t, err.Error(), "missing.yaml")
+	assert.Contains(t, buf.String(), "falco-rules")
+}
@@ -18,15 +18,29 @@ package search
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/spf13/cobra"
 
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
 	"github.com/falcosecurity/falcoctl/pkg/options"
 	"github.com/falcosecurity/falcoctl/pkg/output"
 )
 
 const (
+	longSearch = `Search an artifact by keywords, or by the rules it contains.
+
+The --rule, --tag, --mitre and --priority flags query the rule catalogs carried by the index entries,
+generated by "falcoctl index generate", and list the matching rules. When keywords are given too,
+only the artifacts matching them are queried.
+
+Example - Search the rules detecting a MITRE ATT&CK technique, or one of its sub-techniques:
+	falcoctl artifact search --mitre T1059
+
+Example - Search the rules tagged both "container" and "shell" with priority warning or above:
+	falcoctl artifact search --tag container --tag shell --priority warning
+`
 	defaultMinScore = 0.65
 	// CommandName name of the command. It has to be the first word in the use line.
 	CommandName = "search"
@@ -36,13 +50,22 @@ type artifactSearchOptions struct {
 	*options.Common
 	minScore     float64
 	artifactType oci.ArtifactType
+	rules        index.RuleQuery
 }
 
-func (o *artifactSearchOptions) Validate() error {
+func (o *artifactSearchOptions) Validate(args []string) error {
 	if o.minScore <= 0 || o.minScore > 1 {
 		return fmt.Errorf("minScore must be a number within (0,1]")
 	}
 
+	if len(args) == 0 && o.rules.IsEmpty() {
+		return fmt.Errorf("at least one keyword or one of --rule, --tag, --mitre and --priority is required")
+	}
+
+	if o.rules.Priority != "" {
+		return index.ValidatePriority(o.rules.Priority)
+	}
+
 	return nil
 }
 
@@ -56,10 +79,9 @@ func NewArtifactSearchCmd(ctx context.Context, opt *options.Common) *cobra.Comma
 		Use:                   fmt.Sprintf("%s [keyword1 [keyword2 ...]] [flags]", CommandName),
 		DisableFlagsInUseLine: true,
 		Short:                 "Search an artifact by keywords",
-		Long:                  "Search an artifact by keywords",
-		Args:                  cobra.MinimumNArgs(1),
+		Long:                  longSearch,
 		PreRunE: func(cmd *cobra.Command, args []string) error {
-			return o.Validate()
+			return o.Validate(args)
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			return o.RunArtifactSearch(ctx, args)
@@ -70,19 +92,50 @@ func NewArtifactSearchCmd(ctx context.Context, opt *options.Common) *cobra.Comma
 		"the minimum score used to match artifact names with search keywords")
 
 	cmd.Flags().Var(&o.artifactType, "type", `Only search artifacts with a specific type. Allowed values: "rulesfile", "plugin", "asset"`)
+	cmd.Flags().StringVar(&o.rules.Name, "rule", "", "Search the rules whose name contains the given string")
+	cmd.Flags().StringSliceVar(&o.rules.Tags, "tag", nil, "Search the rules having all the given tags")
+	cmd.Flags().StringSliceVar(&o.rules.Mitre, "mitre", nil,
+		"Search the rules tagged with any of the given MITRE ATT&CK techniques, or one of their sub-techniques")
+	cmd.Flags().StringVar(&o.rules.Priority, "priority", "", "Search the rules with the given priority or a more severe one")
 
 	return cmd
 }
 
 func (o *artifactSearchOptions) RunArtifactSearch(_ context.Context, args []string) error {
-	resultEntries := o.IndexCache.MergedIndexes.SearchByKeywords(o.minScore, args...)
+	var resultEntries []*index.Entry
+	if len(args) > 0 {
+		resultEntries = o.IndexCache.SearchByKeywords(o.minScore, args...)
+	} else {
+		merged, err := o.IndexCache.Merged()
+		if err != nil {
+			return err
+		}
+		resultEntries = merged.Entries
+	}
 
-	var data [][]string
+	var entries []*index.Entry
 	for _, entry := range resultEntries {
 		if o.artifactType != "" && o.artifactType != oci.ArtifactType(entry.Type) {
 			continue
 		}
-		indexName := o.IndexCache.MergedIndexes.IndexByEntry(entry).Name
+		entries = append(entries, entry)
+	}
+
+	var data [][]string
+	if !o.rules.IsEmpty() {
+		selected := index.New("")
+		for _, entry := range entries {
+			selected.Upsert(entry)
+		}
+		for _, match := range selected.SearchRules(&o.rules) {
+			indexName := o.IndexCache.IndexName(match.Entry)
+			data = append(data, []string{indexName, match.Entry.Name, match.Rule.Name, match.Rule.Priority, strings.Join(match.Rule.Mitre, ", ")})
+		}
+		return o.Printer.PrintTable(output.RuleSearch, data)
+	}
+
+	for _, entry := range entries {
+		indexName := o.IndexCache.IndexName(entry)
 		row := []string{indexName, entry.Name, entry.Type, entry.Registry, entry.Repository}
 		data = append(data, row)
 	}
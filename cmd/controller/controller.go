@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/cobra"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	pkgcontroller "github.com/falcosecurity/falcoctl/pkg/controller"
+	"github.com/falcosecurity/falcoctl/pkg/index/cache"
+	"github.com/falcosecurity/falcoctl/pkg/nodestatus"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const longController = `Reconcile the FalcoArtifactSet resources of a cluster.
+
+A FalcoArtifactSet declares the Falco artifacts to be deployed: their names in the configured indexes
+or their references, their versions as tags or semver ranges, the signature policy and the destination.
+The controller resolves the artifacts to digests, verifies their signatures, then delivers them to:
+  - a ConfigMap holding the files of the rulesfile artifacts;
+  - the nodes matching the node selector, through the "falco.org/desired-artifacts" annotation read by
+    the node-local falcoctl instances, which report the installed artifacts in the "falco.org/artifacts"
+    annotation.
+Artifacts are delivered only once all of them are resolved and verified. The resolved digests, the
+verification results and the errors are reported in the status of the resource, and the resources are
+reconciled again every resync interval to pick up the new versions matching their ranges.
+
+Example - Print the CustomResourceDefinition of the FalcoArtifactSet resource:
+	falcoctl controller --print-crd | kubectl apply -f -
+
+Example - Reconcile the FalcoArtifactSet resources of the falco namespace:
+	falcoctl controller --namespace falco
+
+Example - A FalcoArtifactSet writing the rules to a ConfigMap:
+	apiVersion: falco.org/v1alpha1
+	kind: FalcoArtifactSet
+	metadata:
+	  name: rules
+	  namespace: falco
+	spec:
+	  artifacts:
+	    - name: falco-rules
+	      version: ">=3.0.0 <4.0.0"
+	  signature:
+	    mode: Required
+	  destination:
+	    configMap:
+	      name: falco-rules
+`
+
+type controllerOptions struct {
+	*options.Common
+	*options.Registry
+	namespace  string
+	kubeconfig string
+	resync     time.Duration
+	printCRD   bool
+}
+
+// NewControllerCmd returns the controller command.
+func NewControllerCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := controllerOptions{
+		Common:   opt,
+		Registry: &options.Registry{},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "controller [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Reconcile the FalcoArtifactSet resources of a cluster",
+		Long:                  longController,
+		Args:                  cobra.NoArgs,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			opt.Initialize()
+			if o.printCRD {
+				return nil
+			}
+			if err := config.Load(opt.ConfigFile); err != nil {
+				return err
+			}
+			indexes, err := config.Indexes()
+			if err != nil {
+				return err
+			}
+			indexCache, err := cache.NewFromConfig(ctx, config.IndexesFile, config.IndexesDir, indexes)
+			if err != nil {
+				return err
+			}
+			opt.Initialize(options.WithIndexCache(indexCache))
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunController(ctx)
+		},
+	}
+
+	o.Registry.AddFlags(cmd)
+	cmd.Flags().StringVarP(&o.namespace, "namespace", "n", "", "Namespace of the reconciled resources (default: all namespaces)")
+	cmd.Flags().StringVar(&o.kubeconfig, "kubeconfig", "", "Kubernetes config (default: the in-cluster config)")
+	cmd.Flags().DurationVar(&o.resync, "resync", pkgcontroller.DefaultResync, "Interval between two reconciliations of the same resource")
+	cmd.Flags().BoolVar(&o.printCRD, "print-crd", false, "Print the CustomResourceDefinition of the FalcoArtifactSet resource and exit")
+
+	return cmd
+}
+
+// RunController implements the controller command.
+func (o *controllerOptions) RunController(ctx context.Context) error {
+	if o.printCRD {
+		o.Printer.DefaultText.Print(string(pkgcontroller.CRD))
+		return nil
+	}
+	if o.resync <= 0 {
+		return fmt.Errorf("invalid resync interval %s, must be positive", o.resync)
+	}
+
+	cfg, err := nodestatus.RestConfig(o.kubeconfig)
+	if err != nil {
+		return err
+	}
+	dyn, err := dynamic.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("unable to create Kubernetes client: %w", err)
+	}
+	client, err := kubernetes.NewForConfig(cfg)
+	if err != nil {
+		return fmt.Errorf("unable to create Kubernetes client: %w", err)
+	}
+	registryClient, err := ociutils.Client(true)
+	if err != nil {
+		return err
+	}
+
+	indexes, err := o.IndexCache.Merged()
+	if err != nil {
+		return err
+	}
+
+	c, err := pkgcontroller.New(o.Printer, &pkgcontroller.Config{
+		Dynamic:        dyn,
+		Client:         client,
+		Indexes:        indexes,
+		RegistryClient: registryClient,
+		PlainHTTP:      o.PlainHTTP,
+	})
+	if err != nil {
+		return err
+	}
+	return c.Run(ctx, o.namespace, o.resync)
+}
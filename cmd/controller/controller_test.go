@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	pkgcontroller "github.com/falcosecurity/falcoctl/pkg/controller"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+func TestPrintCRD(t *testing.T) {
+	var buf bytes.Buffer
+	opt := options.NewOptions()
+	opt.Initialize(options.WithWriter(&buf))
+	cmd := NewControllerCmd(context.Background(), opt)
+	cmd.SetArgs([]string{"--print-crd"})
+
+	require.NoError(t, cmd.Execute())
+
+	var crd struct {
+		Kind     string `yaml:"kind"`
+		Metadata struct {
+			Name string `yaml:"name"`
+		} `yaml:"metadata"`
+		Spec struct {
+			Group string `yaml:"group"`
+			Names struct {
+				Kind   string `yaml:"kind"`
+				Plural string `yaml:"plural"`
+			} `yaml:"names"`
+		} `yaml:"spec"`
+	}
+	require.NoError(t, yaml.Unmarshal(buf.Bytes(), &crd))
+	assert.Equal(t, "CustomResourceDefinition", crd.Kind)
+	assert.Equal(t, pkgcontroller.Resource+"."+pkgcontroller.Group, crd.Metadata.Name)
+	assert.Equal(t, pkgcontroller.Group, crd.Spec.Group)
+	assert.Equal(t, pkgcontroller.Kind, crd.Spec.Names.Kind)
+	assert.Equal(t, pkgcontroller.Resource, crd.Spec.Names.Plural)
+}
+
+func TestInvalidResync(t *testing.T) {
+	o := controllerOptions{Common: options.NewOptions(), Registry: &options.Registry{}}
+	assert.EqualError(t, o.RunController(context.Background()), "invalid resync interval 0s, must be positive")
+}
@@ -0,0 +1,381 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+	"oras.land/oras-go/v2/registry/remote"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	indexConf "github.com/falcosecurity/falcoctl/pkg/index/config"
+	"github.com/falcosecurity/falcoctl/pkg/index/fetch"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
+)
+
+// Status is the outcome of a check.
+type Status string
+
+const (
+	// StatusPass means that the check succeeded.
+	StatusPass Status = "pass"
+	// StatusWarn means that the check found something that may prevent falcoctl or Falco from working as expected.
+	StatusWarn Status = "warn"
+	// StatusFail means that the check found something that prevents falcoctl or Falco from working.
+	StatusFail Status = "fail"
+	// StatusSkip means that the check was not run, such as the network checks in offline mode.
+	StatusSkip Status = "skip"
+)
+
+// offlineHint is the hint of the checks skipped in offline mode.
+const offlineHint = "run the command without --offline to check it"
+
+// elfMagic is the magic number at the start of the plugin shared libraries.
+var elfMagic = []byte{0x7f, 'E', 'L', 'F'}
+
+// Result is the result of a check.
+type Result struct {
+	Check   string `json:"check"`
+	Status  Status `json:"status"`
+	Message string `json:"message"`
+	// Hint suggests how to fix the problem, if any.
+	Hint string `json:"hint,omitempty"`
+}
+
+func pass(check, format string, args ...interface{}) Result {
+	return Result{Check: check, Status: StatusPass, Message: fmt.Sprintf(format, args...)}
+}
+
+func warn(check, hint, format string, args ...interface{}) Result {
+	return Result{Check: check, Status: StatusWarn, Message: fmt.Sprintf(format, args...), Hint: hint}
+}
+
+func fail(check, hint, format string, args ...interface{}) Result {
+	return Result{Check: check, Status: StatusFail, Message: fmt.Sprintf(format, args...), Hint: hint}
+}
+
+func skip(check, hint, format string, args ...interface{}) Result {
+	return Result{Check: check, Status: StatusSkip, Message: fmt.Sprintf(format, args...), Hint: hint}
+}
+
+// checkConfig loads the config file and validates each of its sections.
+// It returns false if the config file could not be loaded.
+func checkConfig(configFile string) ([]Result, bool) {
+	const check = "config"
+	if err := config.Load(configFile); err != nil {
+		return []Result{fail(check, "fix the YAML syntax of the config file or pass another one with --config",
+			"unable to load config file %q: %s", configFile, err)}, false
+	}
+
+	var results []Result
+	validate := func(section string, err error) {
+		if err != nil {
+			results = append(results, fail(check, fmt.Sprintf("fix the %q section of the config file or the related environment variables", section),
+				"invalid %q section: %s", section, err))
+		}
+	}
+	_, err := config.Indexes()
+	validate("indexes", err)
+	_, err = config.BasicAuths()
+	validate("registry.auth.basic", err)
+	_, err = config.OauthAuths()
+	validate("registry.auth.oauth", err)
+	_, err = config.Gcps()
+	validate("registry.auth.gcp", err)
+	_, err = config.Ecrs()
+	validate("registry.auth.ecr", err)
+	_, err = config.Acrs()
+	validate("registry.auth.acr", err)
+	_, err = config.Follower()
+	validate("artifact.follow", err)
+	_, err = config.Installer()
+	validate("artifact.install", err)
+	_, err = config.ArtifactAllowedTypes()
+	validate("artifact.allowedTypes", err)
+	types, err := config.DriverTypes()
+	validate("driver.type", err)
+	for _, t := range types {
+		_, err := drivertype.Parse(t)
+		validate("driver.type", err)
+	}
+	repos, err := config.DriverRepos()
+	validate("driver.repos", err)
+	for _, repo := range repos {
+		_, err := url.ParseRequestURI(repo)
+		validate("driver.repos", err)
+	}
+
+	if len(results) == 0 {
+		results = append(results, pass(check, "config file %q is valid", configFile))
+	}
+	return results, true
+}
+
+// checkIndexes fetches each configured index and checks that its local copy is not older than maxAge.
+// It returns the fetched indexes, used to find the registries to be checked.
+func checkIndexes(ctx context.Context, indexes []config.Index, indexesDir string, maxAge time.Duration) ([]Result, []*index.Index) {
+	const check = "index"
+	var (
+		results []Result
+		fetched []*index.Index
+	)
+	fetcher := fetch.NewFetcher()
+	for i := range indexes {
+		cfg := &indexes[i]
+		idx, err := fetcher.Fetch(ctx, indexConf.EntryFromIndex(cfg))
+		if errors.Is(err, errdefs.ErrOffline) {
+			results = append(results, checkOfflineIndex(cfg.Name, indexesDir))
+			continue
+		}
+		if err != nil {
+			results = append(results, fail(check, "check the index URL and the network connectivity, or remove the index from the config file",
+				"index %q is not readable from %q: %s", cfg.Name, cfg.URL, err))
+			continue
+		}
+		fetched = append(fetched, idx)
+
+		localPath := filepath.Join(indexesDir, cfg.Name+".yaml")
+		info, err := os.Stat(localPath)
+		switch {
+		case errors.Is(err, fs.ErrNotExist):
+			results = append(results, warn(check, "it is fetched by the next artifact command, or run \"falcoctl index add\"",
+				"index %q is readable but not cached locally", cfg.Name))
+		case err != nil:
+			results = append(results, fail(check, "check the permissions of the indexes directory",
+				"unable to stat local copy of index %q: %s", cfg.Name, err))
+		case time.Since(info.ModTime()) > maxAge:
+			results = append(results, warn(check, fmt.Sprintf("run \"falcoctl index update %s\"", cfg.Name),
+				"index %q is readable but its local copy is stale (last updated %s)", cfg.Name,
+				info.ModTime().UTC().Format(time.RFC3339)))
+		default:
+			results = append(results, pass(check, "index %q is readable and fresh", cfg.Name))
+		}
+	}
+	return results, fetched
+}
+
+// checkOfflineIndex checks the local copy of a remote index, which is used in place of the index in offline mode.
+func checkOfflineIndex(name, indexesDir string) Result {
+	const check = "index"
+	info, err := os.Stat(filepath.Join(indexesDir, name+".yaml"))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		return fail(check, fmt.Sprintf("run \"falcoctl index update %s\" while connected", name),
+			"index %q is not cached locally and cannot be fetched in offline mode", name)
+	case err != nil:
+		return fail(check, "check the permissions of the indexes directory",
+			"unable to stat local copy of index %q: %s", name, err)
+	default:
+		return skip(check, offlineHint, "index %q is not fetched in offline mode, its local copy was last updated %s",
+			name, info.ModTime().UTC().Format(time.RFC3339))
+	}
+}
+
+// registries returns the registries referenced by the indexes and the auth config, without duplicates.
+func registries(indexes []*index.Index) []string {
+	var regs []string
+	seen := make(map[string]struct{})
+	add := func(reg string) {
+		if _, ok := seen[reg]; reg != "" && !ok {
+			seen[reg] = struct{}{}
+			regs = append(regs, reg)
+		}
+	}
+	for _, idx := range indexes {
+		for _, entry := range idx.Entries {
+			add(entry.Registry)
+		}
+	}
+	// Errors have already been reported by the config check.
+	basics, _ := config.BasicAuths()
+	for _, auth := range basics {
+		add(auth.Registry)
+	}
+	oauths, _ := config.OauthAuths()
+	for _, auth := range oauths {
+		add(auth.Registry)
+	}
+	gcps, _ := config.Gcps()
+	for _, auth := range gcps {
+		add(auth.Registry)
+	}
+	ecrs, _ := config.Ecrs()
+	for _, auth := range ecrs {
+		add(auth.Registry)
+	}
+	acrs, _ := config.Acrs()
+	for _, auth := range acrs {
+		add(auth.Registry)
+	}
+	return regs
+}
+
+// checkRegistries resolves, handshakes and authenticates against each registry.
+func checkRegistries(ctx context.Context, client remote.Client, regs []string) []Result {
+	const check = "registry"
+	if offline.Enabled() {
+		return []Result{skip(check, offlineHint, "registries are not checked in offline mode")}
+	}
+	var results []Result
+	for _, reg := range regs {
+		if err := ociutils.CheckConnectionForRegistry(ctx, client, false, reg); err != nil {
+			results = append(results, fail(check, "check the network connectivity, the TLS setup and the credentials "+
+				"(see \"falcoctl registry auth\")", "registry %q is not reachable: %s", reg, err))
+			continue
+		}
+		results = append(results, pass(check, "registry %q is reachable and authenticated", reg))
+	}
+	return results
+}
+
+// checkDirectories checks that the destination directories exist and are writable.
+func checkDirectories(dirs []string) []Result {
+	const check = "directory"
+	var results []Result
+	for _, dir := range dirs {
+		if err := utils.ExistsAndIsWritable(dir); err != nil {
+			results = append(results, fail(check, "create the directory or fix its permissions, or configure another one",
+				"directory %q is not usable: %s", dir, err))
+			continue
+		}
+		results = append(results, pass(check, "directory %q exists and is writable", dir))
+	}
+	return results
+}
+
+// checkFalcoVersions checks that the Falco versions endpoint responds.
+func checkFalcoVersions(ctx context.Context, client *http.Client, versionsURL string) Result {
+	const check = "falco-versions"
+	if offline.Enabled() {
+		return skip(check, offlineHint, "Falco versions endpoint %q is not checked in offline mode", versionsURL)
+	}
+	hint := "check that Falco is running with the webserver enabled, or configure another endpoint"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, versionsURL, http.NoBody)
+	if err != nil {
+		return fail(check, "fix the Falco versions endpoint URL", "invalid Falco versions endpoint %q: %s", versionsURL, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return warn(check, hint, "Falco versions endpoint %q is not reachable: %s", versionsURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return warn(check, hint, "Falco versions endpoint %q returned status code %d", versionsURL, resp.StatusCode)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return warn(check, hint, "unable to read Falco versions from %q: %s", versionsURL, err)
+	}
+	var versions map[string]interface{}
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return warn(check, hint, "Falco versions endpoint %q returned invalid JSON: %s", versionsURL, err)
+	}
+	return pass(check, "Falco versions endpoint %q responds (Falco %v)", versionsURL, versions["falco_version"])
+}
+
+// checkArtifacts checks that the installed rules files parse and the installed plugins are shared libraries.
+func checkArtifacts(rulesfilesDir, pluginsDir string) []Result {
+	const check = "artifacts"
+	var results []Result
+	installed := 0
+
+	walk := func(dir string, validate func(path string) error, match func(path string) bool) {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !match(path) {
+				// Missing directories are reported by the directory check.
+				return nil
+			}
+			installed++
+			if err := validate(path); err != nil {
+				results = append(results, fail(check, "reinstall the artifact providing the file",
+					"installed file %q is corrupted: %s", path, err))
+			}
+			return nil
+		})
+	}
+
+	walk(rulesfilesDir, func(path string) error {
+		data, err := os.ReadFile(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		var rules interface{}
+		return yaml.Unmarshal(data, &rules)
+	}, func(path string) bool {
+		ext := filepath.Ext(path)
+		return ext == ".yaml" || ext == ".yml"
+	})
+
+	walk(pluginsDir, func(path string) error {
+		f, err := os.Open(filepath.Clean(path))
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		magic := make([]byte, len(elfMagic))
+		if _, err := io.ReadFull(f, magic); err != nil || !bytes.Equal(magic, elfMagic) {
+			return errors.New("not a shared library")
+		}
+		return nil
+	}, func(path string) bool {
+		return strings.HasSuffix(path, ".so")
+	})
+
+	switch {
+	case len(results) > 0:
+	case installed == 0:
+		results = append(results, pass(check, "no rules files or plugins installed"))
+	default:
+		results = append(results, pass(check, "%d installed rules files and plugins are intact", installed))
+	}
+	return results
+}
+
+// checkDriver checks that a kernel module or eBPF probe is loaded for the given driver.
+func checkDriver(driverName, sysModuleDir, bpfProbe string) Result {
+	const check = "driver"
+	kmodName := strings.ReplaceAll(driverName, "-", "_")
+	if _, err := os.Stat(filepath.Join(sysModuleDir, kmodName)); err == nil {
+		return pass(check, "kernel module %q is loaded", kmodName)
+	}
+	if _, err := os.Lstat(bpfProbe); err == nil {
+		if _, err := os.Stat(bpfProbe); err != nil {
+			return fail(check, "run \"falcoctl driver install\" or \"falcoctl driver load\"",
+				"eBPF probe %q points to a missing file", bpfProbe)
+		}
+		return pass(check, "eBPF probe %q is in place", bpfProbe)
+	}
+	return warn(check, "run \"falcoctl driver install\", unless Falco uses the modern eBPF probe which needs no driver",
+		"neither kernel module %q nor eBPF probe %q found", kmodName, bpfProbe)
+}
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
+)
+
+func TestCheckConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	valid := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte("driver:\n  type: [kmod]\n"), 0o600))
+	results, loaded := checkConfig(valid)
+	assert.True(t, loaded)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusPass, results[0].Status)
+
+	invalidType := filepath.Join(dir, "invalid-type.yaml")
+	require.NoError(t, os.WriteFile(invalidType, []byte("driver:\n  type: [unknown]\n"), 0o600))
+	results, loaded = checkConfig(invalidType)
+	assert.True(t, loaded)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusFail, results[0].Status)
+	assert.Contains(t, results[0].Message, "driver.type")
+
+	malformed := filepath.Join(dir, "malformed.yaml")
+	require.NoError(t, os.WriteFile(malformed, []byte("driver: [\n"), 0o600))
+	results, loaded = checkConfig(malformed)
+	assert.False(t, loaded)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusFail, results[0].Status)
+	assert.NotEmpty(t, results[0].Hint)
+}
+
+func TestCheckIndexes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/index.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_, _ = w.Write([]byte("- name: k8saudit\n  type: plugin\n  registry: ghcr.io\n  repository: falcosecurity/plugins/plugin/k8saudit\n"))
+	}))
+	defer server.Close()
+
+	indexesDir := t.TempDir()
+	stale := filepath.Join(indexesDir, "stale.yaml")
+	require.NoError(t, os.WriteFile(stale, nil, 0o600))
+	old := time.Now().Add(-30 * 24 * time.Hour)
+	require.NoError(t, os.Chtimes(stale, old, old))
+	require.NoError(t, os.WriteFile(filepath.Join(indexesDir, "fresh.yaml"), nil, 0o600))
+
+	results, fetched := checkIndexes(context.Background(), []config.Index{
+		{Name: "fresh", URL: server.URL + "/index.yaml"},
+		{Name: "stale", URL: server.URL + "/index.yaml"},
+		{Name: "uncached", URL: server.URL + "/index.yaml"},
+		{Name: "unreadable", URL: server.URL + "/missing.yaml"},
+	}, indexesDir, 7*24*time.Hour)
+
+	require.Len(t, results, 4)
+	assert.Equal(t, StatusPass, results[0].Status)
+	assert.Equal(t, StatusWarn, results[1].Status)
+	assert.Contains(t, results[1].Hint, "falcoctl index update stale")
+	assert.Equal(t, StatusWarn, results[2].Status)
+	assert.Equal(t, StatusFail, results[3].Status)
+	require.Len(t, fetched, 3)
+	assert.Equal(t, []string{"ghcr.io"}, registries(fetched))
+}
+
+func TestCheckDirectories(t *testing.T) {
+	dir := t.TempDir()
+	results := checkDirectories([]string{dir, filepath.Join(dir, "missing")})
+	require.Len(t, results, 2)
+	assert.Equal(t, StatusPass, results[0].Status)
+	assert.Equal(t, StatusFail, results[1].Status)
+}
+
+func TestCheckFalcoVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/versions" {
+			_, _ = w.Write([]byte(`{"falco_version":"0.37.0","engine_version":"31"}`))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	result := checkFalcoVersions(context.Background(), server.Client(), server.URL+"/versions")
+	assert.Equal(t, StatusPass, result.Status)
+	assert.Contains(t, result.Message, "0.37.0")
+
+	result = checkFalcoVersions(context.Background(), server.Client(), server.URL+"/other")
+	assert.Equal(t, StatusWarn, result.Status)
+}
+
+func TestCheckArtifacts(t *testing.T) {
+	rulesDir := t.TempDir()
+	pluginsDir := t.TempDir()
+
+	results := checkArtifacts(rulesDir, pluginsDir)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusPass, results[0].Status)
+
+	require.NoError(t, os.WriteFile(filepath.Join(rulesDir, "rules.yaml"), []byte("- rule: test\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(pluginsDir, "libk8saudit.so"), append(elfMagic, 0), 0o600))
+	results = checkArtifacts(rulesDir, pluginsDir)
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusPass, results[0].Status)
+	assert.Contains(t, results[0].Message, "2 installed")
+
+	require.NoError(t, os.WriteFile(filepath.Join(rulesDir, "broken.yaml"), []byte("- rule: [\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(pluginsDir, "libbroken.so"), []byte("text"), 0o600))
+	results = checkArtifacts(rulesDir, pluginsDir)
+	require.Len(t, results, 2)
+	assert.Equal(t, StatusFail, results[0].Status)
+	assert.Equal(t, StatusFail, results[1].Status)
+}
+
+func TestCheckDriver(t *testing.T) {
+	sysModule := t.TempDir()
+	home := t.TempDir()
+	probe := filepath.Join(home, "falco-bpf.o")
+
+	assert.Equal(t, StatusWarn, checkDriver("falco", sysModule, probe).Status)
+
+	require.NoError(t, os.Symlink(filepath.Join(home, "missing.o"), probe))
+	assert.Equal(t, StatusFail, checkDriver("falco", sysModule, probe).Status)
+
+	require.NoError(t, os.Mkdir(filepath.Join(sysModule, "falco"), 0o750))
+	assert.Equal(t, StatusPass, checkDriver("falco", sysModule, probe).Status)
+}
+
+func TestOfflineChecks(t *testing.T) {
+	offline.Enable(true)
+	t.Cleanup(func() { offline.Enable(false) })
+	ctx := context.Background()
+
+	indexesDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(indexesDir, "cached.yaml"), nil, 0o600))
+	results, fetched := checkIndexes(ctx, []config.Index{
+		{Name: "cached", URL: "https://example.com/index.yaml"},
+		{Name: "uncached", URL: "https://example.com/index.yaml"},
+	}, indexesDir, 7*24*time.Hour)
+	require.Len(t, results, 2)
+	assert.Equal(t, StatusSkip, results[0].Status)
+	assert.Equal(t, StatusFail, results[1].Status)
+	assert.Empty(t, fetched)
+
+	results = checkRegistries(ctx, nil, []string{"ghcr.io"})
+	require.Len(t, results, 1)
+	assert.Equal(t, StatusSkip, results[0].Status)
+
+	assert.Equal(t, StatusSkip, checkFalcoVersions(ctx, http.DefaultClient, "http://localhost:8765/versions").Status)
+}
@@ -0,0 +1,197 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package doctor
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"time"
+
+	"github.com/docker/docker/pkg/homedir"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const (
+	longDoctor = `Run a suite of checks on the falcoctl environment and report, for each of them, whether it passes,
+deserves attention (warn) or fails, together with a hint on how to fix the problem.
+
+The following checks are run:
+- the config file parses and each of its sections is valid;
+- every configured index is readable and its local copy is fresh;
+- every registry referenced by the indexes and the auth config resolves, handshakes TLS and authenticates;
+- the destination directories of the installed and followed artifacts exist and are writable;
+- the Falco versions endpoint responds;
+- the installed rules files and plugins are intact;
+- the configured driver is loaded.
+
+In offline mode, the registries and the Falco versions endpoint are not checked (skip), and only the local
+copies of the remote indexes are.
+
+The command exits with an error if any check fails.
+`
+
+	defaultIndexMaxAge      = 7 * 24 * time.Hour
+	defaultFalcoVersionsURL = "http://localhost:8765/versions"
+	sysModuleDir            = "/sys/module"
+	httpTimeout             = 5 * time.Second
+)
+
+type doctorOptions struct {
+	*options.Common
+	indexMaxAge time.Duration
+}
+
+// NewDoctorCmd returns the doctor command.
+func NewDoctorCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := doctorOptions{
+		Common: opt,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "doctor [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Diagnose the falcoctl environment",
+		Long:                  longDoctor,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunDoctor(ctx)
+		},
+	}
+
+	cmd.Flags().DurationVar(&o.indexMaxAge, "index-max-age", defaultIndexMaxAge,
+		"Age after which the local copy of an index is reported as stale")
+
+	return cmd
+}
+
+// RunDoctor executes the business logic for the doctor command.
+func (o *doctorOptions) RunDoctor(ctx context.Context) error {
+	return o.report(Run(ctx, o.ConfigFile, o.indexMaxAge))
+}
+
+// Run runs all the checks against the given config file and returns their results.
+// Local copies of the indexes older than indexMaxAge are reported as stale.
+func Run(ctx context.Context, configFile string, indexMaxAge time.Duration) []Result {
+	results, loaded := checkConfig(configFile)
+	// The other checks rely on the config: if it cannot be loaded, there is nothing else to check.
+	if loaded {
+		results = append(results, runChecks(ctx, indexMaxAge)...)
+	}
+	return results
+}
+
+func runChecks(ctx context.Context, indexMaxAge time.Duration) []Result {
+	var results []Result
+
+	indexes, _ := config.Indexes()
+	indexResults, fetched := checkIndexes(ctx, indexes, config.IndexesDir, indexMaxAge)
+	results = append(results, indexResults...)
+
+	client, err := ociutils.Client(false)
+	if err != nil {
+		results = append(results, fail("registry", "check the registry credentials store",
+			"unable to create registry client: %s", err))
+	} else {
+		results = append(results, checkRegistries(ctx, client, registries(fetched))...)
+	}
+
+	results = append(results, checkDirectories(Directories())...)
+
+	versionsURL := stringOrDefault(config.ArtifactFollowFalcoVersionsKey, defaultFalcoVersionsURL)
+	if u, err := url.Parse(versionsURL); err == nil && (u.Scheme == "http" || u.Scheme == "https") {
+		results = append(results, checkFalcoVersions(ctx, &http.Client{Timeout: httpTimeout}, versionsURL))
+	}
+
+	results = append(results, checkArtifacts(
+		stringOrDefault(config.ArtifactInstallRulesfilesDirKey, config.RulesfilesDir),
+		stringOrDefault(config.ArtifactInstallPluginsDirKey, config.PluginsDir))...)
+
+	driverName := stringOrDefault(config.DriverNameKey, config.DefaultDriver.Name)
+	bpfProbe := filepath.Join(homedir.Get(), ".falco", fmt.Sprintf("%s-bpf.o", driverName))
+	results = append(results, checkDriver(driverName, sysModuleDir, bpfProbe))
+
+	return results
+}
+
+func (o *doctorOptions) report(results []Result) error {
+	failed := 0
+	for _, r := range results {
+		if r.Status == StatusFail {
+			failed++
+		}
+	}
+
+	if o.Printer.Logger.Formatter == pterm.LogFormatterJSON {
+		for _, r := range results {
+			o.Printer.Logger.Info("Check", o.Printer.Logger.Args("result", r))
+		}
+	} else {
+		var data [][]string
+		for _, r := range results {
+			data = append(data, []string{r.Check, string(r.Status), r.Message, r.Hint})
+		}
+		if err := o.Printer.PrintTable(output.Doctor, data); err != nil {
+			return err
+		}
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(results))
+	}
+	o.Printer.Logger.Info("All checks passed or need attention only", o.Printer.Logger.Args("checks", len(results)))
+	return nil
+}
+
+// Directories returns the destination directories of the installed and followed artifacts,
+// as configured in the loaded config file.
+func Directories() []string {
+	return unique(
+		stringOrDefault(config.ArtifactInstallRulesfilesDirKey, config.RulesfilesDir),
+		stringOrDefault(config.ArtifactInstallPluginsDirKey, config.PluginsDir),
+		stringOrDefault(config.ArtifactInstallAssetsDirKey, config.AssetsDir),
+		stringOrDefault(config.ArtifactFollowRulesfilesDirKey, config.RulesfilesDir),
+		stringOrDefault(config.ArtifactFollowPluginsDirKey, config.PluginsDir),
+		stringOrDefault(config.ArtifactFollowAssetsDirKey, config.AssetsDir),
+	)
+}
+
+func stringOrDefault(key, def string) string {
+	if viper.IsSet(key) && viper.GetString(key) != "" {
+		return viper.GetString(key)
+	}
+	return def
+}
+
+func unique(values ...string) []string {
+	var res []string
+	seen := make(map[string]struct{})
+	for _, v := range values {
+		if _, ok := seen[v]; !ok {
+			seen[v] = struct{}{}
+			res = append(res, v)
+		}
+	}
+	return res
+}
@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driveravailability
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/falcosecurity/falcoctl/pkg/driver/availability"
+	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+	"github.com/falcosecurity/falcoctl/pkg/nodestatus"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const longAvailability = `Report which nodes of a Kubernetes cluster have a prebuilt driver available and which ones will need to compile it.
+Nodes are listed from the cluster; their distro, kernel release and architecture are derived from the node info and used to compute
+the expected driver file names, which are then looked up in the configured repos for each allowed driver type.
+The modern eBPF probe needs no artifact and cannot be probed remotely: if allowed, it is assumed to be supported
+by the nodes running a kernel release from 5.8 on, which then need no compilation.
+The kernel version ('uname -v') and the COS build ID are not exposed by the node info: nodes whose driver file names or builds
+depend on them (ie: Ubuntu, Debian and COS) and that cannot use the modern eBPF probe are reported as unknown and excluded
+from the pool coverage.
+`
+
+// unknown is printed in place of the availability of nodes whose driver file names cannot be determined.
+const unknown = "unknown"
+
+type driverAvailabilityOptions struct {
+	*options.Common
+	*options.Driver
+	kubeconfig string
+	poolLabels []string
+	types      []drivertype.DriverType
+	client     kubernetes.Interface
+}
+
+// NewDriverAvailabilityCmd returns the driver availability command.
+func NewDriverAvailabilityCmd(ctx context.Context, opt *options.Common, driver *options.Driver) *cobra.Command {
+	o := driverAvailabilityOptions{
+		Common: opt,
+		Driver: driver,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "availability [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Report prebuilt driver availability for the nodes of a cluster",
+		Long:                  longAvailability,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			typesStr, err := cmd.Flags().GetStringSlice("type")
+			if err != nil {
+				return err
+			}
+			for _, t := range typesStr {
+				driverType, err := drivertype.Parse(t)
+				if err != nil {
+					return err
+				}
+				o.types = append(o.types, driverType)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunDriverAvailability(ctx)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.kubeconfig, "kubeconfig", "", "Kubernetes config.")
+	cmd.Flags().StringSliceVar(&o.poolLabels, "pool-label", availability.DefaultPoolLabels,
+		"Node labels holding the node pool name, looked up in order")
+
+	return cmd
+}
+
+// RunDriverAvailability implements the driver availability command.
+func (o *driverAvailabilityOptions) RunDriverAvailability(ctx context.Context) error {
+	o.Printer.Logger.Info("Running falcoctl driver availability", o.Printer.Logger.Args(
+		"driver version", o.Driver.Version,
+		"driver name", o.Driver.Name,
+		"repos", strings.Join(o.Driver.Repos, ",")))
+
+	cl := o.client
+	if cl == nil {
+		var err error
+		if cl, err = nodestatus.NewClient(o.kubeconfig); err != nil {
+			return err
+		}
+	}
+
+	report, err := availability.Check(ctx, cl, availability.Options{
+		DriverName:    o.Driver.Name,
+		DriverVersion: o.Driver.Version,
+		Repos:         o.Driver.Repos,
+		Types:         o.types,
+		PoolLabels:    o.poolLabels,
+	})
+	if err != nil {
+		return err
+	}
+
+	if o.Printer.Logger.Formatter == pterm.LogFormatterJSON {
+		for i := range report.Nodes {
+			o.Printer.Logger.Info("Node", o.Printer.Logger.Args("node", report.Nodes[i]))
+		}
+		for _, pool := range report.Pools {
+			o.Printer.Logger.Info("Pool", o.Printer.Logger.Args("pool", pool))
+		}
+		return nil
+	}
+
+	var data [][]string
+	for i := range report.Nodes {
+		node := &report.Nodes[i]
+		if node.Unknown {
+			data = append(data, []string{node.Name, node.Pool, node.Distro, node.KernelRelease, node.Arch, unknown, unknown})
+			continue
+		}
+		var available []string
+		for _, driver := range node.Drivers {
+			if driver.Available {
+				available = append(available, driver.Type)
+			}
+		}
+		data = append(data, []string{node.Name, node.Pool, node.Distro, node.KernelRelease, node.Arch,
+			strings.Join(available, ", "), strconv.FormatBool(node.NeedsCompile)})
+	}
+	if err := o.Printer.PrintTable(output.DriverAvailability, data); err != nil {
+		return err
+	}
+
+	data = nil
+	for _, pool := range report.Pools {
+		coverage := "n/a"
+		if pool.Nodes > 0 {
+			coverage = fmt.Sprintf("%d%%", pool.Covered*100/pool.Nodes)
+		}
+		data = append(data, []string{pool.Name, strconv.Itoa(pool.Nodes), strconv.Itoa(pool.Covered), coverage,
+			strconv.Itoa(pool.Unknown)})
+	}
+	return o.Printer.PrintTable(output.DriverCoverage, data)
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driveravailability
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+func TestRunDriverAvailability(t *testing.T) {
+	repo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/7.0.0+driver/x86_64/falco_amazonlinux2_5.10.205-195.804.amzn2.x86_64_1.ko",
+			"/7.0.0+driver/x86_64/falco_ubuntu-generic_5.15.0-91-generic_101.ko":
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer repo.Close()
+
+	var buf bytes.Buffer
+	common := options.NewOptions()
+	common.Initialize(options.WithWriter(&buf))
+	kmod, err := drivertype.Parse(drivertype.TypeKmod)
+	require.NoError(t, err)
+
+	o := &driverAvailabilityOptions{
+		Common: common,
+		Driver: &options.Driver{
+			Name:    "falco",
+			Repos:   []string{repo.URL},
+			Version: "7.0.0+driver",
+		},
+		poolLabels: []string{"pool"},
+		types:      []drivertype.DriverType{kmod},
+		client: fake.NewSimpleClientset(&corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"pool": "workers"}},
+			Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{
+				OSImage:       "Amazon Linux 2",
+				KernelVersion: "5.10.205-195.804.amzn2.x86_64",
+				Architecture:  "amd64",
+			}},
+		}, &corev1.Node{
+			// The Ubuntu driver (built for kernel version #101) is in the repo, but the kernel
+			// version is not reported by the node info.
+			ObjectMeta: metav1.ObjectMeta{Name: "node-2", Labels: map[string]string{"pool": "workers"}},
+			Status: corev1.NodeStatus{NodeInfo: corev1.NodeSystemInfo{
+				OSImage:       "Ubuntu 22.04.3 LTS",
+				KernelVersion: "5.15.0-91-generic",
+				Architecture:  "amd64",
+			}},
+		}),
+	}
+
+	require.NoError(t, o.RunDriverAvailability(context.Background()))
+	out := buf.String()
+	assert.Contains(t, out, "node-1")
+	assert.Regexp(t, `node-2\s+\|?\s*workers\s+\|?\s*ubuntu-generic.*unknown\s+\|?\s*unknown`, out)
+	assert.Regexp(t, `workers\s+\|?\s*1\s+\|?\s*1\s+\|?\s*100%\s+\|?\s*1`, out)
+}
@@ -42,6 +42,7 @@ Global Flags:
       --log-format string      Set formatting for logs (color, text, json) (default "color")
       --log-level string       Set level for logs (info, warn, debug, trace) (default "info")
       --name string            Driver name to be used. (default "falco")
+      --offline                Use only local sources and fail immediately on anything that would need the network
       --repo strings           Driver repo to be used. (default [https://download.falco.org/driver])
       --type strings           Driver types allowed in descending priority order (ebpf, kmod, modern_ebpf) (default [modern_ebpf,kmod,ebpf])
       --version string         Driver version to be used.
@@ -101,6 +101,7 @@ func NewDriverConfigCmd(ctx context.Context, opt *options.Common, driver *option
 	cmd.Flags().StringVar(&o.kubeconfig, "kubeconfig", "", "Kubernetes config.")
 	cmd.Flags().StringVar(&o.configmap, "configmap", "", "Falco configmap name.")
 	cmd.Flags().StringVar(&o.configDir, "falco-config-dir", "/etc/falco", "Falco configuration directory.")
+	driver.AddModuleFlags(cmd)
 
 	return cmd
 }
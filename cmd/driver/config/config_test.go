@@ -35,12 +35,15 @@ Usage:
   falcoctl driver config [flags]
 
 Flags:
-      --configmap string          Falco configmap name.
-      --falco-config-dir string   Falco configuration directory. (default "/etc/falco")
-  -h, --help                      help for config
-      --kubeconfig string         Kubernetes config.
-      --namespace string          Kubernetes namespace.
-      --update-falco              Whether to overwrite Falco configuration (default true)
+      --configmap string           Falco configmap name.
+      --falco-config-dir string    Falco configuration directory. (default "/etc/falco")
+  -h, --help                       help for config
+      --kubeconfig string          Kubernetes config.
+      --load-on-boot               Whether to install the kernel module under the host root and configure it to be loaded at boot
+      --module-param stringArray   Driver module parameter in the key=value form (e.g. --module-param=g_buffer_bytes_dim=16777216). Can be repeated multiple times
+      --namespace string           Kubernetes namespace.
+      --persist-module-params      Whether to write the module parameters to a falcoctl-managed modprobe.d file under the host root
+      --update-falco               Whether to overwrite Falco configuration (default true)
 
 Global Flags:
       --config string          config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
@@ -50,6 +53,7 @@ Global Flags:
       --log-format string      Set formatting for logs (color, text, json) (default "color")
       --log-level string       Set level for logs (info, warn, debug, trace) (default "info")
       --name string            Driver name to be used. (default "falco")
+      --offline                Use only local sources and fail immediately on anything that would need the network
       --repo strings           Driver repo to be used. (default [https://download.falco.org/driver])
       --type strings           Driver types allowed in descending priority order (ebpf, kmod, modern_ebpf) (default [modern_ebpf,kmod,ebpf])
       --version string         Driver version to be used.
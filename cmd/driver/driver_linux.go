@@ -22,6 +22,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"path/filepath"
 	"strings"
@@ -30,17 +31,27 @@ import (
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
+	driveravailability "github.com/falcosecurity/falcoctl/cmd/driver/availability"
 	drivercleanup "github.com/falcosecurity/falcoctl/cmd/driver/cleanup"
 	driverconfig "github.com/falcosecurity/falcoctl/cmd/driver/config"
+	driverfetch "github.com/falcosecurity/falcoctl/cmd/driver/fetch"
 	driverinstall "github.com/falcosecurity/falcoctl/cmd/driver/install"
+	driverload "github.com/falcosecurity/falcoctl/cmd/driver/load"
 	driverprintenv "github.com/falcosecurity/falcoctl/cmd/driver/printenv"
+	driversearch "github.com/falcosecurity/falcoctl/cmd/driver/search"
+	driverunload "github.com/falcosecurity/falcoctl/cmd/driver/unload"
 	"github.com/falcosecurity/falcoctl/internal/config"
 	driverdistro "github.com/falcosecurity/falcoctl/pkg/driver/distro"
 	driverkernel "github.com/falcosecurity/falcoctl/pkg/driver/kernel"
 	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+	driverversion "github.com/falcosecurity/falcoctl/pkg/driver/version"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 	"github.com/falcosecurity/falcoctl/pkg/options"
 )
 
+// driverSrcPattern matches the directories holding the driver sources.
+const driverSrcPattern = "/usr/src/falco-*"
+
 // NewDriverCmd returns the driver command.
 func NewDriverCmd(ctx context.Context, opt *options.Common) *cobra.Command {
 	driver := &options.Driver{}
@@ -128,6 +139,37 @@ func NewDriverCmd(ctx context.Context, opt *options.Common) *cobra.Command {
 				}
 			}
 
+			// Override module flags with viper config if not set by user.
+			// They are only registered by the commands loading the driver.
+			if f = cmd.Flags().Lookup("module-param"); f != nil && !f.Changed && viper.IsSet(config.DriverModuleParamsKey) {
+				val, err := config.DriverModuleParams()
+				if err != nil {
+					return err
+				}
+				driver.ModuleParams = val
+			}
+			if f = cmd.Flags().Lookup("persist-module-params"); f != nil && !f.Changed && viper.IsSet(config.DriverPersistModuleParamsKey) {
+				driver.PersistModuleParams = viper.GetBool(config.DriverPersistModuleParamsKey)
+			}
+			if f = cmd.Flags().Lookup("load-on-boot"); f != nil && !f.Changed && viper.IsSet(config.DriverLoadOnBootKey) {
+				driver.LoadOnBoot = viper.GetBool(config.DriverLoadOnBootKey)
+			}
+
+			// Override requirements flags with viper config if not set by user.
+			// They are only registered by the commands fetching the driver.
+			for flagName, key := range map[string]string{
+				"falco-binary":          config.DriverFalcoBinaryKey,
+				"falco-versions":        config.DriverFalcoVersionsKey,
+				"driver-api-version":    config.DriverAPIVersionKey,
+				"driver-schema-version": config.DriverSchemaVersionKey,
+			} {
+				if f = cmd.Flags().Lookup(flagName); f != nil && !f.Changed && viper.IsSet(key) {
+					if err := cmd.Flags().Set(f.Name, viper.GetString(key)); err != nil {
+						return fmt.Errorf("unable to overwrite %q flag: %w", flagName, err)
+					}
+				}
+			}
+
 			// Logic to discover correct driver to be used
 			// Step 1: build up allowed driver types
 			allowedDriverTypes := make([]drivertype.DriverType, 0)
@@ -164,7 +206,7 @@ func NewDriverCmd(ctx context.Context, opt *options.Common) *cobra.Command {
 
 			driver.Type = driver.Distro.PreferredDriver(driver.Kr, allowedDriverTypes)
 			if driver.Type == nil {
-				return fmt.Errorf("no supported driver found for distro: %s, "+
+				return errdefs.Errorf(errdefs.ErrDriverUnsupported, "no supported driver found for distro: %s, "+
 					"kernelrelease %s, "+
 					"kernelversion %s, "+
 					"arch %s",
@@ -175,6 +217,13 @@ func NewDriverCmd(ctx context.Context, opt *options.Common) *cobra.Command {
 			}
 			opt.Printer.Logger.Debug("Detected supported driver", opt.Printer.Logger.Args("type", driver.Type.String()))
 
+			// Choose or validate the driver version against the versions required by Falco, if configured.
+			if driver.Requirements.IsSet() {
+				if err := resolveDriverVersion(ctx, opt, driver); err != nil {
+					return err
+				}
+			}
+
 			// If empty, try to load it automatically from /usr/src sub folders,
 			// using the most recent (ie: the one with greatest semver) driver version.
 			if driver.Version == "" {
@@ -202,6 +251,11 @@ func NewDriverCmd(ctx context.Context, opt *options.Common) *cobra.Command {
 			"(e.g. '#1 SMP PREEMPT_DYNAMIC Debian 6.1.38-2 (2023-07-27)')")
 
 	cmd.AddCommand(driverinstall.NewDriverInstallCmd(ctx, opt, driver))
+	cmd.AddCommand(driveravailability.NewDriverAvailabilityCmd(ctx, opt, driver))
+	cmd.AddCommand(driversearch.NewDriverSearchCmd(ctx, opt, driver))
+	cmd.AddCommand(driverfetch.NewDriverFetchCmd(ctx, opt, driver))
+	cmd.AddCommand(driverload.NewDriverLoadCmd(ctx, opt, driver))
+	cmd.AddCommand(driverunload.NewDriverUnloadCmd(ctx, opt, driver))
 	cmd.AddCommand(driverconfig.NewDriverConfigCmd(ctx, opt, driver))
 	cmd.AddCommand(drivercleanup.NewDriverCleanupCmd(ctx, opt, driver))
 	cmd.AddCommand(driverprintenv.NewDriverPrintenvCmd(ctx, opt, driver))
@@ -211,7 +265,7 @@ func NewDriverCmd(ctx context.Context, opt *options.Common) *cobra.Command {
 func loadDriverVersion() string {
 	isSet := false
 	greatestVrs := semver.Version{}
-	paths, _ := filepath.Glob("/usr/src/falco-*")
+	paths, _ := filepath.Glob(driverSrcPattern)
 	for _, path := range paths {
 		fileInfo, err := os.Stat(path)
 		// We expect path to point to a folder,
@@ -239,3 +293,55 @@ func loadDriverVersion() string {
 	}
 	return ""
 }
+
+// resolveDriverVersion retrieves the driver API and schema versions required by Falco and uses them
+// to choose the driver version, if not set, or to validate it.
+func resolveDriverVersion(ctx context.Context, opt *options.Common, driver *options.Driver) error {
+	var (
+		req *driverversion.Requirements
+		err error
+	)
+	switch {
+	case driver.Requirements.FalcoBinary != "":
+		req, err = driverversion.FromFalcoBinary(ctx, driver.Requirements.FalcoBinary)
+	case driver.Requirements.FalcoVersionsURL != "":
+		req, err = driverversion.FromURL(ctx, http.DefaultClient, driver.Requirements.FalcoVersionsURL)
+	default:
+		req, err = driverversion.NewRequirements(driver.Requirements.APIVersion, driver.Requirements.SchemaVersion)
+	}
+	if err != nil {
+		return err
+	}
+	opt.Printer.Logger.Debug("Retrieved driver requirements", opt.Printer.Logger.Args(
+		"api version", req.APIVersion.String(),
+		"schema version", req.SchemaVersion.String(),
+		"default driver version", req.DefaultDriverVersion))
+
+	drivers := driverversion.LocalDrivers(driverSrcPattern)
+	for _, repo := range driver.Repos {
+		repoDrivers, err := driverversion.RepoDrivers(ctx, http.DefaultClient, repo)
+		if err != nil {
+			opt.Printer.Logger.Warn("Unable to retrieve driver metadata", opt.Printer.Logger.Args("repo", repo, "reason", err.Error()))
+			continue
+		}
+		drivers = append(drivers, repoDrivers...)
+	}
+
+	if driver.Version == "" {
+		if driver.Version, err = driverversion.Select(req, drivers); err != nil {
+			return err
+		}
+		opt.Printer.Logger.Info("Selected driver version compatible with Falco", opt.Printer.Logger.Args("version", driver.Version))
+		return nil
+	}
+
+	known, err := driverversion.Validate(driver.Version, req, drivers)
+	if err != nil {
+		return err
+	}
+	if !known {
+		opt.Printer.Logger.Warn("Unable to validate driver version against Falco requirements, API and schema versions are unknown",
+			opt.Printer.Logger.Args("version", driver.Version))
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driverfetch
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	driverdistro "github.com/falcosecurity/falcoctl/pkg/driver/distro"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const (
+	// StatusDownloaded is reported when the driver has been downloaded.
+	StatusDownloaded = "downloaded"
+	// StatusBuilt is reported when the driver has been built.
+	StatusBuilt = "built"
+	// StatusAlreadyPresent is reported when the driver was already present on filesystem.
+	StatusAlreadyPresent = "already-present"
+	// StatusSkipped is reported when there is nothing to fetch.
+	StatusSkipped = "skipped"
+)
+
+// DownloadOptions holds the options used to download prebuilt drivers.
+type DownloadOptions struct {
+	InsecureDownload bool
+	HTTPTimeout      time.Duration
+	HTTPHeaders      string
+}
+
+// Options holds the options used to fetch a driver, either downloading it or building it.
+type Options struct {
+	*options.Common
+	*options.Driver
+	Download        bool
+	Compile         bool
+	DownloadHeaders bool
+	DownloadOptions
+}
+
+// NewOptions returns the fetch options, defaulting to downloading or building if needed.
+func NewOptions(opt *options.Common, driver *options.Driver) *Options {
+	return &Options{
+		Common:   opt,
+		Driver:   driver,
+		Download: true,
+		Compile:  true,
+	}
+}
+
+// AddFlags registers the fetch flags on the given command.
+func (o *Options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&o.Download, "download", true, "Whether to enable download of prebuilt drivers")
+	cmd.Flags().BoolVar(&o.Compile, "compile", true, "Whether to enable local compilation of drivers")
+	cmd.Flags().BoolVar(&o.DownloadHeaders, "download-headers", true, "Whether to enable automatic kernel headers download where supported")
+	cmd.Flags().BoolVar(&o.InsecureDownload, "http-insecure", false, "Whether you want to allow insecure downloads or not")
+	cmd.Flags().DurationVar(&o.HTTPTimeout, "http-timeout", 60*time.Second, "Timeout for each http try")
+	cmd.Flags().StringVar(&o.HTTPHeaders, "http-headers",
+		"",
+		"Optional comma-separated list of headers for the http GET request "+
+			"(e.g. --http-headers='x-emc-namespace: default,Proxy-Authenticate: Basic'). Not necessary if default repo is used")
+	o.Driver.AddRequirementsFlags(cmd)
+}
+
+// NewDriverFetchCmd returns the driver fetch command.
+func NewDriverFetchCmd(ctx context.Context, opt *options.Common, driver *options.Driver) *cobra.Command {
+	o := NewOptions(opt, driver)
+
+	cmd := &cobra.Command{
+		Use:                   "fetch [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Fetch previously configured driver without loading it",
+		Long: `Fetch previously configured driver, either downloading it or attempting a build, without loading it.
+The driver is stored in the local cache, where "driver load" will look it up.
+Running the command again when the driver is already present is a no-op.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			_, err := o.RunDriverFetch(ctx)
+			return err
+		},
+	}
+
+	o.AddFlags(cmd)
+	return cmd
+}
+
+// RunDriverFetch implements the driver fetch command. It returns the path of the fetched driver.
+func (o *Options) RunDriverFetch(ctx context.Context) (string, error) {
+	o.Printer.Logger.Info("Running falcoctl driver fetch", o.Printer.Logger.Args(
+		"driver version", o.Driver.Version,
+		"driver type", o.Driver.Type,
+		"driver name", o.Driver.Name,
+		"compile", o.Compile,
+		"download", o.Download,
+		"target", o.Distro.String(),
+		"arch", o.Kr.Architecture.ToNonDeb(),
+		"kernel release", o.Kr.String(),
+		"kernel version", o.Kr.KernelVersion))
+
+	skip, err := o.Check()
+	if err != nil || skip {
+		if skip {
+			o.Printer.Logger.Info("Driver fetch", o.Printer.Logger.Args("status", StatusSkipped))
+		}
+		return "", err
+	}
+
+	dest, status, err := o.Fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	o.Printer.Logger.Info("Driver fetch", o.Printer.Logger.Args("status", status, "path", dest))
+	return dest, nil
+}
+
+// Check verifies that the driver can be fetched. It returns true if there is nothing to fetch.
+func (o *Options) Check() (bool, error) {
+	if !o.Driver.Type.HasArtifacts() {
+		o.Printer.Logger.Info("No artifacts needed for the selected driver.")
+		return true, nil
+	}
+
+	if !o.Download && !o.Compile {
+		o.Printer.Logger.Info("Nothing to do: download and compile disabled.")
+		return true, nil
+	}
+
+	if o.Distro.String() == driverdistro.UndeterminedDistro {
+		if o.Compile {
+			o.Download = false
+			o.Printer.Logger.Info(
+				"Detected an unsupported target system, please get in touch with the Falco community. Trying to compile anyway.")
+		} else {
+			return false, errdefs.Errorf(errdefs.ErrDriverUnsupported, "detected an unsupported target system, please get in touch with the Falco community")
+		}
+	}
+	return false, nil
+}
+
+//nolint:gosec // this was an existent option in falco-driver-loader that we are porting.
+func setDefaultHTTPClientOpts(downloadOptions DownloadOptions) {
+	// Skip insecure verify
+	if downloadOptions.InsecureDownload {
+		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+	}
+	http.DefaultClient.Timeout = downloadOptions.HTTPTimeout
+}
+
+// Fetch downloads the driver or, failing that, builds it. It returns the path of the driver
+// together with the status of the operation. On failure the driver name is returned in place
+// of the path, so that callers can fall back to loading a module already present on the system.
+func (o *Options) Fetch(ctx context.Context) (dest, status string, err error) {
+	var buf bytes.Buffer
+
+	if o.Download {
+		setDefaultHTTPClientOpts(o.DownloadOptions)
+		if !o.Printer.DisableStyling {
+			o.Printer.Spinner, _ = o.Printer.Spinner.Start("Trying to download the driver")
+		}
+		dest, err = driverdistro.Download(ctx, o.Distro, o.Printer.WithWriter(&buf), o.Kr, o.Driver.Name,
+			o.Driver.Type, o.Driver.Version, o.Driver.Repos, o.HTTPHeaders)
+		if o.Printer.Spinner != nil {
+			_ = o.Printer.Spinner.Stop()
+		}
+		o.printOutput("Driver download", &buf)
+		if err == nil {
+			o.Printer.Logger.Info("Driver downloaded.", o.Printer.Logger.Args("path", dest))
+			return dest, StatusDownloaded, nil
+		}
+		if errors.Is(err, driverdistro.ErrAlreadyPresent) {
+			o.Printer.Logger.Info("Skipping download, driver already present.", o.Printer.Logger.Args("path", dest))
+			return dest, StatusAlreadyPresent, nil
+		}
+		// Print the error but go on
+		// attempting a build if requested
+		if o.Compile {
+			o.Printer.Logger.Warn(err.Error())
+		}
+	}
+
+	if o.Compile {
+		if !o.Printer.DisableStyling {
+			o.Printer.Spinner, _ = o.Printer.Spinner.Start("Trying to build the driver")
+		}
+		dest, err = driverdistro.Build(ctx, o.Distro, o.Printer.WithWriter(&buf), o.Kr, o.Driver.Name, o.Driver.Type, o.Driver.Version, o.DownloadHeaders)
+		if o.Printer.Spinner != nil {
+			_ = o.Printer.Spinner.Stop()
+		}
+		o.printOutput("Driver build", &buf)
+		if err == nil {
+			return dest, StatusBuilt, nil
+		}
+		if errors.Is(err, driverdistro.ErrAlreadyPresent) {
+			o.Printer.Logger.Info("Skipping build, driver already present.", o.Printer.Logger.Args("path", dest))
+			return dest, StatusAlreadyPresent, nil
+		}
+	}
+
+	return o.Driver.Name, "", fmt.Errorf("failed: %w", err)
+}
+
+// printOutput prints and resets the output collected in buf.
+func (o *Options) printOutput(msg string, buf *bytes.Buffer) {
+	if o.Printer.Logger.Formatter == pterm.LogFormatterJSON {
+		// Only print formatted text if we are formatting to json
+		out := strings.ReplaceAll(buf.String(), "\n", ";")
+		o.Printer.Logger.Info(msg, o.Printer.Logger.Args("output", out))
+	} else {
+		// Print much more readable output as-is
+		o.Printer.DefaultText.Print(buf.String())
+	}
+	buf.Reset()
+}
@@ -17,44 +17,29 @@ package driverinstall
 
 import (
 	"bytes"
-	"crypto/tls"
-	"errors"
-	"fmt"
-	"net/http"
 	"strings"
-	"time"
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/net/context"
 
-	driverdistro "github.com/falcosecurity/falcoctl/pkg/driver/distro"
+	driverfetch "github.com/falcosecurity/falcoctl/cmd/driver/fetch"
+	"github.com/falcosecurity/falcoctl/pkg/nodestatus"
 	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/tracing"
 )
 
-type driverDownloadOptions struct {
-	InsecureDownload bool
-	HTTPTimeout      time.Duration
-	HTTPHeaders      string
-}
-
 type driverInstallOptions struct {
-	*options.Common
-	*options.Driver
-	Download        bool
-	Compile         bool
-	DownloadHeaders bool
-	driverDownloadOptions
+	*driverfetch.Options
+	nodeStatus options.NodeStatus
 }
 
 // NewDriverInstallCmd returns the driver install command.
 func NewDriverInstallCmd(ctx context.Context, opt *options.Common, driver *options.Driver) *cobra.Command {
 	o := driverInstallOptions{
-		Common: opt,
-		Driver: driver,
 		// Defaults to downloading or building if needed
-		Download: true,
-		Compile:  true,
+		Options: driverfetch.NewOptions(opt, driver),
 	}
 
 	cmd := &cobra.Command{
@@ -62,39 +47,62 @@ func NewDriverInstallCmd(ctx context.Context, opt *options.Common, driver *optio
 		DisableFlagsInUseLine: true,
 		Short:                 "Install previously configured driver",
 		Long:                  `Install previously configured driver, either downloading it or attempting a build.`,
-		RunE: func(cmd *cobra.Command, args []string) error {
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			return o.nodeStatus.OverrideFromConfig(cmd)
+		},
+		RunE: func(cmd *cobra.Command, args []string) (err error) {
+			ctx, span := tracing.Start(ctx, "driver install")
+			defer func() { tracing.End(span, err) }()
+
+			publisher, err := o.nodeStatus.Publisher(o.Printer)
+			if err != nil {
+				return err
+			}
+
 			dest, err := o.RunDriverInstall(ctx)
+			statusErr := err
 			if dest != "" {
 				// We don't care about errors at this stage
 				// Fallback: try to load any available driver if leaving with an error.
 				// It is only useful for kmod, as it will try to
 				// modprobe a pre-existent version of the driver,
 				// hoping it will be compatible.
-				_ = driver.Type.Load(o.Printer, dest, o.Driver.Name, err != nil)
+				_, loadSpan := tracing.Start(ctx, "driver.Load", attribute.String("driver.type", o.Driver.Type.String()))
+				loadErr := driver.Type.Load(o.Printer, dest, o.Driver.Name, err != nil, o.Driver.ModuleParams)
+				tracing.End(loadSpan, loadErr)
+				if err == nil && loadErr == nil {
+					err = o.Driver.Persist(o.Printer, dest)
+					statusErr = err
+				} else if err == nil {
+					statusErr = loadErr
+				}
 			}
+			o.publishStatus(ctx, publisher, statusErr)
 			return err
 		},
 	}
 
-	cmd.Flags().BoolVar(&o.Download, "download", true, "Whether to enable download of prebuilt drivers")
-	cmd.Flags().BoolVar(&o.Compile, "compile", true, "Whether to enable local compilation of drivers")
-	cmd.Flags().BoolVar(&o.DownloadHeaders, "download-headers", true, "Whether to enable automatic kernel headers download where supported")
-	cmd.Flags().BoolVar(&o.InsecureDownload, "http-insecure", false, "Whether you want to allow insecure downloads or not")
-	cmd.Flags().DurationVar(&o.HTTPTimeout, "http-timeout", 60*time.Second, "Timeout for each http try")
-	cmd.Flags().StringVar(&o.HTTPHeaders, "http-headers",
-		"",
-		"Optional comma-separated list of headers for the http GET request "+
-			"(e.g. --http-headers='x-emc-namespace: default,Proxy-Authenticate: Basic'). Not necessary if default repo is used")
+	o.AddFlags(cmd)
+	driver.AddModuleFlags(cmd)
+	o.nodeStatus.AddFlags(cmd)
 	return cmd
 }
 
-//nolint:gosec // this was an existent option in falco-driver-loader that we are porting.
-func setDefaultHTTPClientOpts(downloadOptions driverDownloadOptions) {
-	// Skip insecure verify
-	if downloadOptions.InsecureDownload {
-		http.DefaultTransport.(*http.Transport).TLSClientConfig = &tls.Config{InsecureSkipVerify: true}
+// publishStatus publishes the driver status of the node, if enabled.
+func (o *driverInstallOptions) publishStatus(ctx context.Context, publisher *nodestatus.Publisher, err error) {
+	status := nodestatus.Driver{
+		Type:    o.Driver.Type.String(),
+		Version: o.Driver.Version,
+		Health:  nodestatus.Healthy,
+	}
+	if err != nil {
+		status.Health = nodestatus.Unhealthy
+		status.Error = err.Error()
+	}
+	publisher.SetDriver(ctx, status)
+	if err := publisher.Flush(ctx); err != nil {
+		o.Printer.Logger.Warn("Unable to publish node status", o.Printer.Logger.Args("reason", err.Error()))
 	}
-	http.DefaultClient.Timeout = downloadOptions.HTTPTimeout
 }
 
 // RunDriverInstall implements the driver install command.
@@ -110,30 +118,11 @@ func (o *driverInstallOptions) RunDriverInstall(ctx context.Context) (string, er
 		"kernel release", o.Kr.String(),
 		"kernel version", o.Kr.KernelVersion))
 
-	if !o.Driver.Type.HasArtifacts() {
-		o.Printer.Logger.Info("No artifacts needed for the selected driver.")
-		return "", nil
-	}
-
-	if !o.Download && !o.Compile {
-		o.Printer.Logger.Info("Nothing to do: download and compile disabled.")
-		return "", nil
-	}
-
-	if o.Distro.String() == driverdistro.UndeterminedDistro {
-		if o.Compile {
-			o.Download = false
-			o.Printer.Logger.Info(
-				"Detected an unsupported target system, please get in touch with the Falco community. Trying to compile anyway.")
-		} else {
-			return "", fmt.Errorf("detected an unsupported target system, please get in touch with the Falco community")
-		}
+	if skip, err := o.Check(); err != nil || skip {
+		return "", err
 	}
 
-	var (
-		dest string
-		buf  bytes.Buffer
-	)
+	var buf bytes.Buffer
 
 	if !o.Printer.DisableStyling {
 		o.Printer.Spinner, _ = o.Printer.Spinner.Start("Cleaning up existing drivers")
@@ -150,70 +139,10 @@ func (o *driverInstallOptions) RunDriverInstall(ctx context.Context) (string, er
 		// Print much more readable output as-is
 		o.Printer.DefaultText.Print(buf.String())
 	}
-	buf.Reset()
 	if err != nil {
 		return "", err
 	}
 
-	if o.Download {
-		setDefaultHTTPClientOpts(o.driverDownloadOptions)
-		if !o.Printer.DisableStyling {
-			o.Printer.Spinner, _ = o.Printer.Spinner.Start("Trying to download the driver")
-		}
-		dest, err = driverdistro.Download(ctx, o.Distro, o.Printer.WithWriter(&buf), o.Kr, o.Driver.Name,
-			o.Driver.Type, o.Driver.Version, o.Driver.Repos, o.HTTPHeaders)
-		if o.Printer.Spinner != nil {
-			_ = o.Printer.Spinner.Stop()
-		}
-		if o.Printer.Logger.Formatter == pterm.LogFormatterJSON {
-			// Only print formatted text if we are formatting to json
-			out := strings.ReplaceAll(buf.String(), "\n", ";")
-			o.Printer.Logger.Info("Driver download", o.Printer.Logger.Args("output", out))
-		} else {
-			// Print much more readable output as-is
-			o.Printer.DefaultText.Print(buf.String())
-		}
-		buf.Reset()
-		if err == nil {
-			o.Printer.Logger.Info("Driver downloaded.", o.Printer.Logger.Args("path", dest))
-			return dest, nil
-		}
-		if errors.Is(err, driverdistro.ErrAlreadyPresent) {
-			o.Printer.Logger.Info("Skipping download, driver already present.", o.Printer.Logger.Args("path", dest))
-			return dest, nil
-		}
-		// Print the error but go on
-		// attempting a build if requested
-		if o.Compile {
-			o.Printer.Logger.Warn(err.Error())
-		}
-	}
-
-	if o.Compile {
-		if !o.Printer.DisableStyling {
-			o.Printer.Spinner, _ = o.Printer.Spinner.Start("Trying to build the driver")
-		}
-		dest, err = driverdistro.Build(ctx, o.Distro, o.Printer.WithWriter(&buf), o.Kr, o.Driver.Name, o.Driver.Type, o.Driver.Version, o.DownloadHeaders)
-		if o.Printer.Spinner != nil {
-			_ = o.Printer.Spinner.Stop()
-		}
-		if o.Printer.Logger.Formatter == pterm.LogFormatterJSON {
-			// Only print formatted text if we are formatting to json
-			out := strings.ReplaceAll(buf.String(), "\n", ";")
-			o.Printer.Logger.Info("Driver build", o.Printer.Logger.Args("output", out))
-		} else {
-			// Print much more readable output as-is
-			o.Printer.DefaultText.Print(buf.String())
-		}
-		buf.Reset()
-		if err == nil {
-			return dest, nil
-		}
-		if errors.Is(err, driverdistro.ErrAlreadyPresent) {
-			o.Printer.Logger.Info("Skipping build, driver already present.", o.Printer.Logger.Args("path", dest))
-			return dest, nil
-		}
-	}
-
-	return o.Driver.Name, fmt.Errorf("failed: %w", err)
+	dest, _, err := o.Fetch(ctx)
+	return dest, err
 }
@@ -32,13 +32,23 @@ Usage:
   falcoctl driver install [flags]
 
 Flags:
-      --compile                 Whether to enable local compilation of drivers (default true)
-      --download                Whether to enable download of prebuilt drivers (default true)
-      --download-headers        Whether to enable automatic kernel headers download where supported (default true)
-  -h, --help                    help for install
-      --http-headers string     Optional comma-separated list of headers for the http GET request (e.g. --http-headers='x-emc-namespace: default,Proxy-Authenticate: Basic'). Not necessary if default repo is used
-      --http-insecure           Whether you want to allow insecure downloads or not
-      --http-timeout duration   Timeout for each http try (default 1m0s)
+      --compile                         Whether to enable local compilation of drivers (default true)
+      --download                        Whether to enable download of prebuilt drivers (default true)
+      --download-headers                Whether to enable automatic kernel headers download where supported (default true)
+      --driver-api-version string       Driver API version required by Falco, used to choose and validate the driver version
+      --driver-schema-version string    Driver schema version required by Falco, used to choose and validate the driver version
+      --falco-binary string             Falco binary whose required driver API and schema versions are used to choose and validate the driver version
+      --falco-versions string           Falco webserver versions endpoint (e.g. http://localhost:8765/versions) whose required driver API and schema versions are used to choose and validate the driver version
+  -h, --help                            help for install
+      --http-headers string             Optional comma-separated list of headers for the http GET request (e.g. --http-headers='x-emc-namespace: default,Proxy-Authenticate: Basic'). Not necessary if default repo is used
+      --http-insecure                   Whether you want to allow insecure downloads or not
+      --http-timeout duration           Timeout for each http try (default 1m0s)
+      --load-on-boot                    Whether to install the kernel module under the host root and configure it to be loaded at boot
+      --module-param stringArray        Driver module parameter in the key=value form (e.g. --module-param=g_buffer_bytes_dim=16777216). Can be repeated multiple times
+      --node-name string                Name of the Kubernetes node whose status is published (defaults to the NODE_NAME environment variable, usually set through the downward API)
+      --node-status-interval duration   Minimum interval between two updates of the Kubernetes node (default 1m0s)
+      --persist-module-params           Whether to write the module parameters to a falcoctl-managed modprobe.d file under the host root
+      --publish-node-status             Whether to publish the Falco status of the node as labels and annotations of the Kubernetes node
 
 Global Flags:
       --config string          config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
@@ -48,6 +58,7 @@ Global Flags:
       --log-format string      Set formatting for logs (color, text, json) (default "color")
       --log-level string       Set level for logs (info, warn, debug, trace) (default "info")
       --name string            Driver name to be used. (default "falco")
+      --offline                Use only local sources and fail immediately on anything that would need the network
       --repo strings           Driver repo to be used. (default [https://download.falco.org/driver])
       --type strings           Driver types allowed in descending priority order (ebpf, kmod, modern_ebpf) (default [modern_ebpf,kmod,ebpf])
       --version string         Driver version to be used.
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driverload
+
+import (
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	driverdistro "github.com/falcosecurity/falcoctl/pkg/driver/distro"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const (
+	// StatusLoaded is reported when the driver has been loaded.
+	StatusLoaded = "loaded"
+	// StatusSkipped is reported when the driver type has nothing to load.
+	StatusSkipped = "skipped"
+)
+
+type driverLoadOptions struct {
+	*options.Common
+	*options.Driver
+}
+
+// NewDriverLoadCmd returns the driver load command.
+func NewDriverLoadCmd(ctx context.Context, opt *options.Common, driver *options.Driver) *cobra.Command {
+	o := driverLoadOptions{
+		Common: opt,
+		Driver: driver,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "load [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Load a previously fetched driver",
+		Long: `Load a previously fetched driver, without accessing the network.
+The driver must already be present in the local cache, see "driver fetch".
+Loading a driver that is already loaded is a no-op.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunDriverLoad(ctx)
+		},
+	}
+
+	driver.AddModuleFlags(cmd)
+	return cmd
+}
+
+// RunDriverLoad implements the driver load command.
+func (o *driverLoadOptions) RunDriverLoad(_ context.Context) error {
+	o.Printer.Logger.Info("Running falcoctl driver load", o.Printer.Logger.Args(
+		"driver version", o.Driver.Version,
+		"driver type", o.Driver.Type,
+		"driver name", o.Driver.Name))
+
+	if !o.Driver.Type.HasArtifacts() {
+		o.Printer.Logger.Info("No artifacts needed for the selected driver.")
+		o.Printer.Logger.Info("Driver load", o.Printer.Logger.Args("status", StatusSkipped))
+		return nil
+	}
+
+	src := driverdistro.LocalPath(o.Distro, o.Kr, o.Driver.Name, o.Driver.Type, o.Driver.Version)
+	if exist, err := utils.FileExists(src); err != nil {
+		return err
+	} else if !exist {
+		return errdefs.Errorf(errdefs.ErrNotFound, "driver %q not found, run \"falcoctl driver fetch\" first", src)
+	}
+
+	if err := o.Driver.Type.Load(o.Printer, src, o.Driver.Name, false, o.Driver.ModuleParams); err != nil {
+		return err
+	}
+	if err := o.Driver.Persist(o.Printer, src); err != nil {
+		return err
+	}
+	o.Printer.Logger.Info("Driver load", o.Printer.Logger.Args("status", StatusLoaded, "path", src))
+	return nil
+}
@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driverload_test
+
+import (
+	"regexp"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+
+	"github.com/falcosecurity/falcoctl/cmd"
+)
+
+//nolint:lll // no need to check for line length.
+var driverLoadHelp = `Load a previously fetched driver, without accessing the network.
+The driver must already be present in the local cache, see "driver fetch".
+Loading a driver that is already loaded is a no-op.
+
+Usage:
+  falcoctl driver load [flags]
+
+Flags:
+  -h, --help                       help for load
+      --load-on-boot               Whether to install the kernel module under the host root and configure it to be loaded at boot
+      --module-param stringArray   Driver module parameter in the key=value form (e.g. --module-param=g_buffer_bytes_dim=16777216). Can be repeated multiple times
+      --persist-module-params      Whether to write the module parameters to a falcoctl-managed modprobe.d file under the host root
+
+Global Flags:
+      --config string          config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
+      --host-root string       Driver host root to be used. (default "/")
+      --kernelrelease string   Specify the kernel release for which to download/build the driver in the same format used by 'uname -r' (e.g. '6.1.0-10-cloud-amd64')
+      --kernelversion string   Specify the kernel version for which to download/build the driver in the same format used by 'uname -v' (e.g. '#1 SMP PREEMPT_DYNAMIC Debian 6.1.38-2 (2023-07-27)')
+      --log-format string      Set formatting for logs (color, text, json) (default "color")
+      --log-level string       Set level for logs (info, warn, debug, trace) (default "info")
+      --name string            Driver name to be used. (default "falco")
+      --offline                Use only local sources and fail immediately on anything that would need the network
+      --repo strings           Driver repo to be used. (default [https://download.falco.org/driver])
+      --type strings           Driver types allowed in descending priority order (ebpf, kmod, modern_ebpf) (default [modern_ebpf,kmod,ebpf])
+      --version string         Driver version to be used.
+`
+
+var addAssertFailedBehavior = func(specificError string) {
+	It("check that fails and the usage is not printed", func() {
+		Expect(err).To(HaveOccurred())
+		Expect(output).Should(gbytes.Say(regexp.QuoteMeta(specificError)))
+	})
+}
+
+var _ = Describe("load", func() {
+
+	var (
+		driverCmd = "driver"
+		loadCmd   = "load"
+	)
+
+	// Each test gets its own root command and runs it.
+	// The err variable is asserted by each test.
+	JustBeforeEach(func() {
+		rootCmd = cmd.New(ctx, opt)
+		err = executeRoot(args)
+	})
+
+	JustAfterEach(func() {
+		Expect(output.Clear()).ShouldNot(HaveOccurred())
+	})
+
+	Context("help message", func() {
+		BeforeEach(func() {
+			args = []string{driverCmd, loadCmd, "--help"}
+		})
+
+		It("should match the saved one", func() {
+			Expect(output).Should(gbytes.Say(regexp.QuoteMeta(driverLoadHelp)))
+		})
+	})
+
+	// Here we are testing failure cases for loading a driver.
+	Context("failure", func() {
+		When("with invalid driver type", func() {
+			BeforeEach(func() {
+				args = []string{driverCmd, loadCmd, "--config", configFile, "--type", "foo", "--version", "1.0.0+driver"}
+			})
+			addAssertFailedBehavior(`ERROR unsupported driver type specified: foo`)
+		})
+
+		When("driver has not been fetched", func() {
+			BeforeEach(func() {
+				args = []string{driverCmd, loadCmd, "--config", configFile, "--type", "ebpf", "--version", "0.0.0-notfetched+driver"}
+			})
+			addAssertFailedBehavior(`not found, run "falcoctl driver fetch" first`)
+		})
+	})
+})
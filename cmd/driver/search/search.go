@@ -0,0 +1,133 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driversearch
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/pkg/driver/search"
+	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const longSearch = `Search the prebuilt drivers offered by the configured repos for the current system,
+or for the one simulated through the --kernelrelease and --kernelversion flags.
+Drivers are searched across driver versions and allowed driver types (only kmod and ebpf have prebuilt drivers):
+besides the configured driver version, the versions listed in the repo metadata file or through an S3-style listing are searched too.
+Repos are listed through an "index.txt" file in each driver version and architecture directory, or an S3-style listing,
+when they offer one; otherwise the expected file names are probed.
+When no driver has been built for the target kernel, the drivers built for the closest kernels of the same distro are shown,
+provided that the repo can be listed.
+`
+
+type driverSearchOptions struct {
+	*options.Common
+	*options.Driver
+	types  []drivertype.DriverType
+	client *http.Client
+}
+
+// NewDriverSearchCmd returns the driver search command.
+func NewDriverSearchCmd(ctx context.Context, opt *options.Common, driver *options.Driver) *cobra.Command {
+	o := driverSearchOptions{
+		Common: opt,
+		Driver: driver,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "search [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Search the prebuilt drivers available for the target system",
+		Long:                  longSearch,
+		PreRunE: func(cmd *cobra.Command, args []string) error {
+			typesStr, err := cmd.Flags().GetStringSlice("type")
+			if err != nil {
+				return err
+			}
+			for _, t := range typesStr {
+				driverType, err := drivertype.Parse(t)
+				if err != nil {
+					return err
+				}
+				o.types = append(o.types, driverType)
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunDriverSearch(ctx)
+		},
+	}
+
+	return cmd
+}
+
+// RunDriverSearch implements the driver search command.
+func (o *driverSearchOptions) RunDriverSearch(ctx context.Context) error {
+	o.Printer.Logger.Info("Running falcoctl driver search", o.Printer.Logger.Args(
+		"driver version", o.Driver.Version,
+		"driver name", o.Driver.Name,
+		"distro", o.Driver.Distro.String(),
+		"kernel release", o.Driver.Kr.String(),
+		"arch", o.Driver.Kr.Architecture.ToNonDeb(),
+		"repos", strings.Join(o.Driver.Repos, ",")))
+
+	result, err := search.Search(ctx, &search.Options{
+		DriverName: o.Driver.Name,
+		Versions:   []string{o.Driver.Version},
+		Repos:      o.Driver.Repos,
+		Types:      o.types,
+		Distro:     o.Driver.Distro,
+		Kr:         o.Driver.Kr,
+		Client:     o.client,
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(result.Exact) == 0 {
+		if len(result.Nearest) == 0 {
+			o.Printer.Logger.Info("No prebuilt driver found for the target system.")
+			return nil
+		}
+		o.Printer.Logger.Info("No prebuilt driver found for the target kernel, showing the nearest matches.")
+	}
+
+	matches := make([]search.Match, 0, len(result.Exact)+len(result.Nearest))
+	matches = append(matches, result.Exact...)
+	matches = append(matches, result.Nearest...)
+	if o.Printer.Logger.Formatter == pterm.LogFormatterJSON {
+		for i := range matches {
+			o.Printer.Logger.Info("Driver", o.Printer.Logger.Args("driver", matches[i]))
+		}
+		return nil
+	}
+
+	var data [][]string
+	for i := range matches {
+		match := "nearest"
+		if matches[i].Exact {
+			match = "exact"
+		}
+		data = append(data, []string{matches[i].Version, matches[i].Type, match, matches[i].Filename, matches[i].Repo})
+	}
+	return o.Printer.PrintTable(output.DriverSearch, data)
+}
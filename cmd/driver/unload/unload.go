@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driverunload
+
+import (
+	"errors"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/net/context"
+
+	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const (
+	// StatusUnloaded is reported once the driver is no longer loaded.
+	StatusUnloaded = "unloaded"
+	// StatusStillLoaded is reported when the driver could not be unloaded.
+	StatusStillLoaded = "still-loaded"
+)
+
+type driverUnloadOptions struct {
+	*options.Common
+	*options.Driver
+}
+
+// NewDriverUnloadCmd returns the driver unload command.
+func NewDriverUnloadCmd(ctx context.Context, opt *options.Common, driver *options.Driver) *cobra.Command {
+	o := driverUnloadOptions{
+		Common: opt,
+		Driver: driver,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "unload [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Unload the driver",
+		Long: `Unload the driver, eg for kmod by removing the kernel module, without touching dkms.
+Unloading a driver that is not loaded is a no-op, while a driver that is still loaded after all the attempts
+makes the command fail.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunDriverUnload(ctx)
+		},
+	}
+	return cmd
+}
+
+// RunDriverUnload implements the driver unload command.
+func (o *driverUnloadOptions) RunDriverUnload(_ context.Context) error {
+	o.Printer.Logger.Info("Running falcoctl driver unload", o.Printer.Logger.Args(
+		"driver type", o.Driver.Type,
+		"driver name", o.Driver.Name))
+
+	err := o.Driver.Type.Unload(o.Printer, o.Driver.Name)
+	if errors.Is(err, drivertype.ErrStillLoaded) {
+		o.Printer.Logger.Info("Driver unload", o.Printer.Logger.Args("status", StatusStillLoaded))
+	}
+	if err != nil {
+		return err
+	}
+	o.Printer.Logger.Info("Driver unload", o.Printer.Logger.Args("status", StatusUnloaded))
+	return nil
+}
@@ -36,6 +36,7 @@ Global Flags:
       --config string       config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
       --log-format string   Set formatting for logs (color, text, json) (default "color")
       --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --offline             Use only local sources and fail immediately on anything that would need the network
 `
 
 //nolint:lll // no need to check for line length.
@@ -51,6 +52,7 @@ Global Flags:
       --config string       config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
       --log-format string   Set formatting for logs (color, text, json) (default "color")
       --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --offline             Use only local sources and fail immediately on anything that would need the network
 `
 
 var addAssertFailedBehavior = func(usage, specificError string) {
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const longGenerate = `Generate the rule catalogs of the rulesfile entries of an index file.
+
+For each rulesfile entry, the artifact with the given tag is pulled and the rules found in its layer
+are recorded in the "rules" field of the entry: name, description, priority, source, tags and
+MITRE ATT&CK techniques. The catalogs are used by "falcoctl artifact search" and "falcoctl artifact info".
+Entries whose artifact cannot be pulled keep their previous catalog.
+
+Example - Update the catalogs of an index file in place:
+	falcoctl index generate index.yaml
+
+Example - Write the index file with the catalogs to another file:
+	falcoctl index generate index.yaml --output index-with-rules.yaml
+`
+
+type indexGenerateOptions struct {
+	*options.Common
+	*options.Registry
+	output string
+	tag    string
+}
+
+// NewIndexGenerateCmd returns the index generate command.
+func NewIndexGenerateCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := indexGenerateOptions{
+		Common:   opt,
+		Registry: &options.Registry{},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "generate INDEX_FILE [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Generate the rule catalogs of an index file",
+		Long:                  longGenerate,
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunIndexGenerate(ctx, args[0])
+		},
+	}
+
+	o.Registry.AddFlags(cmd)
+	cmd.Flags().StringVarP(&o.output, "output", "o", "", "Path of the generated index file (default: the input index file)")
+	cmd.Flags().StringVar(&o.tag, "tag", oci.DefaultTag, "Tag of the artifacts whose rules are recorded")
+
+	return cmd
+}
+
+// RunIndexGenerate implements the index generate command.
+func (o *indexGenerateOptions) RunIndexGenerate(ctx context.Context, indexFile string) error {
+	logger := o.Printer.Logger
+
+	idx := index.New(filepath.Base(indexFile))
+	if err := idx.Read(indexFile); err != nil {
+		return err
+	}
+
+	puller, err := ociutils.Puller(o.PlainHTTP, o.Printer)
+	if err != nil {
+		return err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "falcoctl")
+	if err != nil {
+		return fmt.Errorf("cannot create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	for _, entry := range idx.Entries {
+		if entry.Type != string(oci.Rulesfile) {
+			continue
+		}
+		ref := fmt.Sprintf("%s/%s:%s", entry.Registry, entry.Repository, o.tag)
+		rules, err := rulesOf(ctx, puller, ref, tmpDir)
+		if err != nil {
+			logger.Warn("Cannot generate rule catalog, keeping the previous one", logger.Args("entry", entry.Name, "reason", err.Error()))
+			continue
+		}
+		entry.Rules = rules
+		logger.Info("Rule catalog generated", logger.Args("entry", entry.Name, "ref", ref, "rules", len(rules)))
+	}
+
+	output := o.output
+	if output == "" {
+		output = indexFile
+	}
+	// Index.Write creates the parent directory, which must not be empty.
+	if output, err = filepath.Abs(output); err != nil {
+		return err
+	}
+	return idx.Write(output)
+}
+
+// rulesOf pulls the rulesfile artifact ref into dir and returns the rules of its layer.
+func rulesOf(ctx context.Context, puller *ocipuller.Puller, ref, dir string) ([]index.Rule, error) {
+	result, err := puller.Pull(ctx, ref, dir, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+	if result.Type != oci.Rulesfile {
+		return nil, fmt.Errorf("%q is not a rulesfile artifact", ref)
+	}
+
+	path := filepath.Join(dir, result.Filename)
+	defer os.Remove(path)
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return index.RulesFromArchive(f)
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package generate_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/falcosecurity/falcoctl/cmd"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
+	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
+)
+
+var _ = Describe("generate", func() {
+	var (
+		indexFile  string
+		outputFile string
+		configFile string
+	)
+
+	JustBeforeEach(func() {
+		rootCmd = cmd.New(ctx, opt)
+		err = executeRoot(args)
+	})
+
+	JustAfterEach(func() {
+		Expect(output.Clear()).ShouldNot(HaveOccurred())
+	})
+
+	BeforeEach(func() {
+		dir := GinkgoT().TempDir()
+		configFile = filepath.Join(dir, "falcoctl.yaml")
+		indexFile = filepath.Join(dir, "index.yaml")
+		outputFile = filepath.Join(dir, "out", "index.yaml")
+
+		pusher := ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+		_, err := pusher.Push(ctx, oci.Rulesfile, registry+"/rules/cloudtrail:latest",
+			ocipusher.WithFilepaths([]string{rulesfiletgz}),
+			ocipusher.WithArtifactConfig(oci.ArtifactConfig{Name: "cloudtrail-rules", Version: "0.1.0"}))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(os.WriteFile(indexFile, []byte(`- name: cloudtrail-rules
+  type: rulesfile
+  registry: `+registry+`
+  repository: rules/cloudtrail
+- name: missing-rules
+  type: rulesfile
+  registry: `+registry+`
+  repository: rules/missing
+  rules:
+    - name: Previous rule
+- name: cloudtrail
+  type: plugin
+  registry: `+registry+`
+  repository: plugins/cloudtrail
+`), 0o600)).To(Succeed())
+
+		args = []string{"index", "generate", indexFile, "--plain-http", "--config", configFile, "--output", outputFile}
+	})
+
+	It("records the rules of the rulesfile artifacts", func() {
+		Expect(err).ToNot(HaveOccurred())
+
+		idx := index.New("generated")
+		Expect(idx.Read(outputFile)).To(Succeed())
+
+		entry, ok := idx.EntryByName("cloudtrail-rules")
+		Expect(ok).To(BeTrue())
+		Expect(entry.Rules).To(HaveLen(22))
+		Expect(entry.Rules[0].Source).To(Equal("aws_cloudtrail"))
+		for _, r := range entry.Rules {
+			Expect(r.Name).ToNot(BeEmpty())
+			Expect(r.Priority).ToNot(BeEmpty())
+		}
+
+		// Entries whose artifact cannot be pulled keep their catalog, plugins get none.
+		entry, _ = idx.EntryByName("missing-rules")
+		Expect(entry.Rules).To(Equal([]index.Rule{{Name: "Previous rule"}}))
+		entry, _ = idx.EntryByName("cloudtrail")
+		Expect(entry.Rules).To(BeEmpty())
+	})
+})
@@ -21,8 +21,10 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/falcosecurity/falcoctl/cmd/index/add"
+	"github.com/falcosecurity/falcoctl/cmd/index/generate"
 	"github.com/falcosecurity/falcoctl/cmd/index/list"
 	"github.com/falcosecurity/falcoctl/cmd/index/remove"
+	"github.com/falcosecurity/falcoctl/cmd/index/serve"
 	"github.com/falcosecurity/falcoctl/cmd/index/update"
 	"github.com/falcosecurity/falcoctl/internal/config"
 	commonoptions "github.com/falcosecurity/falcoctl/pkg/options"
@@ -45,6 +47,8 @@ func NewIndexCmd(ctx context.Context, opt *commonoptions.Common) *cobra.Command
 	cmd.AddCommand(remove.NewIndexRemoveCmd(ctx, opt))
 	cmd.AddCommand(update.NewIndexUpdateCmd(ctx, opt))
 	cmd.AddCommand(list.NewIndexListCmd(ctx, opt))
+	cmd.AddCommand(generate.NewIndexGenerateCmd(ctx, opt))
+	cmd.AddCommand(serve.NewIndexServeCmd(ctx, opt))
 
 	return cmd
 }
@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/index/cache"
+	indexConf "github.com/falcosecurity/falcoctl/pkg/index/config"
+	"github.com/falcosecurity/falcoctl/pkg/index/serve"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const (
+	longServe = `Serve the merged index of the local cache over HTTP.
+
+The index is served as index.yaml, with an ETag so that clients can revalidate it cheaply, and can be
+added by other falcoctl instances as any HTTP index:
+	falcoctl index add local http://index-server.example.com:8080/index.yaml
+
+The registries of the entries can be rewritten to a local mirror with --mirror, the upstream indexes can
+be refreshed periodically with --refresh, and the index can be signed with a cosign private key given
+with --signing-key. The signature is computed again each time the served index changes, so that it always
+covers the served bytes, and is served as index.yaml.sig. The password of the key is read from the
+COSIGN_PASSWORD environment variable. The signature can be verified with:
+	cosign verify-blob --key cosign.pub --signature index.yaml.sig --insecure-ignore-tlog index.yaml
+
+Example - Serve all the cached indexes:
+	falcoctl index serve --listen :8080
+
+Example - Serve only the falcosecurity index, pointing its artifacts to a mirror:
+	falcoctl index serve --index falcosecurity --mirror ghcr.io=registry.example.com:5000
+
+Example - Refresh the upstream indexes every hour:
+	falcoctl index serve --refresh 1h
+`
+
+	// defaultListen is the default address the index is served at.
+	defaultListen = ":8080"
+	// servedIndexName is the name of the served index.
+	servedIndexName = "served"
+	// readHeaderTimeout bounds the time spent reading the headers of a request.
+	readHeaderTimeout = 10 * time.Second
+	// shutdownTimeout bounds the time spent waiting for the pending requests on exit.
+	shutdownTimeout = 5 * time.Second
+	// cosignPasswordEnv is the environment variable holding the password of the signing key, as for cosign.
+	cosignPasswordEnv = "COSIGN_PASSWORD"
+)
+
+type indexServeOptions struct {
+	*options.Common
+	listen     string
+	indexes    []string
+	mirrors    map[string]string
+	refresh    time.Duration
+	signingKey string
+}
+
+// NewIndexServeCmd returns the index serve command.
+func NewIndexServeCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := indexServeOptions{
+		Common: opt,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "serve [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Serve the merged index over HTTP",
+		Long:                  longServe,
+		Args:                  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunIndexServe(ctx)
+		},
+	}
+
+	cmd.Flags().StringVar(&o.listen, "listen", defaultListen, "Address the index is served at")
+	cmd.Flags().StringSliceVar(&o.indexes, "index", nil, "Name of a cached index to be served, can be repeated (default: all the cached indexes)")
+	cmd.Flags().StringToStringVar(&o.mirrors, "mirror", nil, "Registry of the served entries to be replaced by a mirror, in the form REGISTRY=MIRROR, can be repeated")
+	cmd.Flags().DurationVar(&o.refresh, "refresh", 0, "Interval between two refreshes of the upstream indexes (default: never refreshed)")
+	cmd.Flags().StringVar(&o.signingKey, "signing-key", "", "Cosign private key signing the served index, whose signature is served as index.yaml.sig")
+
+	return cmd
+}
+
+// RunIndexServe implements the index serve command.
+func (o *indexServeOptions) RunIndexServe(ctx context.Context) error {
+	logger := o.Printer.Logger
+
+	if o.refresh < 0 {
+		return fmt.Errorf("invalid refresh interval %s, must not be negative", o.refresh)
+	}
+	names, err := o.indexNames()
+	if err != nil {
+		return err
+	}
+
+	var signer serve.Signer
+	if o.signingKey != "" {
+		if signer, err = serve.NewKeySigner(o.signingKey, []byte(os.Getenv(cosignPasswordEnv))); err != nil {
+			return err
+		}
+	}
+	server := serve.New(signer)
+	if err := o.load(ctx, server, names, false); err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", o.listen)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %q: %w", o.listen, err)
+	}
+	httpServer := &http.Server{
+		Handler:           server,
+		ReadHeaderTimeout: readHeaderTimeout,
+	}
+
+	if o.refresh > 0 {
+		go func() {
+			ticker := time.NewTicker(o.refresh)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-ticker.C:
+					if err := o.load(ctx, server, names, true); err != nil {
+						logger.Warn("Unable to refresh the served index, keeping the previous one", logger.Args("reason", err.Error()))
+					}
+				}
+			}
+		}()
+	}
+
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		_ = httpServer.Shutdown(shutdownCtx)
+	}()
+
+	logger.Info("Serving index", logger.Args("address", listener.Addr().String(), "path", serve.IndexPath, "etag", server.ETag()))
+	if err := httpServer.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return fmt.Errorf("unable to serve index: %w", err)
+	}
+	return nil
+}
+
+// indexNames returns the names of the served indexes, checking that they are cached.
+func (o *indexServeOptions) indexNames() ([]string, error) {
+	indexConfig, err := indexConf.New(config.IndexesFile)
+	if err != nil {
+		return nil, fmt.Errorf("an error occurred while loading index file %q from disk: %w", config.IndexesFile, err)
+	}
+	if len(o.indexes) == 0 {
+		names := make([]string, 0, len(indexConfig.Configs))
+		for _, cfg := range indexConfig.Configs {
+			names = append(names, cfg.Name)
+		}
+		return names, nil
+	}
+	for _, name := range o.indexes {
+		if indexConfig.Get(name) == nil {
+			return nil, errdefs.Errorf(errdefs.ErrNotFound, "index %q not found in the cache, please make sure to add it before serving it", name)
+		}
+	}
+	return o.indexes, nil
+}
+
+// load builds the served index from the local cache, after updating the served indexes from upstream
+// if update is set, and sets it in server.
+func (o *indexServeOptions) load(ctx context.Context, server *serve.Server, names []string, update bool) error {
+	logger := o.Printer.Logger
+
+	indexCache, err := cache.New(ctx, config.IndexesFile, config.IndexesDir)
+	if err != nil {
+		return fmt.Errorf("unable to create index cache: %w", err)
+	}
+
+	if update {
+		for _, name := range names {
+			logger.Debug("Refreshing index", logger.Args("name", name))
+			if err := indexCache.Update(ctx, name); err != nil {
+				logger.Warn("Unable to refresh index", logger.Args("name", name, "reason", err.Error()))
+			}
+		}
+		if _, err := indexCache.Write(); err != nil {
+			return fmt.Errorf("unable to write cache to disk: %w", err)
+		}
+	}
+
+	merged, err := indexCache.Merged()
+	if err != nil {
+		return err
+	}
+	idx := serve.Select(merged, servedIndexName, o.indexes, o.mirrors)
+	if err := server.Set(idx); err != nil {
+		return fmt.Errorf("unable to set the served index: %w", err)
+	}
+	logger.Debug("Served index loaded", logger.Args("entries", len(idx.Entries), "etag", server.ETag()))
+	return nil
+}
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/login/acr"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const (
+	longAcr = `Register an Azure ACR registry to use the Azure default credentials to connect to it.
+
+In particular, it can use environment variables, AKS Workload Identity, managed identities
+or the Azure CLI to authenticate. The Microsoft Entra ID token is exchanged for a registry
+refresh token, which is refreshed before it expires.
+
+Example 
+	falcoctl registry auth acr myregistry.azurecr.io
+`
+)
+
+// RegistryAcrOptions contains the options for the registry acr command.
+type RegistryAcrOptions struct {
+	*options.Common
+}
+
+// NewAcrCmd returns the acr command.
+func NewAcrCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := RegistryAcrOptions{
+		Common: opt,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "acr [REGISTRY]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Register an Azure ACR registry to log in using Azure credentials",
+		Long:                  longAcr,
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunAcr(ctx, args)
+		},
+	}
+
+	return cmd
+}
+
+// RunAcr executes the business logic for the acr command.
+func (o *RegistryAcrOptions) RunAcr(ctx context.Context, args []string) error {
+	logger := o.Printer.Logger
+	reg := args[0]
+	if err := acr.Login(ctx, reg); err != nil {
+		return err
+	}
+	logger.Info("ACR authentication successful", logger.Args("registry", reg))
+
+	logger.Debug("Adding new acr entry to configuration", logger.Args("file", o.ConfigFile))
+	if err := config.AddAcr([]config.AcrAuth{{
+		Registry: reg,
+	}}, o.ConfigFile); err != nil {
+		return fmt.Errorf("index entry %q: %w", reg, err)
+	}
+
+	logger.Info("ACR authentication entry successfully added", logger.Args("registry", reg, "config file", o.ConfigFile))
+
+	return nil
+}
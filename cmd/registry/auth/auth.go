@@ -20,7 +20,9 @@ import (
 
 	"github.com/spf13/cobra"
 
+	"github.com/falcosecurity/falcoctl/cmd/registry/auth/acr"
 	"github.com/falcosecurity/falcoctl/cmd/registry/auth/basic"
+	"github.com/falcosecurity/falcoctl/cmd/registry/auth/ecr"
 	"github.com/falcosecurity/falcoctl/cmd/registry/auth/gcp"
 	"github.com/falcosecurity/falcoctl/cmd/registry/auth/oauth"
 	commonoptions "github.com/falcosecurity/falcoctl/pkg/options"
@@ -38,6 +40,8 @@ func NewAuthCmd(ctx context.Context, opt *commonoptions.Common) *cobra.Command {
 	cmd.AddCommand(basic.NewBasicCmd(ctx, opt))
 	cmd.AddCommand(oauth.NewOauthCmd(ctx, opt))
 	cmd.AddCommand(gcp.NewGcpCmd(ctx, opt))
+	cmd.AddCommand(ecr.NewEcrCmd(ctx, opt))
+	cmd.AddCommand(acr.NewAcrCmd(ctx, opt))
 
 	return cmd
 }
@@ -133,7 +133,7 @@ func (o *loginOptions) RunBasic(ctx context.Context, args []string) error {
 		return fmt.Errorf("unable to create new store: %w", err)
 	}
 
-	if err := basic.Login(ctx, client, credentialStore, reg, o.username, o.password); err != nil {
+	if err := basic.Login(ctx, client, credentialStore, reg, o.username, o.password, o.insecure); err != nil {
 		return err
 	}
 	logger.Debug("Credentials added", logger.Args("credential store", config.RegistryCredentialConfPath()))
@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package ecr
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/login/ecr"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+)
+
+const (
+	longEcr = `Register an Amazon ECR registry to use the AWS default credentials to connect to it.
+
+In particular, it can use environment variables, shared configuration files, EKS Pod Identity,
+IAM roles for service accounts or the EC2 instance metadata to authenticate. Authorization tokens
+are refreshed before they expire.
+
+Example 
+	falcoctl registry auth ecr 123456789012.dkr.ecr.us-east-1.amazonaws.com
+`
+)
+
+// RegistryEcrOptions contains the options for the registry ecr command.
+type RegistryEcrOptions struct {
+	*options.Common
+}
+
+// NewEcrCmd returns the ecr command.
+func NewEcrCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := RegistryEcrOptions{
+		Common: opt,
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "ecr [REGISTRY]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Register an Amazon ECR registry to log in using AWS credentials",
+		Long:                  longEcr,
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunEcr(ctx, args)
+		},
+	}
+
+	return cmd
+}
+
+// RunEcr executes the business logic for the ecr command.
+func (o *RegistryEcrOptions) RunEcr(ctx context.Context, args []string) error {
+	logger := o.Printer.Logger
+	reg := args[0]
+	if err := ecr.Login(ctx, reg); err != nil {
+		return err
+	}
+	logger.Info("ECR authentication successful", logger.Args("registry", reg))
+
+	logger.Debug("Adding new ecr entry to configuration", logger.Args("file", o.ConfigFile))
+	if err := config.AddEcr([]config.EcrAuth{{
+		Registry: reg,
+	}}, o.ConfigFile); err != nil {
+		return fmt.Errorf("index entry %q: %w", reg, err)
+	}
+
+	logger.Info("ECR authentication entry successfully added", logger.Args("registry", reg, "config file", o.ConfigFile))
+
+	return nil
+}
@@ -17,12 +17,27 @@ package pull
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
 	"runtime"
+	"strings"
 
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 	"github.com/spf13/cobra"
+	orasregistry "oras.land/oras-go/v2/registry"
 
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/signature"
 	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/index/cache"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
 	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
 	"github.com/falcosecurity/falcoctl/pkg/options"
 	"github.com/falcosecurity/falcoctl/pkg/output"
@@ -47,18 +62,69 @@ Example - Pull artifact "myplugin" for platform "linux/arm64" in "myDir" directo
 
 Example - Pull artifact "myrulesfile":
 	falcoctl registry pull localhost:5000/myrulesfile:latest
+
+Example - Pull artifact "myplugin" for all its platforms, each one extracted in its own "<os>-<arch>" subdirectory of "myDir":
+	falcoctl registry pull localhost:5000/myplugin:latest --all-platforms --extract --dest-dir=./myDir
+
+Example - Pull artifact "myrulesfile" verifying its signature with the one of its entry in the cached indexes, and print the result as JSON:
+	falcoctl registry pull localhost:5000/myrulesfile:latest --verify --output json
+
+Example - Pull artifact "myrulesfile" verifying its signature with a public key:
+	falcoctl registry pull localhost:5000/myrulesfile:latest --key cosign.pub
 `
+	jsonFormat = "json"
 )
 
 type pullOptions struct {
 	*options.Common
 	*options.Artifact
 	*options.Registry
-	destDir string
+	destDir      string
+	extract      bool
+	allPlatforms bool
+	verify       bool
+	output       string
+	cosign       index.CosignSignature
+}
+
+// pullResult is the result of the pull command, printed with --output json.
+type pullResult struct {
+	Ref       string           `json:"ref"`
+	Digest    string           `json:"digest"`
+	Type      oci.ArtifactType `json:"type"`
+	Verified  bool             `json:"verified"`
+	Platforms []platformResult `json:"platforms"`
+}
+
+// platformResult describes the content pulled for a platform.
+type platformResult struct {
+	Platform string `json:"platform,omitempty"`
+	// Digest is the digest of the manifest of the platform.
+	Digest string `json:"digest"`
+	// Files are the paths of the pulled archive, or of the extracted files.
+	Files []string `json:"files"`
 }
 
 func (o *pullOptions) Validate() error {
-	return o.Artifact.Validate()
+	if err := o.Artifact.Validate(); err != nil {
+		return err
+	}
+	if o.allPlatforms && len(o.Platforms) > 0 {
+		return errors.New("--all-platforms cannot be used along with --platform")
+	}
+	if o.output != "" && o.output != jsonFormat {
+		return fmt.Errorf("--output must be %q", jsonFormat)
+	}
+	return nil
+}
+
+// trustPolicy returns the signature given by the flags, nil if none.
+func (o *pullOptions) trustPolicy() *index.Signature {
+	if o.cosign.KeyRef == "" && o.cosign.CertificateIdentity == "" && o.cosign.CertificateIdentityRegexp == "" {
+		return nil
+	}
+	cosign := o.cosign
+	return &index.Signature{Cosign: &cosign}
 }
 
 // NewPullCmd returns the pull command.
@@ -97,20 +163,43 @@ func NewPullCmd(ctx context.Context, opt *options.Common) *cobra.Command {
 	o.Registry.AddFlags(cmd)
 	output.ExitOnErr(o.Printer, o.Artifact.AddFlags(cmd))
 	cmd.Flags().StringVarP(&o.destDir, "dest-dir", "o", "", "destination dir where to save the artifacts(default: current directory)")
+	cmd.Flags().BoolVar(&o.extract, "extract", false, "extract the pulled archives in the destination dir, removing them")
+	cmd.Flags().BoolVar(&o.allPlatforms, "all-platforms", false,
+		"pull all the platforms of a plugin, each one in its own <os>-<arch> subdirectory of the destination dir")
+	cmd.Flags().BoolVar(&o.verify, "verify", false, "verify the signature of the artifact before pulling it, "+
+		"using the signature flags if set or else the signature of its entry in the cached indexes")
+	cmd.Flags().StringVar(&o.output, "output", "", "print the result in the given format, only 'json' is supported")
+	cmd.Flags().StringVar(&o.cosign.KeyRef, "key", "", "path of the public key verifying the signature (implies --verify)")
+	cmd.Flags().StringVar(&o.cosign.CertificateIdentity, "certificate-identity", "",
+		"identity expected in the certificate of a keyless signature (implies --verify)")
+	cmd.Flags().StringVar(&o.cosign.CertificateIdentityRegexp, "certificate-identity-regexp", "",
+		"regular expression matching the identity in the certificate of a keyless signature (implies --verify)")
+	cmd.Flags().StringVar(&o.cosign.CertificateOidcIssuer, "certificate-oidc-issuer", "",
+		"OIDC issuer expected in the certificate of a keyless signature")
+	cmd.Flags().StringVar(&o.cosign.CertificateOidcIssuerRegexp, "certificate-oidc-issuer-regexp", "",
+		"regular expression matching the OIDC issuer in the certificate of a keyless signature")
+	cmd.Flags().BoolVar(&o.cosign.IgnoreTlog, "insecure-ignore-tlog", false,
+		"do not check the transparency log when verifying the signature")
 	return cmd
 }
 
 // RunPull executes the business logic for the pull command.
 func (o *pullOptions) RunPull(ctx context.Context, args []string) error {
-	logger := o.Printer.Logger
 	ref := args[0]
 
+	// With --output json only the result is printed.
+	printer := o.Printer
+	if o.output == jsonFormat {
+		printer = o.Printer.WithWriter(io.Discard)
+	}
+	logger := printer.Logger
+
 	registry, err := utils.GetRegistryFromRef(ref)
 	if err != nil {
 		return err
 	}
 
-	puller, err := ociutils.Puller(o.PlainHTTP, o.Printer)
+	puller, err := ociutils.Puller(o.PlainHTTP, printer)
 	if err != nil {
 		return fmt.Errorf("an error occurred while creating the puller for registry %s: %w", registry, err)
 	}
@@ -128,17 +217,137 @@ func (o *pullOptions) RunPull(ctx context.Context, args []string) error {
 		logger.Info("Pulling artifact in", logger.Args("directory", o.destDir))
 	}
 
-	os, arch := runtime.GOOS, runtime.GOARCH
-	if len(o.Artifact.Platforms) > 0 {
-		os, arch = o.OSArch(0)
+	sig := o.trustPolicy()
+	verify := o.verify || sig != nil
+
+	// Pin the digest, so that the verified content and all the platforms come from the same
+	// artifact even if the tag is moved in the meantime.
+	pullRef := ref
+	if verify || o.allPlatforms {
+		if pullRef, err = digestRef(ctx, puller, ref); err != nil {
+			return err
+		}
 	}
 
-	res, err := puller.Pull(ctx, ref, o.destDir, os, arch)
-	if err != nil {
-		return err
+	if verify {
+		if sig == nil {
+			if sig, err = indexSignature(ctx, registry, pullRef); err != nil {
+				return err
+			}
+		}
+		logger.Info("Verifying signature for artifact", logger.Args("digest", pullRef))
+		if err := signature.Verify(ctx, pullRef, sig); err != nil {
+			return fmt.Errorf("error while verifying signature for %s: %w", pullRef, err)
+		}
+		logger.Info("Signature successfully verified!")
 	}
 
-	logger.Info("Artifact pulled", logger.Args("name", args[0], "type", res.Type, "digest", res.Digest))
+	var platforms []v1.Platform
+	if o.allPlatforms {
+		if platforms, err = puller.Platforms(ctx, pullRef); err != nil {
+			return err
+		}
+	}
+
+	result := pullResult{Ref: ref, Verified: verify}
+	if len(platforms) == 0 {
+		goos, arch := runtime.GOOS, runtime.GOARCH
+		if len(o.Artifact.Platforms) > 0 {
+			goos, arch = o.OSArch(0)
+		}
+		res, files, err := o.pull(ctx, puller, pullRef, o.destDir, goos, arch)
+		if err != nil {
+			return err
+		}
+		result.Digest, result.Type = res.RootDigest, res.Type
+		result.Platforms = append(result.Platforms, platformResult{Digest: res.Digest, Files: files})
+	}
+	for _, platform := range platforms {
+		name := platform.OS + "/" + platform.Architecture
+		logger.Info("Pulling platform", logger.Args("platform", name))
+		dir := filepath.Join(o.destDir, platform.OS+"-"+platform.Architecture)
+		res, files, err := o.pull(ctx, puller, pullRef, dir, platform.OS, platform.Architecture)
+		if err != nil {
+			return err
+		}
+		result.Digest, result.Type = res.RootDigest, res.Type
+		result.Platforms = append(result.Platforms, platformResult{Platform: name, Digest: res.Digest, Files: files})
+	}
+
+	logger.Info("Artifact pulled", logger.Args("name", args[0], "type", result.Type, "digest", result.Digest))
+
+	if o.output == jsonFormat {
+		data, err := json.MarshalIndent(result, "", "  ")
+		if err != nil {
+			return err
+		}
+		o.Printer.DefaultText.Println(string(data))
+	}
 
 	return nil
 }
+
+// pull pulls the artifact for the given platform in dir, extracting it if requested, and returns the
+// paths of the pulled files.
+func (o *pullOptions) pull(ctx context.Context, puller *ocipuller.Puller, ref, dir, goos, arch string) (*oci.RegistryResult, []string, error) {
+	res, err := puller.Pull(ctx, ref, dir, goos, arch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	archive := filepath.Join(dir, res.Filename)
+	if !o.extract {
+		return res, []string{archive}, nil
+	}
+
+	f, err := os.Open(filepath.Clean(archive))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer f.Close()
+	files, err := utils.ExtractTarGz(ctx, f, dir, 0)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to extract %q: %w", archive, err)
+	}
+	if err := os.Remove(archive); err != nil {
+		return nil, nil, err
+	}
+	return res, files, nil
+}
+
+// digestRef resolves ref, defaulting to the tag latest, to a reference by digest.
+func digestRef(ctx context.Context, puller *ocipuller.Puller, ref string) (string, error) {
+	parsed, err := orasregistry.ParseReference(ref)
+	if err != nil {
+		return "", err
+	}
+	if parsed.Reference == "" {
+		parsed.Reference = oci.DefaultTag
+	}
+
+	desc, err := puller.Descriptor(ctx, parsed.String())
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s@%s", parsed.Registry, parsed.Repository, desc.Digest), nil
+}
+
+// indexSignature returns the signature of the entry of the cached indexes for the repository of ref.
+func indexSignature(ctx context.Context, registry, ref string) (*index.Signature, error) {
+	repository, err := utils.RepositoryFromRef(ref)
+	if err != nil {
+		return nil, err
+	}
+	repository = strings.TrimPrefix(repository, registry+"/")
+
+	indexCache, err := cache.New(ctx, config.IndexesFile, config.IndexesDir)
+	if err != nil {
+		return nil, err
+	}
+	entry, ok := indexCache.EntryByRepository(registry, repository)
+	if !ok || entry.Signature == nil {
+		return nil, errdefs.Errorf(errdefs.ErrSignatureInvalid,
+			"no signature available to verify %q: set the signature flags or add an index listing it with its signature", ref)
+	}
+	return entry.Signature, nil
+}
@@ -16,6 +16,7 @@
 package pull_test
 
 import (
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -32,6 +33,7 @@ import (
 	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
 	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
 	out "github.com/falcosecurity/falcoctl/pkg/output"
+	"github.com/falcosecurity/falcoctl/pkg/testkit"
 )
 
 //nolint:lll,unused // no need to check for line length.
@@ -70,6 +72,15 @@ Example - Pull artifact "myplugin" for platform "linux/arm64" in "myDir" directo
 
 Example - Pull artifact "myrulesfile":
 	falcoctl registry pull localhost:5000/myrulesfile:latest
+
+Example - Pull artifact "myplugin" for all its platforms, each one extracted in its own "<os>-<arch>" subdirectory of "myDir":
+	falcoctl registry pull localhost:5000/myplugin:latest --all-platforms --extract --dest-dir=./myDir
+
+Example - Pull artifact "myrulesfile" verifying its signature with the one of its entry in the cached indexes, and print the result as JSON:
+	falcoctl registry pull localhost:5000/myrulesfile:latest --verify --output json
+
+Example - Pull artifact "myrulesfile" verifying its signature with a public key:
+	falcoctl registry pull localhost:5000/myrulesfile:latest --key cosign.pub
 `
 
 //nolint:unused // false positive
@@ -322,6 +333,115 @@ var registryPullTests = Describe("pull", func() {
 			pullAssertFailedBehavior(registryPullUsage, "not found: no matching manifest was found in the manifest list")
 		})
 
+		When("--all-platforms with --platform", func() {
+			BeforeEach(func() {
+				args = []string{registryCmd, pullCmd, registry + repoAndTag, "--plain-http",
+					"--all-platforms", "--platform", testPluginPlatform1}
+			})
+
+			pullAssertFailedBehavior(registryPullUsage, "ERROR --all-platforms cannot be used along with --platform")
+		})
+
+		When("unsupported --output", func() {
+			BeforeEach(func() {
+				args = []string{registryCmd, pullCmd, registry + repoAndTag, "--plain-http", "--output", "yaml"}
+			})
+
+			pullAssertFailedBehavior(registryPullUsage, `ERROR --output must be "json"`)
+		})
+	})
+
+	Context("success", func() {
+		var (
+			kit     *testkit.Registry
+			signer  *testkit.Signer
+			destDir string
+		)
+
+		BeforeEach(func() {
+			kit, err = testkit.StartRegistry(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			signer, err = testkit.NewSigner(GinkgoT().TempDir())
+			Expect(err).ToNot(HaveOccurred())
+			destDir = GinkgoT().TempDir()
+			DeferCleanup(kit.Close)
+		})
+
+		When("--extract and --output json", func() {
+			var res *oci.RegistryResult
+
+			BeforeEach(func() {
+				res, err = kit.Push(ctx, &testkit.Artifact{Type: oci.Rulesfile, Repository: "rules/my-rules"})
+				Expect(err).ToNot(HaveOccurred())
+				args = []string{registryCmd, pullCmd, kit.Ref("rules/my-rules", testkit.DefaultVersion), "--plain-http",
+					"--extract", "--output", "json", "--dest-dir", destDir}
+			})
+
+			It("extracts the archive and prints only the result", func() {
+				Expect(err).ToNot(HaveOccurred())
+				file := filepath.Join(destDir, "my-rules.yaml")
+				entries, err := os.ReadDir(destDir)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(entries).To(HaveLen(1))
+				Expect(file).Should(BeARegularFile())
+
+				var result map[string]interface{}
+				Expect(json.Unmarshal(output.Contents(), &result)).To(Succeed())
+				Expect(result).To(HaveKeyWithValue("type", "rulesfile"))
+				Expect(result).To(HaveKeyWithValue("digest", res.RootDigest))
+				Expect(result).To(HaveKeyWithValue("verified", false))
+				Expect(result["platforms"]).To(ConsistOf(HaveKeyWithValue("files", ConsistOf(file))))
+			})
+		})
+
+		When("--all-platforms", func() {
+			BeforeEach(func() {
+				_, err = kit.Push(ctx, &testkit.Artifact{Type: oci.Plugin, Repository: "plugins/my-plugin",
+					Platforms: []string{"linux/amd64", "linux/arm64"}})
+				Expect(err).ToNot(HaveOccurred())
+				args = []string{registryCmd, pullCmd, kit.Ref("plugins/my-plugin", testkit.DefaultVersion), "--plain-http",
+					"--all-platforms", "--extract", "--dest-dir", destDir}
+			})
+
+			It("pulls each platform in its own subdirectory", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(filepath.Join(destDir, "linux-amd64", "libmy-plugin.so")).Should(BeARegularFile())
+				Expect(filepath.Join(destDir, "linux-arm64", "libmy-plugin.so")).Should(BeARegularFile())
+			})
+		})
+
+		When("--key matches the signature", func() {
+			BeforeEach(func() {
+				_, err = kit.Push(ctx, &testkit.Artifact{Type: oci.Rulesfile, Repository: "rules/signed", Signer: signer})
+				Expect(err).ToNot(HaveOccurred())
+				args = []string{registryCmd, pullCmd, kit.Ref("rules/signed", testkit.DefaultVersion), "--plain-http",
+					"--key", signer.PublicKeyPath, "--insecure-ignore-tlog", "--dest-dir", destDir}
+			})
+
+			It("verifies and pulls the artifact", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(output).Should(gbytes.Say("Signature successfully verified!"))
+			})
+		})
+
+		When("--key does not match the signature", func() {
+			BeforeEach(func() {
+				other, err := testkit.NewSigner(GinkgoT().TempDir())
+				Expect(err).ToNot(HaveOccurred())
+				_, err = kit.Push(ctx, &testkit.Artifact{Type: oci.Rulesfile, Repository: "rules/signed", Signer: other})
+				Expect(err).ToNot(HaveOccurred())
+				args = []string{registryCmd, pullCmd, kit.Ref("rules/signed", testkit.DefaultVersion), "--plain-http",
+					"--key", signer.PublicKeyPath, "--insecure-ignore-tlog", "--dest-dir", destDir}
+			})
+
+			It("fails without pulling the artifact", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(output).Should(gbytes.Say("ERROR error while verifying signature for"))
+				entries, err := os.ReadDir(destDir)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(entries).To(BeEmpty())
+			})
+		})
 	})
 
 })
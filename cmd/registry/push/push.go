@@ -158,75 +158,105 @@ func (o *pushOptions) runPush(ctx context.Context, args []string) error {
 		}
 	}()
 
-	config := &oci.ArtifactConfig{
-		Name:    o.Name,
-		Version: o.Version,
+	config, paths, tmpDirs, err := PrepareArtifact(o.Printer.Logger, ref, paths, o.Artifact)
+	toBeDeletedTmpDirs = append(toBeDeletedTmpDirs, tmpDirs...)
+	if err != nil {
+		return err
+	}
+
+	opts, err := PusherOptions(o.Artifact, config, paths)
+	if err != nil {
+		return err
+	}
+
+	res, err := pusher.Push(ctx, o.ArtifactType, ref, opts...)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("Artifact pushed", logger.Args("name", args[0], "type", res.Type, "digest", res.RootDigest))
+
+	return nil
+}
+
+// PrepareArtifact archives the files that are not already tar.gz archives and builds the config layer
+// of the artifact described by artifactOptions. For rulesfiles, dependencies and requirements not provided
+// by the user are parsed from the rulesfile itself. If no name is provided it is extracted from ref.
+// It returns the config layer, the paths of the archives to be pushed and the temporary directories
+// that the caller must remove once done, also in case of errors.
+func PrepareArtifact(logger *pterm.Logger, ref string, paths []string,
+	artifactOptions *options.Artifact) (config *oci.ArtifactConfig, archives, tmpDirs []string, err error) {
+	config = &oci.ArtifactConfig{
+		Name:    artifactOptions.Name,
+		Version: artifactOptions.Version,
 	}
 
+	archives = make([]string, len(paths))
 	for i, p := range paths {
+		archives[i] = p
 		if err = utils.IsTarGz(filepath.Clean(p)); err != nil && !errors.Is(err, utils.ErrNotTarGz) {
-			return err
+			return nil, nil, tmpDirs, err
 		} else if err == nil {
 			continue
-		} else {
-			if o.ArtifactType == oci.Rulesfile {
-				if config, err = rulesConfigLayer(o.Printer.Logger, p, o.Artifact); err != nil {
-					return err
-				}
-			}
-			path, err := utils.CreateTarGzArchive("", p, true)
-			if err != nil {
-				return err
+		}
+
+		if artifactOptions.ArtifactType == oci.Rulesfile {
+			if config, err = rulesConfigLayer(logger, p, artifactOptions); err != nil {
+				return nil, nil, tmpDirs, err
 			}
-			paths[i] = path
-			toBeDeletedTmpDirs = append(toBeDeletedTmpDirs, filepath.Dir(path))
 		}
+		path, err := utils.CreateTarGzArchive("", p, true)
+		if err != nil {
+			return nil, nil, tmpDirs, err
+		}
+		archives[i] = path
+		tmpDirs = append(tmpDirs, filepath.Dir(path))
 	}
 
 	if config.Name == "" {
 		// extract artifact name from ref, if not provided by the user
 		if config.Name, err = utils.NameFromRef(ref); err != nil {
-			return err
+			return nil, nil, tmpDirs, err
 		}
 	}
-	if err := config.ParseDependencies(o.Dependencies...); err != nil {
-		return err
+	if err := config.ParseDependencies(artifactOptions.Dependencies...); err != nil {
+		return nil, nil, tmpDirs, err
 	}
-	if err := config.ParseRequirements(o.Requirements...); err != nil {
-		return err
+	if err := config.ParseRequirements(artifactOptions.Requirements...); err != nil {
+		return nil, nil, tmpDirs, err
 	}
 
-	if o.AutoFloatingTags {
-		v, err := semver.Parse(o.Version)
+	return config, archives, tmpDirs, nil
+}
+
+// PusherOptions returns the options used to push the artifact described by artifactOptions,
+// adding the floating tags for the major and minor versions if requested.
+func PusherOptions(artifactOptions *options.Artifact, config *oci.ArtifactConfig, paths []string) (ocipusher.Options, error) {
+	tags := artifactOptions.Tags
+	if artifactOptions.AutoFloatingTags {
+		v, err := semver.Parse(artifactOptions.Version)
 		if err != nil {
-			return fmt.Errorf("expected semver for the flag \"--version\": %w", err)
+			return nil, fmt.Errorf("expected semver for the flag \"--version\": %w", err)
 		}
-		o.Tags = append(o.Tags, o.Version, fmt.Sprintf("%v", v.Major), fmt.Sprintf("%v.%v", v.Major, v.Minor))
+		tags = append(tags, artifactOptions.Version, fmt.Sprintf("%v", v.Major), fmt.Sprintf("%v.%v", v.Major, v.Minor))
 	}
 
 	opts := ocipusher.Options{
-		ocipusher.WithTags(o.Tags...),
-		ocipusher.WithAnnotationSource(o.AnnotationSource),
+		ocipusher.WithTags(tags...),
+		ocipusher.WithAnnotationSource(artifactOptions.AnnotationSource),
 		ocipusher.WithArtifactConfig(*config),
 	}
 
-	switch o.ArtifactType {
+	switch artifactOptions.ArtifactType {
 	case oci.Plugin:
-		opts = append(opts, ocipusher.WithFilepathsAndPlatforms(paths, o.Platforms))
+		opts = append(opts, ocipusher.WithFilepathsAndPlatforms(paths, artifactOptions.Platforms))
 	case oci.Rulesfile:
 		opts = append(opts, ocipusher.WithFilepaths(paths))
 	case oci.Asset:
 		opts = append(opts, ocipusher.WithFilepaths(paths))
 	}
 
-	res, err := pusher.Push(ctx, o.ArtifactType, ref, opts...)
-	if err != nil {
-		return err
-	}
-
-	logger.Info("Artifact pushed", logger.Args("name", args[0], "type", res.Type, "digest", res.RootDigest))
-
-	return nil
+	return opts, nil
 }
 
 const (
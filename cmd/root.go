@@ -17,19 +17,29 @@ package cmd
 
 import (
 	"context"
+	"time"
 
 	"github.com/spf13/cobra"
 
 	"github.com/falcosecurity/falcoctl/cmd/artifact"
+	"github.com/falcosecurity/falcoctl/cmd/controller"
+	"github.com/falcosecurity/falcoctl/cmd/doctor"
 	"github.com/falcosecurity/falcoctl/cmd/driver"
 	"github.com/falcosecurity/falcoctl/cmd/index"
 	"github.com/falcosecurity/falcoctl/cmd/registry"
+	"github.com/falcosecurity/falcoctl/cmd/sbom"
+	"github.com/falcosecurity/falcoctl/cmd/selfupdate"
+	"github.com/falcosecurity/falcoctl/cmd/supportbundle"
 	"github.com/falcosecurity/falcoctl/cmd/tls"
 	"github.com/falcosecurity/falcoctl/cmd/version"
 	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/tracing"
 )
 
 const (
+	// tracingShutdownTimeout bounds the time spent flushing the spans on exit.
+	tracingShutdownTimeout = 5 * time.Second
+
 	longRootCmd = `
      __       _                _   _ 
     / _| __ _| | ___ ___   ___| |_| |
@@ -65,11 +75,16 @@ func New(ctx context.Context, opt *options.Common) *cobra.Command {
 
 	// Commands
 	rootCmd.AddCommand(tls.NewTLSCmd(opt))
-	rootCmd.AddCommand(version.NewVersionCmd(opt))
+	rootCmd.AddCommand(version.NewVersionCmd(ctx, opt))
 	rootCmd.AddCommand(registry.NewRegistryCmd(ctx, opt))
 	rootCmd.AddCommand(index.NewIndexCmd(ctx, opt))
 	rootCmd.AddCommand(artifact.NewArtifactCmd(ctx, opt))
 	rootCmd.AddCommand(driver.NewDriverCmd(ctx, opt))
+	rootCmd.AddCommand(doctor.NewDoctorCmd(ctx, opt))
+	rootCmd.AddCommand(supportbundle.NewSupportBundleCmd(ctx, opt))
+	rootCmd.AddCommand(controller.NewControllerCmd(ctx, opt))
+	rootCmd.AddCommand(sbom.NewSbomCmd(ctx, opt))
+	rootCmd.AddCommand(selfupdate.NewSelfUpdateCmd(ctx, opt))
 
 	return rootCmd
 }
@@ -79,6 +94,12 @@ func Execute(cmd *cobra.Command, opt *options.Common) error {
 	// we do not log the error here since we expect that each subcommand
 	// handles the errors by itself.
 	err := cmd.Execute()
+	// Flush the spans, if tracing is enabled, before exiting.
+	ctx, cancel := context.WithTimeout(context.Background(), tracingShutdownTimeout)
+	defer cancel()
+	if shutdownErr := tracing.Shutdown(ctx); shutdownErr != nil {
+		opt.Printer.Logger.Warn("Unable to flush the traces", opt.Printer.Logger.Args("reason", shutdownErr.Error()))
+	}
 	opt.Printer.CheckErr(err)
 	return err
 }
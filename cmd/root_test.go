@@ -42,20 +42,26 @@ Usage:
   falcoctl [command]
 
 Available Commands:
-  artifact    Interact with Falco artifacts
-  completion  Generate the autocompletion script for the specified shell
-  driver      Interact with falcosecurity driver
-  help        Help about any command
-  index       Interact with index
-  registry    Interact with OCI registries
-  tls         Generate and install TLS material for Falco
-  version     Print the falcoctl version information
+  artifact       Interact with Falco artifacts
+  completion     Generate the autocompletion script for the specified shell
+  controller     Reconcile the FalcoArtifactSet resources of a cluster
+  doctor         Diagnose the falcoctl environment
+  driver         Interact with falcosecurity driver
+  help           Help about any command
+  index          Interact with index
+  registry       Interact with OCI registries
+  sbom           Generate a software bill of materials of the installed artifacts and driver
+  self-update    Update falcoctl to a new version pulled from an OCI repository
+  support-bundle Collect a diagnostic archive of the falcoctl environment
+  tls            Generate and install TLS material for Falco
+  version        Print the falcoctl version information
 
 Flags:
       --config string       config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
   -h, --help                help for falcoctl
       --log-format string   Set formatting for logs (color, text, json) (default "color")
       --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --offline             Use only local sources and fail immediately on anything that would need the network
 
 Use "falcoctl [command] --help" for more information about a command.
 `
@@ -74,19 +80,25 @@ Usage:
   falcoctl [command]
 
 Available Commands:
-  artifact    Interact with Falco artifacts
-  completion  Generate the autocompletion script for the specified shell
-  help        Help about any command
-  index       Interact with index
-  registry    Interact with OCI registries
-  tls         Generate and install TLS material for Falco
-  version     Print the falcoctl version information
+  artifact       Interact with Falco artifacts
+  completion     Generate the autocompletion script for the specified shell
+  controller     Reconcile the FalcoArtifactSet resources of a cluster
+  doctor         Diagnose the falcoctl environment
+  help           Help about any command
+  index          Interact with index
+  registry       Interact with OCI registries
+  sbom           Generate a software bill of materials of the installed artifacts and driver
+  self-update    Update falcoctl to a new version pulled from an OCI repository
+  support-bundle Collect a diagnostic archive of the falcoctl environment
+  tls            Generate and install TLS material for Falco
+  version        Print the falcoctl version information
 
 Flags:
       --config string       config file to be used for falcoctl (default "/etc/falcoctl/falcoctl.yaml")
   -h, --help                help for falcoctl
       --log-format string   Set formatting for logs (color, text, json) (default "color")
       --log-level string    Set level for logs (info, warn, debug, trace) (default "info")
+      --offline             Use only local sources and fail immediately on anything that would need the network
 
 Use "falcoctl [command] --help" for more information about a command.
 `
@@ -0,0 +1,284 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/docker/docker/pkg/homedir"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"oras.land/oras-go/v2/registry"
+
+	"github.com/falcosecurity/falcoctl/cmd/version"
+	"github.com/falcosecurity/falcoctl/internal/config"
+	driverdistro "github.com/falcosecurity/falcoctl/pkg/driver/distro"
+	driverkernel "github.com/falcosecurity/falcoctl/pkg/driver/kernel"
+	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+	"github.com/falcosecurity/falcoctl/pkg/enum"
+	"github.com/falcosecurity/falcoctl/pkg/index/cache"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/installed"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/sbom"
+)
+
+const (
+	longSbom = `Generate a software bill of materials of the artifacts and driver installed on the host.
+
+The document lists, as components:
+  - the artifacts installed by "falcoctl artifact install" and "falcoctl artifact follow", with their name,
+    version, type, OCI reference as package URL, digest, license and supplier from their index entry,
+    the checksums of their files and their dependencies;
+  - the driver in use, with its version, type and kernel target: the loaded kernel module or, otherwise,
+    the first of the configured driver types the host can run (the eBPF probe in place or the modern eBPF probe).
+Installed files missing from the disk are left out of the document.
+
+Example - Write a CycloneDX document to stdout:
+	falcoctl sbom
+
+Example - Write an SPDX document to a file:
+	falcoctl sbom --format spdx -o sbom.spdx.json
+`
+	sysModuleDir = "/sys/module"
+)
+
+type sbomOptions struct {
+	*options.Common
+	format    *enum.Enum
+	output    string
+	noDriver  bool
+	stateFile string
+	indexes   *index.MergedIndexes
+}
+
+// NewSbomCmd returns the sbom command.
+func NewSbomCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := sbomOptions{
+		Common: opt,
+		format: enum.NewEnum(sbom.Formats, sbom.CycloneDX),
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "sbom [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Generate a software bill of materials of the installed artifacts and driver",
+		Long:                  longSbom,
+		Args:                  cobra.NoArgs,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			opt.Initialize()
+			if err := config.Load(opt.ConfigFile); err != nil {
+				return err
+			}
+			o.stateFile = config.InstalledFile
+			// The indexes only provide the license and supplier of the artifacts: go on without them.
+			o.indexes = index.NewMergedIndexes()
+			indexes, err := config.Indexes()
+			if err == nil {
+				var indexCache *cache.Cache
+				if indexCache, err = cache.NewFromConfig(ctx, config.IndexesFile, config.IndexesDir, indexes); err == nil {
+					o.indexes, err = indexCache.Merged()
+				}
+			}
+			if err != nil {
+				opt.Printer.Logger.Warn("Unable to load the indexes, licenses and suppliers are left out",
+					opt.Printer.Logger.Args("reason", err.Error()))
+			}
+			return nil
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunSbom(ctx)
+		},
+	}
+
+	cmd.Flags().Var(o.format, "format", "SBOM format, one of "+o.format.Allowed())
+	cmd.Flags().StringVarP(&o.output, "output", "o", "", "Path of the document to be written (default: stdout)")
+	cmd.Flags().BoolVar(&o.noDriver, "no-driver", false, "Leave the driver out of the document")
+
+	return cmd
+}
+
+// RunSbom implements the sbom command.
+func (o *sbomOptions) RunSbom(_ context.Context) error {
+	state, err := installed.Load(o.stateFile)
+	if err != nil {
+		return err
+	}
+
+	doc := &sbom.Document{
+		Timestamp:   time.Now(),
+		ToolVersion: version.SemVersion(),
+		Components:  o.components(state),
+	}
+	if doc.Name, err = os.Hostname(); err != nil {
+		doc.Name = "unknown"
+	}
+	if !o.noDriver {
+		doc.Driver = o.driver()
+	}
+
+	var buf bytes.Buffer
+	if err := sbom.Encode(&buf, o.format.Value, doc); err != nil {
+		return err
+	}
+	if o.output == "" {
+		o.Printer.DefaultText.Print(buf.String())
+		return nil
+	}
+	if err := os.WriteFile(o.output, buf.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("unable to write the SBOM to %q: %w", o.output, err)
+	}
+	o.Printer.Logger.Info("SBOM written", o.Printer.Logger.Args("output", o.output, "format", o.format.Value,
+		"artifacts", len(doc.Components)))
+	return nil
+}
+
+// components returns the components of the installed artifacts.
+func (o *sbomOptions) components(state *installed.State) []sbom.Component {
+	logger := o.Printer.Logger
+	components := make([]sbom.Component, 0, len(state.Artifacts))
+	for i := range state.Artifacts {
+		a := &state.Artifacts[i]
+		c := sbom.Component{
+			Name:      a.Name,
+			Version:   a.Version,
+			Type:      a.Type,
+			Ref:       a.Ref,
+			Digest:    a.Digest,
+			DependsOn: dependsOn(state, a),
+		}
+		if entry := o.entry(a.Ref); entry != nil {
+			c.License = entry.License
+			names := make([]string, 0, len(entry.Maintainers))
+			for _, m := range entry.Maintainers {
+				names = append(names, m.Name)
+			}
+			c.Supplier = strings.Join(names, ", ")
+		}
+		for _, f := range a.Files {
+			file, err := sbom.HashFile(f.Path)
+			if errors.Is(err, fs.ErrNotExist) {
+				logger.Warn("Installed file missing, leaving it out", logger.Args("artifact", a.Name, "path", f.Path))
+				continue
+			} else if err != nil {
+				logger.Warn("Unable to hash installed file, leaving it out", logger.Args("artifact", a.Name, "reason", err.Error()))
+				continue
+			}
+			c.Files = append(c.Files, file)
+		}
+		components = append(components, c)
+	}
+	return components
+}
+
+// entry returns the index entry of the repository of ref, if any.
+func (o *sbomOptions) entry(ref string) *index.Entry {
+	parsedRef, err := registry.ParseReference(ref)
+	if err != nil {
+		return nil
+	}
+	entry, _ := o.indexes.EntryByRepository(parsedRef.Registry, parsedRef.Repository)
+	return entry
+}
+
+// dependsOn returns the names of the installed artifacts satisfying the dependencies of an artifact,
+// either directly or through one of their alternatives.
+func dependsOn(state *installed.State, a *installed.Artifact) []string {
+	var names []string
+	for _, dep := range a.Dependencies {
+		if _, ok := state.ByName(dep.Name); ok {
+			names = append(names, dep.Name)
+			continue
+		}
+		for _, alt := range dep.Alternatives {
+			if _, ok := state.ByName(alt.Name); ok {
+				names = append(names, alt.Name)
+				break
+			}
+		}
+	}
+	return names
+}
+
+// driver returns the driver in use, or nil if none is found.
+func (o *sbomOptions) driver() *sbom.Driver {
+	logger := o.Printer.Logger
+	name := viper.GetString(config.DriverNameKey)
+	if name == "" {
+		name = config.DefaultDriver.Name
+	}
+	bpfProbe := filepath.Join(homedir.Get(), ".falco", fmt.Sprintf("%s-bpf.o", name))
+	// Errors have been reported when loading the config.
+	types, _ := config.DriverTypes()
+	kr, krErr := driverkernel.FetchInfo("", "")
+	modernBpfSupported := func() bool {
+		modernBpf, err := drivertype.Parse(drivertype.TypeModernBpf)
+		return err == nil && krErr == nil && modernBpf.Supported(kr)
+	}
+	d := loadedDriver(name, viper.GetString(config.DriverVersionKey), types, sysModuleDir, bpfProbe, modernBpfSupported)
+	if d == nil {
+		logger.Info("No kernel module loaded and no configured eBPF probe usable, leaving the driver out")
+		return nil
+	}
+
+	if krErr != nil {
+		logger.Warn("Unable to retrieve the kernel information", logger.Args("reason", krErr.Error()))
+		return d
+	}
+	d.KernelRelease = kr.String()
+	d.Arch = kr.Architecture.ToNonDeb()
+	if distro, err := driverdistro.Discover(kr, viper.GetString(config.DriverHostRootKey)); err == nil {
+		d.Target = distro.String()
+	}
+	return d
+}
+
+// loadedDriver returns the loaded kernel module, whose version is exposed by the kernel. Otherwise, eBPF
+// probes leaving no trace in /sys/module, it returns the first of the configured types that the host can run:
+// the eBPF probe in place, whose version is the configured one, or the modern eBPF probe, which is embedded
+// in Falco and has no version of its own. It returns nil if none is found.
+func loadedDriver(name, configuredVersion string, types []string, sysModuleDir, bpfProbe string,
+	modernBpfSupported func() bool) *sbom.Driver {
+	kmodName := strings.ReplaceAll(name, "-", "_")
+	if _, err := os.Stat(filepath.Join(sysModuleDir, kmodName)); err == nil {
+		d := &sbom.Driver{Name: name, Type: drivertype.TypeKmod, Version: configuredVersion}
+		if v, err := os.ReadFile(filepath.Clean(filepath.Join(sysModuleDir, kmodName, "version"))); err == nil {
+			d.Version = strings.TrimSpace(string(v))
+		}
+		return d
+	}
+	for _, t := range types {
+		switch t {
+		case drivertype.TypeBpf:
+			if _, err := os.Stat(bpfProbe); err == nil {
+				return &sbom.Driver{Name: name, Type: drivertype.TypeBpf, Version: configuredVersion}
+			}
+		case drivertype.TypeModernBpf:
+			if modernBpfSupported() {
+				return &sbom.Driver{Name: name, Type: drivertype.TypeModernBpf}
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falcosecurity/falcoctl/pkg/enum"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/installed"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/sbom"
+)
+
+func newOptions(t *testing.T, buf *bytes.Buffer, state *installed.State) *sbomOptions {
+	t.Helper()
+	opt := options.NewOptions()
+	opt.Initialize(options.WithWriter(buf))
+
+	stateFile := filepath.Join(t.TempDir(), "installed.yaml")
+	require.NoError(t, state.Write(stateFile))
+
+	idx := index.New("falcosecurity")
+	idx.Upsert(&index.Entry{
+		Name:       "cloudtrail-rules",
+		Type:       "rulesfile",
+		Registry:   "ghcr.io",
+		Repository: "falcosecurity/plugins/ruleset/cloudtrail",
+		License:    "Apache-2.0",
+		Maintainers: index.Maintainer{
+			{Name: "The Falco Authors", Email: "cncf-falco-dev@lists.cncf.io"},
+			{Name: "Jane Doe", Email: "jane@example.com"},
+		},
+	})
+	indexes := index.NewMergedIndexes()
+	indexes.Merge(idx)
+
+	return &sbomOptions{
+		Common:    opt,
+		format:    enum.NewEnum(sbom.Formats, sbom.CycloneDX),
+		noDriver:  true,
+		stateFile: stateFile,
+		indexes:   indexes,
+	}
+}
+
+func TestComponents(t *testing.T) {
+	dir := t.TempDir()
+	rulesFile := filepath.Join(dir, "cloudtrail_rules.yaml")
+	require.NoError(t, os.WriteFile(rulesFile, []byte("- rule: test\n"), 0o600))
+	state := &installed.State{}
+	state.Set(installed.Artifact{
+		Ref:     "ghcr.io/falcosecurity/plugins/ruleset/cloudtrail:0.13.0",
+		Name:    "cloudtrail-rules",
+		Version: "0.13.0",
+		Type:    "rulesfile",
+		Digest:  "sha256:0123",
+		Dependencies: []oci.ArtifactDependency{
+			{Name: "cloudtrail", Version: "0.12.0"},
+			{Name: "k8saudit", Version: "0.7.0", Alternatives: []oci.Dependency{{Name: "k8saudit-eks", Version: "0.5.0"}}},
+			{Name: "json", Version: "0.7.0"},
+		},
+		Files: []installed.File{{Path: rulesFile}, {Path: filepath.Join(dir, "removed.yaml")}},
+	})
+	state.Set(installed.Artifact{Ref: "ghcr.io/falcosecurity/plugins/plugin/cloudtrail:0.12.0", Name: "cloudtrail", Type: "plugin"})
+	state.Set(installed.Artifact{Ref: "ghcr.io/falcosecurity/plugins/plugin/k8saudit-eks:0.5.0", Name: "k8saudit-eks", Type: "plugin"})
+
+	var buf bytes.Buffer
+	o := newOptions(t, &buf, state)
+	components := o.components(state)
+
+	require.Len(t, components, 3)
+	rules := components[2]
+	assert.Equal(t, "cloudtrail-rules", rules.Name)
+	assert.Equal(t, "Apache-2.0", rules.License)
+	assert.Equal(t, "The Falco Authors, Jane Doe", rules.Supplier)
+	assert.Equal(t, []string{"cloudtrail", "k8saudit-eks"}, rules.DependsOn)
+	require.Len(t, rules.Files, 1)
+	assert.Equal(t, rulesFile, rules.Files[0].Path)
+	assert.Contains(t, buf.String(), "Installed file missing")
+
+	assert.Empty(t, components[0].License)
+	assert.Empty(t, components[0].DependsOn)
+}
+
+func TestRunSbomOutput(t *testing.T) {
+	state := &installed.State{}
+	state.Set(installed.Artifact{Ref: "ghcr.io/falcosecurity/plugins/plugin/cloudtrail:0.12.0", Name: "cloudtrail", Type: "plugin"})
+
+	var buf bytes.Buffer
+	o := newOptions(t, &buf, state)
+	o.output = filepath.Join(t.TempDir(), "sbom.json")
+	require.NoError(t, o.format.Set(sbom.SPDX))
+	require.NoError(t, o.RunSbom(context.Background()))
+
+	data, err := os.ReadFile(o.output)
+	require.NoError(t, err)
+	var doc struct {
+		SPDXVersion string `json:"spdxVersion"`
+		Packages    []struct {
+			Name string `json:"name"`
+		} `json:"packages"`
+	}
+	require.NoError(t, json.Unmarshal(data, &doc))
+	assert.Equal(t, "SPDX-2.3", doc.SPDXVersion)
+	require.Len(t, doc.Packages, 1)
+	assert.Equal(t, "cloudtrail", doc.Packages[0].Name)
+}
+
+func TestLoadedDriver(t *testing.T) {
+	dir := t.TempDir()
+	sysModule := filepath.Join(dir, "module")
+	bpfProbe := filepath.Join(dir, "falco-bpf.o")
+	supported := func() bool { return true }
+	unsupported := func() bool { return false }
+	allTypes := []string{"modern_ebpf", "kmod", "ebpf"}
+
+	assert.Nil(t, loadedDriver("falco", "7.3.0+driver", allTypes, sysModule, bpfProbe, unsupported))
+	assert.Equal(t, &sbom.Driver{Name: "falco", Type: "modern_ebpf"},
+		loadedDriver("falco", "7.3.0+driver", allTypes, sysModule, bpfProbe, supported))
+
+	require.NoError(t, os.WriteFile(bpfProbe, nil, 0o600))
+	assert.Equal(t, &sbom.Driver{Name: "falco", Type: "ebpf", Version: "7.3.0+driver"},
+		loadedDriver("falco", "7.3.0+driver", allTypes, sysModule, bpfProbe, unsupported))
+	// The configured types are looked up in order.
+	assert.Equal(t, &sbom.Driver{Name: "falco", Type: "modern_ebpf"},
+		loadedDriver("falco", "7.3.0+driver", allTypes, sysModule, bpfProbe, supported))
+	assert.Equal(t, &sbom.Driver{Name: "falco", Type: "ebpf", Version: "7.3.0+driver"},
+		loadedDriver("falco", "7.3.0+driver", []string{"ebpf", "modern_ebpf"}, sysModule, bpfProbe, supported))
+	assert.Nil(t, loadedDriver("falco", "7.3.0+driver", []string{"kmod"}, sysModule, bpfProbe, supported))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(sysModule, "falco_custom"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(sysModule, "falco_custom", "version"), []byte("7.2.1+driver\n"), 0o600))
+	assert.Equal(t, &sbom.Driver{Name: "falco-custom", Type: "kmod", Version: "7.2.1+driver"},
+		loadedDriver("falco-custom", "7.3.0+driver", allTypes, sysModule, bpfProbe, supported))
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfupdate
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/falcosecurity/falcoctl/cmd/version"
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/selfupdate"
+)
+
+const longSelfUpdate = `Update falcoctl to a new version pulled from an OCI repository.
+
+The falcoctl binary artifact of the requested version, the latest stable one by default, is pulled for the
+running platform from the repository set in the "selfUpdate.repository" config key or with --repository.
+Its signature is verified against the "selfUpdate.signature" config section, and the update is refused
+when no signature is configured, unless --no-verify is given. The new binary must then run its version
+command and report the requested version before it atomically replaces the running executable, which
+is kept next to it with the ".bak" suffix.
+
+Example - Update to the latest version:
+	falcoctl self-update
+
+Example - Update to the latest version, prereleases included:
+	falcoctl self-update --prerelease
+
+Example - Update to a given version:
+	falcoctl self-update --version 0.11.0
+`
+
+type selfUpdateOptions struct {
+	*options.Common
+	*options.Registry
+	version    string
+	repository string
+	noVerify   bool
+	force      bool
+	prerelease bool
+}
+
+// NewSelfUpdateCmd returns the self-update command.
+func NewSelfUpdateCmd(ctx context.Context, opt *options.Common) *cobra.Command {
+	o := selfUpdateOptions{
+		Common:   opt,
+		Registry: &options.Registry{},
+	}
+
+	cmd := &cobra.Command{
+		Use:                   "self-update [flags]",
+		DisableFlagsInUseLine: true,
+		Short:                 "Update falcoctl to a new version pulled from an OCI repository",
+		Long:                  longSelfUpdate,
+		Args:                  cobra.NoArgs,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			opt.Initialize()
+			return config.Load(opt.ConfigFile)
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return o.RunSelfUpdate(ctx)
+		},
+	}
+
+	o.Registry.AddFlags(cmd)
+	cmd.Flags().StringVar(&o.version, "version", "", "Version to update to (default: the latest one)")
+	cmd.Flags().StringVar(&o.repository, "repository", "", "OCI repository of the falcoctl binary artifacts (default: the configured one)")
+	cmd.Flags().BoolVar(&o.noVerify, "no-verify", false, "Skip the signature verification of the falcoctl binary artifact")
+	cmd.Flags().BoolVar(&o.force, "force", false, "Update even if the latest version is not newer than the running one")
+	cmd.Flags().BoolVar(&o.prerelease, "prerelease", false, "Consider the prerelease versions (e.g. 0.12.0-rc1) when looking for the latest one")
+
+	return cmd
+}
+
+// RunSelfUpdate implements the self-update command.
+func (o *selfUpdateOptions) RunSelfUpdate(ctx context.Context) error {
+	logger := o.Printer.Logger
+
+	updater, err := selfupdate.NewFromConfig(o.repository, o.PlainHTTP, o.noVerify)
+	if err != nil {
+		return err
+	}
+	updater.Prerelease = o.prerelease
+
+	target := o.version
+	if target == "" {
+		if target, err = updater.Latest(ctx); err != nil {
+			return err
+		}
+		if !o.force && !selfupdate.Newer(version.SemVersion(), target) {
+			logger.Info("falcoctl is already up to date", logger.Args("version", version.SemVersion(), "latest", target))
+			return nil
+		}
+	}
+
+	logger.Info("Updating falcoctl", logger.Args("from", version.SemVersion(), "to", target, "repository", updater.Repository))
+	if o.noVerify {
+		logger.Warn("Signature verification disabled")
+	}
+	result, err := updater.Update(ctx, target)
+	if err != nil {
+		return err
+	}
+
+	logger.Info("falcoctl successfully updated", logger.Args("version", result.Version, "ref", result.Ref,
+		"executable", result.Executable, "backup", result.Backup))
+	return nil
+}
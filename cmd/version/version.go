@@ -16,6 +16,7 @@
 package version
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -24,7 +25,9 @@ import (
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 
+	"github.com/falcosecurity/falcoctl/internal/config"
 	commonoptions "github.com/falcosecurity/falcoctl/pkg/options"
+	"github.com/falcosecurity/falcoctl/pkg/selfupdate"
 )
 
 const (
@@ -51,6 +54,7 @@ var (
 type options struct {
 	*commonoptions.Common
 	Output string
+	Check  bool
 }
 
 var errOutputFlag = errors.New("--output must be 'yaml' or 'json'")
@@ -62,6 +66,9 @@ type version struct {
 	GoVersion  string `json:"goVersion"`
 	Compiler   string `json:"compiler"`
 	Platform   string `json:"platform"`
+	// Latest and UpdateAvailable are only set by --check.
+	Latest          string `json:"latest,omitempty" yaml:",omitempty"`
+	UpdateAvailable bool   `json:"updateAvailable,omitempty" yaml:",omitempty"`
 }
 
 func newVersion() version {
@@ -78,7 +85,7 @@ func newVersion() version {
 }
 
 // NewVersionCmd returns the version command.
-func NewVersionCmd(opt *commonoptions.Common) *cobra.Command {
+func NewVersionCmd(ctx context.Context, opt *commonoptions.Common) *cobra.Command {
 	o := options{
 		Common: opt,
 	}
@@ -93,10 +100,16 @@ func NewVersionCmd(opt *commonoptions.Common) *cobra.Command {
 			return o.validate()
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
+			if o.Check {
+				if err := o.check(ctx, &v); err != nil {
+					return err
+				}
+			}
 			return o.Run(&v)
 		},
 	}
 	cmd.Flags().StringVarP(&o.Output, "output", "o", "", "One of 'yaml' or 'json'")
+	cmd.Flags().BoolVar(&o.Check, "check", false, "Check whether a newer version is available in the configured falcoctl repository")
 
 	return cmd
 }
@@ -109,11 +122,33 @@ func (o *options) validate() error {
 	return nil
 }
 
+// check looks for the latest stable version in the falcoctl repository of the config file.
+func (o *options) check(ctx context.Context, v *version) error {
+	if err := config.Load(o.ConfigFile); err != nil {
+		return err
+	}
+	updater, err := selfupdate.NewFromConfig("", false, true)
+	if err != nil {
+		return err
+	}
+	if v.Latest, err = updater.Latest(ctx); err != nil {
+		return err
+	}
+	v.UpdateAvailable = selfupdate.Newer(v.SemVersion, v.Latest)
+	return nil
+}
+
 // Run executes the business logic for the version command.
 func (o *options) Run(v *version) error {
 	switch o.Output {
 	case "":
 		o.Printer.DefaultText.Printf("Client Version: %s\n", v.SemVersion)
+		if v.Latest != "" {
+			o.Printer.DefaultText.Printf("Latest Version: %s\n", v.Latest)
+			if v.UpdateAvailable {
+				o.Printer.DefaultText.Println("A newer version is available, run \"falcoctl self-update\" to update")
+			}
+		}
 	case yamlFormat:
 		marshaled, err := yaml.Marshal(v)
 		if err != nil {
@@ -133,3 +168,13 @@ func (o *options) Run(v *version) error {
 
 	return nil
 }
+
+// YAML returns the falcoctl version information in YAML format.
+func YAML() ([]byte, error) {
+	return yaml.Marshal(newVersion())
+}
+
+// SemVersion returns the falcoctl semantic version.
+func SemVersion() string {
+	return semVersion
+}
@@ -16,12 +16,14 @@
 package config
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"reflect"
 	"regexp"
+	"slices"
 	"strings"
 	"time"
 
@@ -33,6 +35,8 @@ import (
 	"github.com/falcosecurity/falcoctl/internal/utils"
 	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
+	"github.com/falcosecurity/falcoctl/pkg/tracing"
 )
 
 var (
@@ -46,6 +50,8 @@ var (
 	IndexesDir string
 	// ClientCredentialsFile name of the file where oauth client credentials are stored. It lives under FalcoctlPath.
 	ClientCredentialsFile string
+	// InstalledFile name of the file where the installed artifacts are recorded. It lives under FalcoctlPath.
+	InstalledFile string
 	// DefaultIndex is the default index for the falcosecurity organization.
 	DefaultIndex Index
 	// DefaultRegistryCredentialConfPath is the default path for the credential store configuration file.
@@ -89,6 +95,10 @@ const (
 	RegistryAuthBasicKey = "registry.auth.basic"
 	// RegistryAuthGcpKey is the Viper key for gcp authentication configuration.
 	RegistryAuthGcpKey = "registry.auth.gcp"
+	// RegistryAuthEcrKey is the Viper key for Amazon ECR authentication configuration.
+	RegistryAuthEcrKey = "registry.auth.ecr"
+	// RegistryAuthAcrKey is the Viper key for Azure ACR authentication configuration.
+	RegistryAuthAcrKey = "registry.auth.acr"
 
 	// IndexesKey is the Viper key for indexes configuration.
 	IndexesKey = "indexes"
@@ -109,6 +119,8 @@ const (
 	ArtifactFollowAssetsDirKey = "artifact.follow.assetsdir"
 	// ArtifactFollowTmpDirKey is the Viper key for follower "pluginsDir" configuration.
 	ArtifactFollowTmpDirKey = "artifact.follow.tmpdir"
+	// ArtifactFollowNodeArtifactsKey is the Viper key for following the artifacts desired on the node.
+	ArtifactFollowNodeArtifactsKey = "artifact.follow.nodeartifacts"
 
 	// ArtifactInstallArtifactsKey is the Viper key for installer "artifacts" configuration.
 	ArtifactInstallArtifactsKey = "artifact.install.refs"
@@ -125,6 +137,10 @@ const (
 	ArtifactAllowedTypesKey = "artifact.allowedTypes"
 	// ArtifactNoVerifyKey is the Viper key for skipping signature verification.
 	ArtifactNoVerifyKey = "artifact.noVerify"
+	// ArtifactConflictPolicyKey is the Viper key for the policy applied to the installed files modified locally.
+	ArtifactConflictPolicyKey = "artifact.conflictPolicy"
+	// ArtifactAllowOverwriteKey is the Viper key for overwriting the files owned by other artifacts.
+	ArtifactAllowOverwriteKey = "artifact.allowOverwrite"
 
 	// DriverKey is the Viper key for driver structure.
 	DriverKey = "driver"
@@ -136,6 +152,56 @@ const (
 	DriverReposKey = "driver.repos"
 	// DriverNameKey is the Viper key for the driver name.
 	DriverNameKey = "driver.name"
+	// DriverModuleParamsKey is the Viper key for the driver module parameters.
+	DriverModuleParamsKey = "driver.moduleParams"
+	// DriverPersistModuleParamsKey is the Viper key to persist the driver module parameters.
+	DriverPersistModuleParamsKey = "driver.persistModuleParams"
+	// DriverLoadOnBootKey is the Viper key to load the driver at boot.
+	DriverLoadOnBootKey = "driver.loadOnBoot"
+	// DriverFalcoBinaryKey is the Viper key for the Falco binary used to retrieve the driver requirements.
+	DriverFalcoBinaryKey = "driver.falcoBinary"
+	// DriverFalcoVersionsKey is the Viper key for the Falco versions endpoint used to retrieve the driver requirements.
+	DriverFalcoVersionsKey = "driver.falcoVersions"
+	// DriverAPIVersionKey is the Viper key for the driver API version required by Falco.
+	DriverAPIVersionKey = "driver.apiVersion"
+	// DriverSchemaVersionKey is the Viper key for the driver schema version required by Falco.
+	DriverSchemaVersionKey = "driver.schemaVersion"
+	// OfflineKey is the Viper key for enabling the offline mode.
+	OfflineKey = "offline"
+	// OfflineLayoutsKey is the Viper key for the local OCI layouts serving repositories in offline mode.
+	OfflineLayoutsKey = "offlineLayouts"
+	// NodeStatusPublishKey is the Viper key for enabling the node status publication.
+	NodeStatusPublishKey = "nodeStatus.publish"
+	// NodeStatusNodeNameKey is the Viper key for the name of the node whose status is published.
+	NodeStatusNodeNameKey = "nodeStatus.nodeName"
+	// NodeStatusMinIntervalKey is the Viper key for the minimum interval between node updates.
+	NodeStatusMinIntervalKey = "nodeStatus.minInterval"
+	// AdvisoriesSourcesKey is the Viper key for the advisory feeds.
+	AdvisoriesSourcesKey = "advisories.sources"
+	// AdvisoriesPolicyKey is the Viper key for the policy applied when a selected version is affected by an advisory.
+	AdvisoriesPolicyKey = "advisories.policy"
+	// AdvisoriesSeverityKey is the Viper key for the minimum severity of the advisories the policy applies to.
+	AdvisoriesSeverityKey = "advisories.severity"
+	// TracingExporterKey is the Viper key for the exporter of the OpenTelemetry spans.
+	TracingExporterKey = "tracing.exporter"
+	// TracingEndpointKey is the Viper key for the OTLP endpoint the spans are exported to.
+	TracingEndpointKey = "tracing.endpoint"
+	// TracingInsecureKey is the Viper key for disabling TLS towards the OTLP endpoint.
+	TracingInsecureKey = "tracing.insecure"
+	// TracingFileKey is the Viper key for the file the spans are written to by the file exporter.
+	TracingFileKey = "tracing.file"
+	// SelfUpdateRepositoryKey is the Viper key for the OCI repository of the falcoctl binary artifacts.
+	SelfUpdateRepositoryKey = "selfUpdate.repository"
+	// SelfUpdateSignatureKeyKey is the Viper key for the cosign public key verifying the falcoctl binary artifacts.
+	SelfUpdateSignatureKeyKey = "selfUpdate.signature.key"
+	// SelfUpdateSignatureIdentityKey is the Viper key for the certificate identity of the keyless signatures.
+	SelfUpdateSignatureIdentityKey = "selfUpdate.signature.certificateIdentity"
+	// SelfUpdateSignatureIdentityRegexpKey is the Viper key for the certificate identity regexp of the keyless signatures.
+	SelfUpdateSignatureIdentityRegexpKey = "selfUpdate.signature.certificateIdentityRegexp"
+	// SelfUpdateSignatureOidcIssuerKey is the Viper key for the certificate OIDC issuer of the keyless signatures.
+	SelfUpdateSignatureOidcIssuerKey = "selfUpdate.signature.certificateOidcIssuer"
+	// SelfUpdateSignatureOidcIssuerRegexpKey is the Viper key for the certificate OIDC issuer regexp of the keyless signatures.
+	SelfUpdateSignatureOidcIssuerRegexpKey = "selfUpdate.signature.certificateOidcIssuerRegexp"
 	// DriverHostRootKey is the Viper key for the driver host root.
 	DriverHostRootKey   = "driver.hostRoot"
 	falcoHostRootEnvKey = "HOST_ROOT"
@@ -168,6 +234,16 @@ type GcpAuth struct {
 	Registry string `mapstructure:"registry"`
 }
 
+// EcrAuth represents an Amazon ECR activation setting.
+type EcrAuth struct {
+	Registry string `mapstructure:"registry"`
+}
+
+// AcrAuth represents an Azure ACR activation setting.
+type AcrAuth struct {
+	Registry string `mapstructure:"registry"`
+}
+
 // Follow represents the follower configuration.
 type Follow struct {
 	Every         time.Duration `mapstructure:"every"`
@@ -188,13 +264,46 @@ type Install struct {
 	NoVerify      bool     `mapstructure:"noVerify"`
 }
 
+// Advisories represents the advisories configuration.
+type Advisories struct {
+	// Sources are the advisory feeds, fetched through the same backends of the indexes.
+	Sources  []Index `mapstructure:"sources"`
+	Policy   string  `mapstructure:"policy"`
+	Severity string  `mapstructure:"severity"`
+}
+
+// Layout is a local OCI layout, a directory or a tar archive of it, serving a repository in offline mode.
+type Layout struct {
+	Repository string `mapstructure:"repository"`
+	Path       string `mapstructure:"path"`
+}
+
+// SelfUpdate represents the self-update configuration.
+type SelfUpdate struct {
+	Repository string
+	// Signature is the trust policy of the falcoctl binary artifacts, nil if not configured.
+	Signature *SelfUpdateSignature
+}
+
+// SelfUpdateSignature represents the cosign parameters verifying the falcoctl binary artifacts.
+type SelfUpdateSignature struct {
+	Key                         string
+	CertificateIdentity         string
+	CertificateIdentityRegexp   string
+	CertificateOidcIssuer       string
+	CertificateOidcIssuerRegexp string
+}
+
 // Driver represents the internal driver configuration (with Type string).
 type Driver struct {
-	Type     []string `mapstructure:"type"`
-	Name     string   `mapstructure:"name"`
-	Repos    []string `mapstructure:"repos"`
-	Version  string   `mapstructure:"version"`
-	HostRoot string   `mapstructure:"hostRoot"`
+	Type                []string `mapstructure:"type"`
+	Name                string   `mapstructure:"name"`
+	Repos               []string `mapstructure:"repos"`
+	Version             string   `mapstructure:"version"`
+	HostRoot            string   `mapstructure:"hostRoot"`
+	ModuleParams        []string `mapstructure:"moduleParams"`
+	PersistModuleParams bool     `mapstructure:"persistModuleParams"`
+	LoadOnBoot          bool     `mapstructure:"loadOnBoot"`
 }
 
 func init() {
@@ -203,6 +312,7 @@ func init() {
 	IndexesFile = filepath.Join(FalcoctlPath, "indexes.yaml")
 	IndexesDir = filepath.Join(FalcoctlPath, "indexes")
 	ClientCredentialsFile = filepath.Join(FalcoctlPath, "clientcredentials.json")
+	InstalledFile = filepath.Join(FalcoctlPath, "installed.yaml")
 	DefaultIndex = Index{
 		Name:    "falcosecurity",
 		URL:     "https://falcosecurity.github.io/falcoctl/index.yaml",
@@ -271,6 +381,14 @@ func Load(path string) error {
 	// Bind to environment variables.
 	viper.AutomaticEnv()
 
+	// The offline mode is enabled by the --offline flag, bound to the same key, by the config or by the environment.
+	offline.Enable(viper.GetBool(OfflineKey))
+
+	// Tracing is set up once per process, spans are flushed by tracing.Shutdown when the command returns.
+	if err := tracing.Setup(context.Background(), Tracing()); err != nil {
+		return fmt.Errorf("unable to set up tracing: %w", err)
+	}
+
 	return nil
 }
 
@@ -285,6 +403,55 @@ func Indexes() ([]Index, error) {
 	return indexes, nil
 }
 
+// AdvisoriesConfig retrieves the advisories section of the config file.
+func AdvisoriesConfig() (Advisories, error) {
+	var sources []Index
+	if err := viper.UnmarshalKey(AdvisoriesSourcesKey, &sources, viper.DecodeHook(indexListHookFunc())); err != nil {
+		return Advisories{}, fmt.Errorf("unable to get advisory sources from configuration: %w", err)
+	}
+
+	return Advisories{
+		Sources:  sources,
+		Policy:   viper.GetString(AdvisoriesPolicyKey),
+		Severity: viper.GetString(AdvisoriesSeverityKey),
+	}, nil
+}
+
+// OfflineLayouts retrieves the local OCI layouts serving repositories in offline mode.
+func OfflineLayouts() ([]Layout, error) {
+	var layouts []Layout
+	if err := viper.UnmarshalKey(OfflineLayoutsKey, &layouts); err != nil {
+		return nil, fmt.Errorf("unable to get offline layouts from configuration: %w", err)
+	}
+	return layouts, nil
+}
+
+// Tracing retrieves the tracing section of the config file.
+func Tracing() *tracing.Config {
+	return &tracing.Config{
+		Exporter: viper.GetString(TracingExporterKey),
+		Endpoint: viper.GetString(TracingEndpointKey),
+		Insecure: viper.GetBool(TracingInsecureKey),
+		File:     viper.GetString(TracingFileKey),
+	}
+}
+
+// SelfUpdateConfig retrieves the self-update section of the config file.
+func SelfUpdateConfig() SelfUpdate {
+	conf := SelfUpdate{Repository: viper.GetString(SelfUpdateRepositoryKey)}
+	sig := SelfUpdateSignature{
+		Key:                         viper.GetString(SelfUpdateSignatureKeyKey),
+		CertificateIdentity:         viper.GetString(SelfUpdateSignatureIdentityKey),
+		CertificateIdentityRegexp:   viper.GetString(SelfUpdateSignatureIdentityRegexpKey),
+		CertificateOidcIssuer:       viper.GetString(SelfUpdateSignatureOidcIssuerKey),
+		CertificateOidcIssuerRegexp: viper.GetString(SelfUpdateSignatureOidcIssuerRegexpKey),
+	}
+	if sig != (SelfUpdateSignature{}) {
+		conf.Signature = &sig
+	}
+	return conf
+}
+
 // Gcps retrieves the gcp auth section of the config file.
 func Gcps() ([]GcpAuth, error) {
 	var auths []GcpAuth
@@ -296,6 +463,30 @@ func Gcps() ([]GcpAuth, error) {
 	return auths, nil
 }
 
+// Ecrs retrieves the ecr auth section of the config file.
+func Ecrs() ([]EcrAuth, error) {
+	var auths []EcrAuth
+
+	hook := registryListHookFunc(func(reg string) EcrAuth { return EcrAuth{Registry: reg} })
+	if err := viper.UnmarshalKey(RegistryAuthEcrKey, &auths, viper.DecodeHook(hook)); err != nil {
+		return nil, fmt.Errorf("unable to get ecrAuths: %w", err)
+	}
+
+	return auths, nil
+}
+
+// Acrs retrieves the acr auth section of the config file.
+func Acrs() ([]AcrAuth, error) {
+	var auths []AcrAuth
+
+	hook := registryListHookFunc(func(reg string) AcrAuth { return AcrAuth{Registry: reg} })
+	if err := viper.UnmarshalKey(RegistryAuthAcrKey, &auths, viper.DecodeHook(hook)); err != nil {
+		return nil, fmt.Errorf("unable to get acrAuths: %w", err)
+	}
+
+	return auths, nil
+}
+
 // indexListHookFunc returns a DecodeHookFunc that converts
 // strings to string slices, when the target type is DotSeparatedStringList.
 // when passed as env should be in the following format:
@@ -518,6 +709,43 @@ func gcpAuthListHookFunc() mapstructure.DecodeHookFuncType {
 	}
 }
 
+// registryListHookFunc returns a DecodeHookFunc that converts strings to slices of
+// registry settings, built by newAuth, like gcpAuthListHookFunc does.
+// when passed as env should be in the following format:
+// "registry;registry1".
+func registryListHookFunc[T any](newAuth func(registry string) T) mapstructure.DecodeHookFuncType {
+	return func(f reflect.Type, t reflect.Type, data interface{}) (interface{}, error) {
+		if f.Kind() != reflect.String && f.Kind() != reflect.Slice {
+			return data, nil
+		}
+
+		if t != reflect.TypeOf([]T{}) {
+			return data, fmt.Errorf("unable to decode data since destination variable is not of type %T", []T{})
+		}
+
+		switch f.Kind() {
+		case reflect.String:
+			if !SemicolonSeparatedRegexp.MatchString(data.(string)) {
+				return data, fmt.Errorf("env variable not correctly set, should match %q, got %q", SemicolonSeparatedRegexp.String(), data.(string))
+			}
+			tokens := strings.Split(data.(string), ";")
+			auths := make([]T, len(tokens))
+			for i, token := range tokens {
+				auths[i] = newAuth(token)
+			}
+			return auths, nil
+		case reflect.Slice:
+			var auths []T
+			if err := mapstructure.WeakDecode(data, &auths); err != nil {
+				return err, nil
+			}
+			return auths, nil
+		default:
+			return nil, nil
+		}
+	}
+}
+
 // Follower retrieves the follower section of the config file.
 func Follower() (Follow, error) {
 	// with Follow we can just use nested keys.
@@ -588,6 +816,19 @@ func DriverRepos() ([]string, error) {
 	return repos, nil
 }
 
+// DriverModuleParams retrieves the driver module parameters of the config file.
+func DriverModuleParams() ([]string, error) {
+	// manage driver.moduleParams as ";" separated list.
+	params := viper.GetStringSlice(DriverModuleParamsKey)
+	if len(params) == 1 { // in this case it might come from the env
+		if !SemicolonSeparatedRegexp.MatchString(params[0]) {
+			return params, fmt.Errorf("env variable not correctly set, should match %q, got %q", SemicolonSeparatedRegexp.String(), params[0])
+		}
+		params = strings.Split(params[0], ";")
+	}
+	return params, nil
+}
+
 // StoreDriver stores a driver conf in config file.
 func StoreDriver(driverCfg *Driver, configFile string) error {
 	if err := UpdateConfigFile(DriverKey, driverCfg, configFile); err != nil {
@@ -734,6 +975,44 @@ func AddGcp(gcps []GcpAuth, configFile string) error {
 	return nil
 }
 
+// AddEcr appends the provided ecrs to a configuration file if not present.
+func AddEcr(ecrs []EcrAuth, configFile string) error {
+	currEcrs, err := Ecrs()
+	if err != nil {
+		return err
+	}
+	for _, ecr := range ecrs {
+		if !slices.Contains(currEcrs, ecr) {
+			currEcrs = append(currEcrs, ecr)
+		}
+	}
+
+	if err := UpdateConfigFile(RegistryAuthEcrKey, currEcrs, configFile); err != nil {
+		return fmt.Errorf("unable to update ecrs list in the config file %q: %w", configFile, err)
+	}
+
+	return nil
+}
+
+// AddAcr appends the provided acrs to a configuration file if not present.
+func AddAcr(acrs []AcrAuth, configFile string) error {
+	currAcrs, err := Acrs()
+	if err != nil {
+		return err
+	}
+	for _, acr := range acrs {
+		if !slices.Contains(currAcrs, acr) {
+			currAcrs = append(currAcrs, acr)
+		}
+	}
+
+	if err := UpdateConfigFile(RegistryAuthAcrKey, currAcrs, configFile); err != nil {
+		return fmt.Errorf("unable to update acrs list in the config file %q: %w", configFile, err)
+	}
+
+	return nil
+}
+
 func findGcpInSlice(slice []GcpAuth, val *GcpAuth) (int, bool) {
 	for i, item := range slice {
 		if item.Registry == val.Registry {
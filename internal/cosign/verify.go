@@ -34,7 +34,6 @@ import (
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/options"
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/rekor"
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/sign"
-	cosignError "github.com/sigstore/cosign/v2/cmd/cosign/errors"
 	"github.com/sigstore/cosign/v2/pkg/blob"
 	"github.com/sigstore/cosign/v2/pkg/cosign"
 	"github.com/sigstore/cosign/v2/pkg/cosign/pivkey"
@@ -180,7 +179,8 @@ func (c *VerifyCommand) DoVerify(ctx context.Context, images []string) (err erro
 	keyRef := c.KeyRef
 	certRef := c.CertRef
 
-	if !c.IgnoreSCT {
+	// Signed Certificate Timestamps are only verified for keyless signatures.
+	if !c.IgnoreSCT && keylessVerification(c.KeyRef, c.Sk) {
 		co.CTLogPubKeys, err = cosign.GetCTLogPubs(ctx)
 		if err != nil {
 			return fmt.Errorf("getting ctlog public keys: %w", err)
@@ -275,7 +275,8 @@ func (c *VerifyCommand) DoVerify(ctx context.Context, images []string) (err erro
 
 			_, _, err = cosign.VerifyImageSignatures(ctx, ref, co)
 			if err != nil {
-				return cosignError.WrapError(err)
+				// Keep the error chain, so that callers can tell verification failures from transport errors.
+				return err
 			}
 		}
 	}
@@ -17,9 +17,7 @@ package follower
 
 import (
 	"context"
-	"crypto/sha256"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -31,16 +29,22 @@ import (
 	"github.com/blang/semver"
 	"github.com/pterm/pterm"
 	"github.com/robfig/cron/v3"
+	"go.opentelemetry.io/otel/attribute"
 	"oras.land/oras-go/v2/registry"
 
 	"github.com/falcosecurity/falcoctl/internal/config"
 	"github.com/falcosecurity/falcoctl/internal/signature"
 	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/advisory"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/installed"
+	"github.com/falcosecurity/falcoctl/pkg/nodestatus"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
 	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
 	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
 	"github.com/falcosecurity/falcoctl/pkg/output"
+	"github.com/falcosecurity/falcoctl/pkg/tracing"
 )
 
 // Follower knows how to track an artifact in a remote repository given the reference.
@@ -84,6 +88,18 @@ type Config struct {
 	AllowedTypes oci.ArtifactTypeSlice
 	// Signature has the data needed for signature checking
 	Signature *index.Signature
+	// NodeStatus publishes the installed artifacts and the follower health, if not nil.
+	NodeStatus *nodestatus.Publisher
+	// StateFile is the file where the installed artifacts are recorded, if not empty.
+	StateFile string
+	// ConflictPolicy is the policy applied to the files modified locally, the default one if empty.
+	ConflictPolicy string
+	// AllowOverwrite allows to overwrite the files owned by other artifacts.
+	AllowOverwrite bool
+	// Advisories applies the advisory policy to the new versions, if not nil.
+	Advisories *advisory.Checker
+	// Entry is the index entry of the artifact, if any, listing its versions.
+	Entry *index.Entry
 }
 
 var (
@@ -154,11 +170,15 @@ func (f *Follower) Follow(ctx context.Context) {
 }
 
 func (f *Follower) follow(ctx context.Context) {
+	ctx, span := tracing.Start(ctx, "follower.Cycle", attribute.String("ref", f.ref))
+	defer span.End()
+
 	// First thing get the descriptor from remote repo.
 	f.logger.Debug("Fetching descriptor from remote repository...", f.logger.Args("followerName", f.ref))
 	desc, err := f.Descriptor(ctx, f.ref)
 	if err != nil {
 		f.logger.Debug(fmt.Sprintf("an error occurred while fetching descriptor from remote repository: %v", err))
+		f.setStatus(ctx, err)
 		return
 	}
 	f.logger.Debug("Descriptor correctly fetched", f.logger.Args("followerName", f.ref))
@@ -167,21 +187,35 @@ func (f *Follower) follow(ctx context.Context) {
 	// TODO(alacuku): check that the file also exists to cover the case when someone has removed the file.
 	if desc.Digest.String() == f.currentDigest {
 		f.logger.Debug("Nothing to do, artifact already up to date.", f.logger.Args("followerName", f.ref))
+		f.setStatus(ctx, nil)
 		return
 	}
 
 	f.logger.Info("Found new artifact version", f.logger.Args("followerName", f.ref, "tag", f.tag))
+	f.logListedVersions(desc.Digest.String())
 
 	// Pull config layer to check falco versions
 	artifactConfig, err := f.ArtifactConfig(ctx, f.ref, runtime.GOOS, runtime.GOARCH)
 	if err != nil {
 		f.logger.Error("Unable to pull config layer", f.logger.Args("followerName", f.ref, "reason", err.Error()))
+		f.setStatus(ctx, err)
 		return
 	}
 
 	err = f.checkRequirements(artifactConfig)
 	if err != nil {
 		f.logger.Error("Unmet requirements", f.logger.Args("followerName", f.ref, "reason", err.Error()))
+		f.setStatus(ctx, err)
+		return
+	}
+
+	version := artifactConfig.Version
+	if version == "" {
+		version = f.tag
+	}
+	if err := f.Advisories.Check(ctx, f.logger, f.ref, f.artifactName(artifactConfig), version); err != nil {
+		f.logger.Error("Version blocked by advisories", f.logger.Args("followerName", f.ref, "reason", err.Error()))
+		f.setStatus(ctx, err)
 		return
 	}
 
@@ -190,6 +224,7 @@ func (f *Follower) follow(ctx context.Context) {
 	filePaths, res, err := f.pull(ctx)
 	if err != nil {
 		f.logger.Error("Unable to pull artifact", f.logger.Args("followerName", f.ref, "reason", err.Error()))
+		f.setStatus(ctx, err)
 		return
 	}
 	f.logger.Debug("Artifact correctly pulled", f.logger.Args("followerName", f.ref))
@@ -200,26 +235,100 @@ func (f *Follower) follow(ctx context.Context) {
 	err = utils.ExistsAndIsWritable(dstDir)
 	if err != nil {
 		f.logger.Error("Invalid destination", f.logger.Args("followerName", f.ref, "directory", dstDir, "reason", err.Error()))
+		f.setStatus(ctx, err)
 		return
 	}
 
-	// Move files to their destination
-	if err := f.moveFiles(filePaths, dstDir); err != nil {
+	// Move files to their destination, recording them in the state to protect them from being overwritten by the
+	// next installations: on failure, the artifact is installed again at the next sync.
+	if err := f.moveFiles(res, artifactConfig, filePaths, dstDir); err != nil {
+		f.setStatus(ctx, err)
 		return
 	}
-
 	f.logger.Info("Artifact correctly installed",
 		f.logger.Args("followerName", f.ref, "artifactName", f.ref, "type", res.Type, "digest", res.Digest, "directory", dstDir))
 	f.currentDigest = desc.Digest.String()
+	f.NodeStatus.SetArtifact(ctx, nodestatus.Artifact{
+		Ref:         f.ref,
+		Type:        res.Type.String(),
+		Version:     res.Config.Version,
+		Digest:      res.Digest,
+		RootDigest:  res.RootDigest,
+		InstalledAt: time.Now(),
+	})
+	f.setStatus(ctx, nil)
+}
+
+// logListedVersions logs the version with the given digest, and the newer ones, listed by the index entry of the artifact.
+func (f *Follower) logListedVersions(digest string) {
+	if f.Entry == nil {
+		return
+	}
+	if v, ok := f.Entry.VersionByDigest(digest); ok {
+		f.logger.Info("New version listed by the index", f.logger.Args("followerName", f.ref, "version", v.Version,
+			"release date", v.ReleaseDate, "changelog", v.ChangelogSummary()))
+		if v.Deprecated {
+			f.logger.Warn("Installing a deprecated version", f.logger.Args("followerName", f.ref, "version", v.Version))
+		}
+	}
+	for _, v := range f.Entry.NewerVersions(f.tag) {
+		f.logger.Info("Newer version listed by the index", f.logger.Args("followerName", f.ref, "version", v.Version,
+			"release date", v.ReleaseDate, "changelog", v.ChangelogSummary()))
+	}
+}
+
+// setStatus publishes the follower status after a sync, which failed if err is not nil.
+func (f *Follower) setStatus(ctx context.Context, err error) {
+	status := nodestatus.Follower{
+		LastSync: time.Now(),
+		Health:   nodestatus.Healthy,
+	}
+	if err != nil {
+		status.Health = nodestatus.Unhealthy
+		status.Error = err.Error()
+	}
+	tracing.RecordError(ctx, err)
+	f.NodeStatus.SetFollower(ctx, f.ref, status)
+}
+
+// artifact returns the installed artifact to record in the state file.
+func (f *Follower) artifact(res *oci.RegistryResult, artifactConfig *oci.ArtifactConfig, files []installed.File) installed.Artifact {
+	return installed.Artifact{
+		Ref:          f.ref,
+		Name:         f.artifactName(artifactConfig),
+		Version:      artifactConfig.Version,
+		Type:         res.Type.String(),
+		Digest:       res.RootDigest,
+		Dependencies: artifactConfig.Dependencies,
+		InstalledAt:  time.Now().UTC(),
+		Files:        files,
+	}
+}
+
+// artifactName returns the name of the artifact from its config, falling back to the repository name.
+func (f *Follower) artifactName(artifactConfig *oci.ArtifactConfig) string {
+	if artifactConfig.Name != "" {
+		return artifactConfig.Name
+	}
+	name, _ := utils.NameFromRef(f.ref)
+	return name
 }
 
 // moveFiles moves files from their temporary location to the destination directory.
 // It preserves the directory structure relative to the temporary directory.
 // For example, if a file is at "tmpDir/subdir/file.yaml", it will be moved to
 // "dstDir/subdir/file.yaml". This ensures that files in subdirectories are moved
-// correctly as individual files, not as entire directories.
-func (f *Follower) moveFiles(filePaths []string, dstDir string) error {
-	// Install the artifacts if necessary.
+// correctly as individual files, not as entire directories. The files owned by other artifacts
+// are overwritten only if allowed, and the files modified locally are handled according to the
+// configured conflict policy. The installed files are recorded in the state file, if configured, while holding
+// its lock, so that the files shipped by concurrent followers are checked against each other.
+func (f *Follower) moveFiles(res *oci.RegistryResult, artifactConfig *oci.ArtifactConfig, filePaths []string, dstDir string) error {
+	// The files are recorded with their absolute path, to match the ones written by the other commands.
+	dstDir, err := filepath.Abs(dstDir)
+	if err != nil {
+		return err
+	}
+	moves := make([]installed.Move, 0, len(filePaths))
 	for _, path := range filePaths {
 		// Get the relative path from the temporary directory to preserve directory structure
 		relPath, err := filepath.Rel(f.tmpDir, path)
@@ -227,57 +336,25 @@ func (f *Follower) moveFiles(filePaths []string, dstDir string) error {
 			f.logger.Error("Unable to get relative path", f.logger.Args("followerName", f.ref, "path", path, "reason", err.Error()))
 			return err
 		}
+		f.logger.Debug("Installing file", f.logger.Args("followerName", f.ref, "path", relPath, "destDirectory", dstDir))
+		moves = append(moves, installed.Move{Src: path, Dst: filepath.Join(dstDir, relPath)})
+	}
 
-		dstPath := filepath.Join(dstDir, relPath)
-		// Ensure the parent directory exists
-		if err := os.MkdirAll(filepath.Dir(dstPath), 0o750); err != nil {
-			f.logger.Error("Unable to create destination directory", f.logger.Args(
-				"followerName", f.ref,
-				"directory", filepath.Dir(dstPath),
-				"reason", err.Error(),
-			))
-			return err
-		}
-
-		f.logger.Debug("Installing file", f.logger.Args("followerName", f.ref, "path", relPath))
-		// Check if the file exists.
-		f.logger.Debug("Checking if file already exists", f.logger.Args("followerName", f.ref, "path", relPath, "directory", dstDir))
-		exists, err := utils.FileExists(dstPath)
-		if err != nil {
-			f.logger.Error("Unable to check existence for file", f.logger.Args("followerName", f.ref, "path", relPath, "reason", err.Error()))
-			return err
-		}
-
-		if !exists {
-			f.logger.Debug("Moving file", f.logger.Args("followerName", f.ref, "path", relPath, "destDirectory", dstDir))
-			if err = utils.Move(path, dstPath); err != nil {
-				f.logger.Error("Unable to move file", f.logger.Args("followerName", f.ref, "path", relPath, "destDirectory", dstDir, "reason", err.Error()))
-				return err
+	err = installed.Transaction(f.StateFile, f.ConflictPolicy, f.AllowOverwrite, func(installer *installed.Installer) (installed.Artifact, error) {
+		files, conflicts, err := installer.Install(f.ref, moves)
+		for _, c := range conflicts {
+			if c.Owner != "" {
+				f.logger.Warn("File owned by another artifact", f.logger.Args("followerName", f.ref, "path", c.Path, "owner", c.Owner))
 			}
-			f.logger.Debug("File correctly installed", f.logger.Args("followerName", f.ref, "path", path))
-			// It's done, move to the next file.
-			continue
-		}
-
-		f.logger.Debug(fmt.Sprintf("file %q already exists in %q, checking if it is equal to the existing one", relPath, dstDir),
-			f.logger.Args("followerName", f.ref))
-
-		// Check if the files are equal.
-		eq, err := equal([]string{path, dstPath})
-		if err != nil {
-			f.logger.Error("Unable to compare files", f.logger.Args("followerName", f.ref, "existingFile", dstPath, "reason", err.Error()))
-			return err
-		}
-
-		if !eq {
-			f.logger.Debug(fmt.Sprintf("Overwriting file %q with file %q", dstPath, path), f.logger.Args("followerName", f.ref))
-			if err = utils.Move(path, dstPath); err != nil {
-				f.logger.Error("Unable to overwrite file", f.logger.Args("followerName", f.ref, "existingFile", dstPath, "reason", err.Error()))
-				return err
+			if c.Policy != "" {
+				f.logger.Warn("File modified locally", f.logger.Args("followerName", f.ref, "path", c.Path, "policy", c.Policy, "saved", c.Saved))
 			}
-		} else {
-			f.logger.Debug("The two file are equal, nothing to be done")
 		}
+		return f.artifact(res, artifactConfig, files), err
+	})
+	if err != nil {
+		f.logger.Error("Unable to install files", f.logger.Args("followerName", f.ref, "destDirectory", dstDir, "reason", err.Error()))
+		return err
 	}
 	return nil
 }
@@ -358,7 +435,7 @@ func (f *Follower) checkRequirements(artifactConfig *oci.ArtifactConfig) error {
 		reqName := requirement.Name
 		falcoVer, ok := f.FalcoVersions[requirement.Name]
 		if !ok {
-			return fmt.Errorf("unrecognized key %s: Falco does not satisfy this requirement", reqName)
+			return errdefs.Errorf(errdefs.ErrRequirementsUnmet, "unrecognized key %s: Falco does not satisfy this requirement", reqName)
 		}
 		if isInt.MatchString(requirement.Version) { // handle integers
 			falcoVerInt, err := strconv.Atoi(falcoVer)
@@ -372,7 +449,7 @@ func (f *Follower) checkRequirements(artifactConfig *oci.ArtifactConfig) error {
 			}
 
 			if falcoVerInt < reqVerInt {
-				return fmt.Errorf("incompatible versions, Falco: %d, Requirement: %s:%d", falcoVerInt, reqName, reqVerInt)
+				return errdefs.Errorf(errdefs.ErrRequirementsUnmet, "incompatible versions, Falco: %d, Requirement: %s:%d", falcoVerInt, reqName, reqVerInt)
 			}
 		} else { // handle semver
 			falcoSemver, err := semver.Parse(falcoVer)
@@ -387,9 +464,11 @@ func (f *Follower) checkRequirements(artifactConfig *oci.ArtifactConfig) error {
 
 			// Normal semver check
 			if falcoSemver.Major != reqSemver.Major {
-				return fmt.Errorf("incompatible versions, MAJOR mismatch, Falco: %s, Requirement: %s:%s", falcoSemver.String(), reqName, reqSemver.String())
+				return errdefs.Errorf(errdefs.ErrRequirementsUnmet, "incompatible versions, MAJOR mismatch, Falco: %s, Requirement: %s:%s",
+					falcoSemver.String(), reqName, reqSemver.String())
 			} else if falcoSemver.Compare(reqSemver) < 0 {
-				return fmt.Errorf("incompatible versions, MINOR mismatch, Falco: %s, Requirement: %s:%s", falcoSemver.String(), reqName, reqSemver.String())
+				return errdefs.Errorf(errdefs.ErrRequirementsUnmet, "incompatible versions, MINOR mismatch, Falco: %s, Requirement: %s:%s",
+					falcoSemver.String(), reqName, reqSemver.String())
 			}
 		}
 	}
@@ -402,39 +481,3 @@ func (f *Follower) cleanUp() {
 		f.logger.Warn("Unable to clean working directory", f.logger.Args("followerName", f.ref, "directory", f.tmpDir, "reason", err))
 	}
 }
-
-// equal checks if the two files are equal by comparing their sha256 hashes.
-func equal(files []string) (bool, error) {
-	var hashes []string
-	if len(files) != 2 {
-		return false, fmt.Errorf("expecting 2 files but got %d", len(files))
-	}
-
-	hasher := sha256.New()
-
-	for _, file := range files {
-		filePath := filepath.Clean(file)
-		f, err := os.Open(filePath)
-		if err != nil {
-			return false, err
-		}
-
-		if _, err := io.Copy(hasher, f); err != nil {
-			return false, err
-		}
-		hashes = append(hashes, string(hasher.Sum([]byte{})))
-
-		// Clean up.
-		if err := f.Close(); err != nil {
-			return false, fmt.Errorf("unable to close file %q: %w", filePath, err)
-		}
-
-		hasher.Reset()
-	}
-
-	if hashes[0] != hashes[1] {
-		return false, nil
-	}
-
-	return true, nil
-}
@@ -18,11 +18,14 @@ package follower
 import (
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 
 	"github.com/pterm/pterm"
 	"github.com/stretchr/testify/assert"
 
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/installed"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
 	"github.com/falcosecurity/falcoctl/pkg/output"
 )
@@ -262,9 +265,11 @@ func TestMoveFiles(t *testing.T) {
 				assert.NoError(t, err)
 			}
 
+			stateFile := filepath.Join(tmpDir, "installed.yaml")
 			f, err := New("test-registry/test-ref", output.NewPrinter(pterm.LogLevelDebug, pterm.LogFormatterJSON, os.Stdout), &Config{
 				RulesfilesDir: dstDir,
 				TmpDir:        tmpDir,
+				StateFile:     stateFile,
 			})
 			assert.NoError(t, err)
 
@@ -279,8 +284,12 @@ func TestMoveFiles(t *testing.T) {
 			}
 
 			f.currentDigest = "test-digest"
-			err = f.moveFiles(paths, dstDir)
+			err = f.moveFiles(&oci.RegistryResult{Type: oci.Rulesfile}, &oci.ArtifactConfig{}, paths, dstDir)
 			assert.NoError(t, err)
+			state, err := installed.Load(stateFile)
+			assert.NoError(t, err)
+			assert.Len(t, state.Artifacts, 1)
+			assert.Len(t, state.Artifacts[0].Files, len(tt.files))
 
 			for _, tf := range tt.files {
 				dstPath := filepath.Join(dstDir, tf.path)
@@ -291,6 +300,10 @@ func TestMoveFiles(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, tf.content, string(content), "file content should match at %s", dstPath)
 
+				// Files modified locally are backed up with the default conflict policy.
+				_, err = os.Stat(dstPath + installed.BackupFileSuffix)
+				assert.Equal(t, tf.replace, err == nil, "backup existence mismatch for %s", dstPath)
+
 				// For files marked as replace=false, verify they have identical content with existing files
 				if !tf.replace {
 					for _, ef := range tt.existing {
@@ -303,3 +316,55 @@ func TestMoveFiles(t *testing.T) {
 		})
 	}
 }
+
+func TestMoveFilesConcurrentFollowers(t *testing.T) {
+	tmpDir := t.TempDir()
+	dstDir := t.TempDir()
+	stateFile := filepath.Join(tmpDir, "installed.yaml")
+	printer := output.NewPrinter(pterm.LogLevelDebug, pterm.LogFormatterJSON, os.Stdout)
+
+	// Two followers of different artifacts shipping the same file.
+	refs := []string{"test-registry/first:latest", "test-registry/second:latest"}
+	followers := make([]*Follower, len(refs))
+	paths := make([][]string, len(refs))
+	for i, ref := range refs {
+		f, err := New(ref, printer, &Config{RulesfilesDir: dstDir, TmpDir: tmpDir, StateFile: stateFile})
+		assert.NoError(t, err)
+		path := filepath.Join(f.tmpDir, "rules.yaml")
+		assert.NoError(t, os.WriteFile(path, []byte(ref), 0o600))
+		followers[i], paths[i] = f, []string{path}
+	}
+
+	errs := make([]error, len(followers))
+	var wg sync.WaitGroup
+	for i, f := range followers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs[i] = f.moveFiles(&oci.RegistryResult{Type: oci.Rulesfile}, &oci.ArtifactConfig{}, paths[i], dstDir)
+		}()
+	}
+	wg.Wait()
+
+	// Only one of them installs the file, the other one finds it owned by the first.
+	var installedBy string
+	for i, err := range errs {
+		if err == nil {
+			assert.Empty(t, installedBy, "both followers installed the file")
+			installedBy = refs[i]
+			continue
+		}
+		assert.ErrorIs(t, err, errdefs.ErrFileConflict)
+	}
+	assert.NotEmpty(t, installedBy, "no follower installed the file")
+
+	content, err := os.ReadFile(filepath.Join(dstDir, "rules.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, installedBy, string(content))
+
+	state, err := installed.Load(stateFile)
+	assert.NoError(t, err)
+	owner, ok := state.Owner(filepath.Join(dstDir, "rules.yaml"))
+	assert.True(t, ok)
+	assert.Equal(t, installedBy, owner.Ref)
+}
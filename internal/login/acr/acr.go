@@ -0,0 +1,49 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package acr
+
+import (
+	"context"
+	"fmt"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
+	"github.com/falcosecurity/falcoctl/pkg/oci/registry"
+)
+
+// Login checks if the Azure credentials grant access to the registry.
+func Login(ctx context.Context, reg string) error {
+	// Check that we can retrieve an authorization token.
+	cred, _, err := authn.ACRToken(ctx, reg)
+	if err != nil {
+		return fmt.Errorf("wrong Azure credentials, unable to retrieve token: %w", err)
+	}
+
+	// Check connection to the registry
+	client := &auth.Client{Credential: auth.StaticCredential(reg, cred)}
+
+	r, err := registry.NewRegistry(reg, registry.WithClient(client))
+	if err != nil {
+		return err
+	}
+
+	if err := r.CheckConnection(ctx); err != nil {
+		return fmt.Errorf("unable to connect to registry %q: %w", reg, err)
+	}
+
+	return nil
+}
@@ -18,7 +18,6 @@ package basic
 import (
 	"context"
 	"fmt"
-	"net/http"
 	"strings"
 
 	"oras.land/oras-go/v2/registry/remote/auth"
@@ -27,8 +26,9 @@ import (
 	"github.com/falcosecurity/falcoctl/pkg/oci/registry"
 )
 
-// Login checks if passed credentials are correct and stores them.
-func Login(ctx context.Context, client *auth.Client, credStore credentials.Store, reg, username, password string) error {
+// Login checks if passed credentials are correct and stores them. If insecure is true, the registry can be
+// reached through plain HTTP.
+func Login(ctx context.Context, client *auth.Client, credStore credentials.Store, reg, username, password string, insecure bool) error {
 	cred := auth.Credential{
 		Username: username,
 		Password: password,
@@ -36,10 +36,6 @@ func Login(ctx context.Context, client *auth.Client, credStore credentials.Store
 
 	client.Credential = auth.StaticCredential(reg, cred)
 
-	// Check if client is configured for insecure connections
-	transport, ok := client.Client.Transport.(*http.Transport)
-	insecure := ok && transport.TLSClientConfig != nil && transport.TLSClientConfig.InsecureSkipVerify
-
 	// If the registry URL starts with https://, force HTTPS
 	forceHTTPS := strings.HasPrefix(reg, "https://")
 	// If the registry URL starts with http://, force HTTP
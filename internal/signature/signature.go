@@ -17,15 +17,27 @@ package signature
 
 import (
 	"context"
+	"errors"
 
 	"github.com/sigstore/cosign/v2/cmd/cosign/cli/options"
+	sigcosign "github.com/sigstore/cosign/v2/pkg/cosign"
+	"go.opentelemetry.io/otel/attribute"
 
 	"github.com/falcosecurity/falcoctl/internal/cosign"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
+	"github.com/falcosecurity/falcoctl/pkg/tracing"
 )
 
 // Verify checks that a fully qualified reference is signed according to the parameters.
-func Verify(ctx context.Context, ref string, signature *index.Signature) error {
+// A signature that does not verify yields an error of class errdefs.ErrSignatureInvalid, while
+// errors hit retrieving the signature, such as network or authentication failures, are returned as they are.
+// In offline mode, it fails with an error of class errdefs.ErrOffline without reaching the registry.
+func Verify(ctx context.Context, ref string, signature *index.Signature) (err error) {
+	ctx, span := tracing.Start(ctx, "signature.Verify", attribute.String("ref", ref))
+	defer func() { tracing.End(span, err) }()
+
 	if signature == nil {
 		// nothing to do
 		return nil
@@ -36,6 +48,11 @@ func Verify(ctx context.Context, ref string, signature *index.Signature) error {
 		return nil
 	}
 
+	if offline.Enabled() {
+		// the signatures are stored in the registry along with the artifacts
+		return offline.Errorf("signature of %q", ref)
+	}
+
 	v := cosign.VerifyCommand{
 		CertVerifyOptions: options.CertVerifyOptions{
 			CertIdentity:         signature.Cosign.CertificateIdentity,
@@ -46,5 +63,25 @@ func Verify(ctx context.Context, ref string, signature *index.Signature) error {
 		KeyRef:     signature.Cosign.KeyRef,
 		IgnoreTlog: signature.Cosign.IgnoreTlog,
 	}
-	return v.DoVerify(ctx, []string{ref})
+	if err := v.DoVerify(ctx, []string{ref}); err != nil {
+		if isVerificationFailure(err) {
+			return errdefs.Wrap(errdefs.ErrSignatureInvalid, err)
+		}
+		return err
+	}
+	return nil
+}
+
+// isVerificationFailure reports whether err is due to the signature itself, as opposed to transient,
+// transport or authentication issues.
+func isVerificationFailure(err error) bool {
+	var (
+		failure       *sigcosign.VerificationFailure
+		noMatching    *sigcosign.ErrNoMatchingSignatures
+		noSignatures  *sigcosign.ErrNoSignaturesFound
+		noCertificate *sigcosign.ErrNoCertificateFoundOnSignature
+		legacy        *sigcosign.VerificationError
+	)
+	return errors.As(err, &failure) || errors.As(err, &noMatching) || errors.As(err, &noSignatures) ||
+		errors.As(err, &noCertificate) || errors.As(err, &legacy)
 }
@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package signature
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+
+	sigcosign "github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
+)
+
+func TestIsVerificationFailure(t *testing.T) {
+	assert.True(t, isVerificationFailure(fmt.Errorf("verifying: %w", sigcosign.NewVerificationError("no matching signatures"))))
+	assert.False(t, isVerificationFailure(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}))
+	assert.False(t, isVerificationFailure(errors.New("loading public key")))
+}
+
+func TestVerifyOffline(t *testing.T) {
+	offline.Enable(true)
+	t.Cleanup(func() { offline.Enable(false) })
+	ref := "ghcr.io/falcosecurity/rules/falco-rules:latest"
+
+	assert.NoError(t, Verify(context.Background(), ref, nil))
+	err := Verify(context.Background(), ref, &index.Signature{Cosign: &index.CosignSignature{}})
+	assert.ErrorIs(t, err, errdefs.ErrOffline)
+}
@@ -24,6 +24,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 )
 
 // TmpDirPrefix prefix used for the temporary directory where the tar.gz archives live before pushing
@@ -81,6 +82,13 @@ func CreateTarGzArchive(dir, path string, stripComponents bool) (file string, er
 		if err != nil {
 			return "", err
 		}
+		// Drop the fields that depend on the local filesystem, so that archiving
+		// the same content always produces the same archive.
+		header.ModTime = time.Time{}
+		header.AccessTime = time.Time{}
+		header.ChangeTime = time.Time{}
+		header.Uid, header.Gid = 0, 0
+		header.Uname, header.Gname = "", ""
 
 		if err = tw.WriteHeader(header); err != nil {
 			return "", err
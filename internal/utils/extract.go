@@ -25,7 +25,10 @@ import (
 	"path/filepath"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/net/context"
+
+	"github.com/falcosecurity/falcoctl/pkg/tracing"
 )
 
 type link struct {
@@ -34,13 +37,15 @@ type link struct {
 }
 
 // ExtractTarGz extracts a *.tar.gz compressed archive and moves its content to destDir.
-// Returns a slice containing the full path of the extracted files.
-func ExtractTarGz(ctx context.Context, gzipStream io.Reader, destDir string, stripPathComponents int) ([]string, error) {
+// Returns a slice containing the full path of the extracted files, including the hard links and the symbolic links.
+func ExtractTarGz(ctx context.Context, gzipStream io.Reader, destDir string, stripPathComponents int) (_ []string, err error) {
+	_, span := tracing.Start(ctx, "utils.ExtractTarGz", attribute.String("destination", destDir))
+	defer func() { tracing.End(span, err) }()
+
 	var (
 		files    []string
 		links    []link
 		symlinks []link
-		err      error
 	)
 
 	// We need an absolute path
@@ -134,6 +139,7 @@ func ExtractTarGz(ctx context.Context, gzipStream io.Reader, destDir string, str
 		if err = os.Link(links[i].Name, links[i].Path); err != nil {
 			return nil, err
 		}
+		files = append(files, links[i].Path)
 	}
 
 	for i := range symlinks {
@@ -145,6 +151,7 @@ func ExtractTarGz(ctx context.Context, gzipStream io.Reader, destDir string, str
 		if err = os.Symlink(symlinks[i].Name, symlinks[i].Path); err != nil {
 			return nil, err
 		}
+		files = append(files, symlinks[i].Path)
 	}
 	return files, nil
 }
@@ -17,6 +17,7 @@ package utils
 
 import (
 	"archive/tar"
+	"bytes"
 	"compress/gzip"
 	"io"
 	"os"
@@ -208,3 +209,30 @@ func TestExtractTarGzStripComponents(t *testing.T) {
 		assert.Contains(t, list, path)
 	}
 }
+
+func TestExtractTarGzLinks(t *testing.T) {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	tarWriter := tar.NewWriter(gzipWriter)
+	content := []byte("content")
+	assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "rules.yaml", Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(content))}))
+	_, err := tarWriter.Write(content)
+	assert.NoError(t, err)
+	assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "hardlink.yaml", Typeflag: tar.TypeLink, Linkname: "rules.yaml"}))
+	assert.NoError(t, tarWriter.WriteHeader(&tar.Header{Name: "symlink.yaml", Typeflag: tar.TypeSymlink, Linkname: "rules.yaml"}))
+	assert.NoError(t, tarWriter.Close())
+	assert.NoError(t, gzipWriter.Close())
+
+	destDir := t.TempDir()
+	list, err := ExtractTarGz(context.TODO(), &buf, destDir, 0)
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{
+		filepath.Join(destDir, "rules.yaml"),
+		filepath.Join(destDir, "hardlink.yaml"),
+		filepath.Join(destDir, "symlink.yaml"),
+	}, list)
+
+	target, err := os.Readlink(filepath.Join(destDir, "symlink.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, "rules.yaml", target)
+}
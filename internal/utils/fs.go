@@ -20,12 +20,26 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 )
 
 // Move moves oldPath file to to newPath file. It works also on different file system types.
+// Symbolic links are moved as links, not as the files they point to.
 func Move(oldPath, newPath string) error {
 	err := os.Rename(oldPath, newPath)
 	if err != nil {
+		if target, linkErr := os.Readlink(oldPath); linkErr == nil {
+			// the file is a symbolic link, recreate it
+			if err := os.Remove(newPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return fmt.Errorf("unable to replace file %s: %w", newPath, err)
+			}
+			if err := os.Symlink(target, newPath); err != nil {
+				return fmt.Errorf("unable to create link %s: %w", newPath, err)
+			}
+			return os.Remove(oldPath)
+		}
+
 		// if rename fails, just do a copy
 		data, err := os.ReadFile(filepath.Clean(oldPath))
 		if err != nil {
@@ -45,7 +59,7 @@ func Move(oldPath, newPath string) error {
 func ExistsAndIsWritable(path string) error {
 	info, err := os.Stat(path)
 	if errors.Is(err, os.ErrNotExist) {
-		return fmt.Errorf("%s doesn't exists", path)
+		return errdefs.Errorf(errdefs.ErrNotFound, "%s doesn't exists", path)
 	} else if err != nil {
 		return err
 	}
@@ -62,7 +76,7 @@ func ExistsAndIsWritable(path string) error {
 			return err
 		}
 	} else {
-		return fmt.Errorf("%s is not writable", path)
+		return errdefs.Errorf(errdefs.ErrPermission, "%s is not writable", path)
 	}
 
 	return nil
@@ -23,6 +23,7 @@ import (
 	"syscall"
 
 	"github.com/falcosecurity/falcoctl/cmd"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 	"github.com/falcosecurity/falcoctl/pkg/options"
 )
 
@@ -51,8 +52,9 @@ func main() {
 	rootCmd := cmd.New(ctx, opt)
 
 	// Execute the command.
+	// The exit code depends on the class of the error, see pkg/errdefs.
 	if err := cmd.Execute(rootCmd, opt); err != nil {
-		os.Exit(1)
+		os.Exit(errdefs.ExitCode(err))
 	}
-	os.Exit(0)
+	os.Exit(errdefs.ExitOK)
 }
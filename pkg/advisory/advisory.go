@@ -0,0 +1,254 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package advisory
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pterm/pterm"
+	"gopkg.in/yaml.v3"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	indexconf "github.com/falcosecurity/falcoctl/pkg/index/config"
+	"github.com/falcosecurity/falcoctl/pkg/index/fetch"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+)
+
+// Policies applied when a version selected by install or follow is affected by an advisory.
+const (
+	// PolicyIgnore installs the affected version silently.
+	PolicyIgnore = "ignore"
+	// PolicyWarn installs the affected version, logging a warning for each advisory.
+	PolicyWarn = "warn"
+	// PolicyBlock refuses to install the affected version.
+	PolicyBlock = "block"
+)
+
+// Policies lists the allowed policies.
+var Policies = []string{PolicyIgnore, PolicyWarn, PolicyBlock}
+
+// record is an advisory along with the repository of the index entry publishing it, if any.
+type record struct {
+	index.Advisory
+	registry   string
+	repository string
+}
+
+// Database holds the advisories published by the indexes and by the advisory feeds.
+type Database struct {
+	records []record
+}
+
+// Finding is an advisory affecting an artifact version.
+type Finding struct {
+	Ref      string
+	Artifact string
+	Version  string
+	Advisory index.Advisory
+}
+
+// NewDatabase returns a database of the advisories listed by the index entries and of the given feed advisories.
+// Only the entries listing advisories matter. Invalid advisories are left out.
+func NewDatabase(entries []*index.Entry, feed []index.Advisory) *Database {
+	d := &Database{}
+	for _, entry := range entries {
+		for _, a := range entry.Advisories {
+			if a.Artifact == "" {
+				a.Artifact = entry.Name
+			}
+			if a.Validate() == nil {
+				d.records = append(d.records, record{Advisory: a, registry: entry.Registry, repository: entry.Repository})
+			}
+		}
+	}
+	for _, a := range feed {
+		if a.Validate() == nil {
+			d.records = append(d.records, record{Advisory: a})
+		}
+	}
+	return d
+}
+
+// Len returns the number of advisories of the database.
+func (d *Database) Len() int {
+	return len(d.records)
+}
+
+// Match returns the advisories affecting the given version of an artifact, identified by its name or by the
+// repository of its reference, the most severe first.
+func (d *Database) Match(ref, name, version string) []index.Advisory {
+	reg, repo := "", ""
+	if repository, err := utils.RepositoryFromRef(ref); err == nil {
+		reg, repo, _ = strings.Cut(repository, "/")
+	}
+
+	var matches []index.Advisory
+	seen := make(map[string]bool)
+	for i := range d.records {
+		r := &d.records[i]
+		sameRepo := r.repository != "" && r.registry == reg && r.repository == repo
+		if seen[r.ID] || (r.Artifact != name && !sameRepo) || !r.Affects(version) {
+			continue
+		}
+		seen[r.ID] = true
+		matches = append(matches, r.Advisory)
+	}
+	sort.SliceStable(matches, func(i, j int) bool {
+		if ri, rj := index.SeverityRank(matches[i].Severity), index.SeverityRank(matches[j].Severity); ri != rj {
+			return ri > rj
+		}
+		return matches[i].ID < matches[j].ID
+	})
+	return matches
+}
+
+// Parse parses an advisory feed, a YAML list of advisories naming the affected artifact.
+func Parse(data []byte) ([]index.Advisory, error) {
+	var advisories []index.Advisory
+	if err := yaml.Unmarshal(data, &advisories); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal advisories: %w", err)
+	}
+	for i := range advisories {
+		if err := advisories[i].Validate(); err != nil {
+			return nil, err
+		}
+		if advisories[i].Artifact == "" {
+			return nil, fmt.Errorf("advisory %q does not name the affected artifact", advisories[i].ID)
+		}
+	}
+	return advisories, nil
+}
+
+// Fetch retrieves the advisory feeds from the given sources, through the index backends.
+func Fetch(ctx context.Context, sources []config.Index) ([]index.Advisory, error) {
+	fetcher := fetch.NewFetcher()
+	var advisories []index.Advisory
+	for i := range sources {
+		data, err := fetcher.FetchBytes(ctx, indexconf.EntryFromIndex(&sources[i]))
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch advisory feed %q: %w", sources[i].Name, err)
+		}
+		feed, err := Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("invalid advisory feed %q: %w", sources[i].Name, err)
+		}
+		advisories = append(advisories, feed...)
+	}
+	return advisories, nil
+}
+
+// feedTTL is the time after which the checker fetches the advisory feeds again,
+// so that long running followers see the advisories published in the meantime.
+const feedTTL = 10 * time.Minute
+
+// Checker applies the configured policy to the versions selected by install and follow.
+type Checker struct {
+	// Policy is one of Policies, PolicyWarn if empty.
+	Policy string
+	// Severity is the minimum severity of the advisories the policy applies to, all of them if empty.
+	Severity string
+
+	entries []*index.Entry
+	sources []config.Index
+	mu      sync.Mutex
+	db      *Database
+	fetched time.Time
+}
+
+// NewChecker returns a checker of the advisories of the index entries and of the configured feeds.
+func NewChecker(ctx context.Context, logger *pterm.Logger, entries []*index.Entry, conf *config.Advisories) (*Checker, error) {
+	if err := ValidatePolicy(conf.Policy, conf.Severity); err != nil {
+		return nil, err
+	}
+	c := &Checker{Policy: conf.Policy, Severity: conf.Severity, entries: entries, sources: conf.Sources}
+	c.load(ctx, logger)
+	return c, nil
+}
+
+// load fetches the advisory feeds. A feed that cannot be fetched is reported through the logger,
+// the others are used anyway.
+func (c *Checker) load(ctx context.Context, logger *pterm.Logger) {
+	var feed []index.Advisory
+	for i := range c.sources {
+		advisories, err := Fetch(ctx, c.sources[i:i+1])
+		if err != nil {
+			logger.Warn("Unable to load advisory feed", logger.Args("name", c.sources[i].Name, "reason", err.Error()))
+			continue
+		}
+		feed = append(feed, advisories...)
+	}
+	c.db = NewDatabase(c.entries, feed)
+	c.fetched = time.Now()
+}
+
+// ValidatePolicy checks the policy and the minimum severity. Empty values select the defaults.
+func ValidatePolicy(policy, severity string) error {
+	if policy != "" && policy != PolicyIgnore && policy != PolicyWarn && policy != PolicyBlock {
+		return fmt.Errorf("invalid advisory policy %q, allowed values: %s", policy, strings.Join(Policies, ", "))
+	}
+	if severity != "" && index.SeverityRank(severity) < 0 {
+		return fmt.Errorf("invalid advisory severity %q, allowed values: %s", severity, strings.Join(index.Severities, ", "))
+	}
+	return nil
+}
+
+// Findings returns the advisories affecting the given version of an artifact, at least as severe as min.
+func (d *Database) Findings(ref, name, version, minSeverity string) []Finding {
+	var findings []Finding
+	for _, a := range d.Match(ref, name, version) {
+		if index.SeverityRank(a.Severity) < index.SeverityRank(minSeverity) {
+			continue
+		}
+		findings = append(findings, Finding{Ref: ref, Artifact: name, Version: version, Advisory: a})
+	}
+	return findings
+}
+
+// Check applies the policy to the selected version of an artifact: it logs a warning for each advisory affecting it
+// and, with PolicyBlock, returns an error of class errdefs.ErrAdvisory.
+func (c *Checker) Check(ctx context.Context, logger *pterm.Logger, ref, name, version string) error {
+	if c == nil || c.Policy == PolicyIgnore {
+		return nil
+	}
+	c.mu.Lock()
+	if len(c.sources) > 0 && time.Since(c.fetched) > feedTTL {
+		c.load(ctx, logger)
+	}
+	findings := c.db.Findings(ref, name, version, c.Severity)
+	c.mu.Unlock()
+	if len(findings) == 0 {
+		return nil
+	}
+	ids := make([]string, 0, len(findings))
+	for i := range findings {
+		a := &findings[i].Advisory
+		logger.Warn("Selected version is affected by an advisory", logger.Args("ref", ref, "version", version,
+			"advisory", a.ID, "severity", a.Severity, "fixed", a.Fixed, "description", a.Description))
+		ids = append(ids, a.ID)
+	}
+	if c.Policy == PolicyBlock {
+		return errdefs.Errorf(errdefs.ErrAdvisory, "version %s of %q is affected by advisories %s, blocked by the advisory policy",
+			version, name, strings.Join(ids, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package advisory
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const testFeed = `
+- id: FALCO-2025-002
+  artifact: falco-rules
+  affected: ">=3.0.0 <3.2.1"
+  severity: medium
+  fixed: 3.2.1
+  description: Missing detection of reverse shells
+- id: FALCO-2025-003
+  artifact: falco-rules
+  affected: "<4.0.0"
+  severity: critical
+`
+
+func testIndexes() *index.MergedIndexes {
+	idx := index.New("falcosecurity")
+	idx.Upsert(&index.Entry{
+		Name:       "cloudtrail",
+		Type:       "plugin",
+		Registry:   "ghcr.io",
+		Repository: "falcosecurity/plugins/plugin/cloudtrail",
+		Advisories: []index.Advisory{
+			{ID: "FALCO-2025-001", Affected: ">=0.9.0 <0.9.2", Severity: index.SeverityHigh, Fixed: "0.9.2"},
+			{ID: "FALCO-2025-004", Affected: "invalid", Severity: index.SeverityLow},
+		},
+	})
+	indexes := index.NewMergedIndexes()
+	indexes.Merge(idx)
+	return indexes
+}
+
+func TestMatch(t *testing.T) {
+	feed, err := Parse([]byte(testFeed))
+	require.NoError(t, err)
+	db := NewDatabase(testIndexes().Entries, feed)
+	assert.Equal(t, 3, db.Len())
+
+	ids := func(advisories []index.Advisory) []string {
+		var res []string
+		for i := range advisories {
+			res = append(res, advisories[i].ID)
+		}
+		return res
+	}
+
+	// Entry advisories match by name and by repository.
+	assert.Equal(t, []string{"FALCO-2025-001"}, ids(db.Match("ghcr.io/falcosecurity/plugins/plugin/cloudtrail:0.9", "cloudtrail", "0.9.1")))
+	assert.Equal(t, []string{"FALCO-2025-001"}, ids(db.Match("ghcr.io/falcosecurity/plugins/plugin/cloudtrail:0.9", "ct", "0.9.1")))
+	assert.Equal(t, []string{"FALCO-2025-001"}, ids(db.Match("registry.example.com/cloudtrail:0.9.1", "cloudtrail", "0.9.1")))
+	assert.Empty(t, db.Match("ghcr.io/falcosecurity/plugins/plugin/cloudtrail:0.9", "cloudtrail", "0.9.2"))
+	assert.Empty(t, db.Match("ghcr.io/falcosecurity/plugins/plugin/k8saudit:0.9", "k8saudit", "0.9.1"))
+
+	// The most severe first.
+	assert.Equal(t, []string{"FALCO-2025-003", "FALCO-2025-002"},
+		ids(db.Match("ghcr.io/falcosecurity/rules/falco-rules:3", "falco-rules", "3.1.0")))
+
+	findings := db.Findings("ghcr.io/falcosecurity/rules/falco-rules:3", "falco-rules", "3.1.0", index.SeverityHigh)
+	require.Len(t, findings, 1)
+	assert.Equal(t, "FALCO-2025-003", findings[0].Advisory.ID)
+	assert.Equal(t, "3.1.0", findings[0].Version)
+}
+
+func TestParse(t *testing.T) {
+	_, err := Parse([]byte("- id: FALCO-2025-001\n  affected: <1.0.0\n  severity: low\n"))
+	assert.EqualError(t, err, `advisory "FALCO-2025-001" does not name the affected artifact`)
+	_, err = Parse([]byte("- id: FALCO-2025-001\n  artifact: falco-rules\n  affected: <1.0.0\n  severity: urgent\n"))
+	assert.ErrorContains(t, err, `invalid severity "urgent"`)
+	_, err = Parse([]byte("id: FALCO-2025-001"))
+	assert.ErrorContains(t, err, "cannot unmarshal advisories")
+}
+
+func TestFetch(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "advisories.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testFeed), 0o600))
+
+	advisories, err := Fetch(context.Background(), []config.Index{{Name: "feed", URL: "file://" + path}})
+	require.NoError(t, err)
+	assert.Len(t, advisories, 2)
+
+	_, err = Fetch(context.Background(), []config.Index{{Name: "missing", URL: "file://" + path + ".missing"}})
+	assert.ErrorContains(t, err, `unable to fetch advisory feed "missing"`)
+}
+
+func TestChecker(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "advisories.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(testFeed), 0o600))
+	sources := []config.Index{
+		{Name: "feed", URL: "file://" + path},
+		{Name: "missing", URL: "file://" + path + ".missing"},
+	}
+	const ref = "ghcr.io/falcosecurity/rules/falco-rules:3"
+
+	testCases := []struct {
+		policy   string
+		severity string
+		blocked  bool
+		warned   bool
+	}{
+		{policy: "", warned: true},
+		{policy: PolicyWarn, warned: true},
+		{policy: PolicyIgnore},
+		{policy: PolicyBlock, warned: true, blocked: true},
+		{policy: PolicyBlock, severity: index.SeverityCritical, warned: true, blocked: true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.policy+tc.severity, func(t *testing.T) {
+			var buf bytes.Buffer
+			printer := output.NewPrinter(pterm.LogLevelInfo, pterm.LogFormatterJSON, &buf)
+			c, err := NewChecker(context.Background(), printer.Logger, testIndexes().Entries,
+				&config.Advisories{Sources: sources, Policy: tc.policy, Severity: tc.severity})
+			require.NoError(t, err)
+			assert.Contains(t, buf.String(), "Unable to load advisory feed")
+
+			err = c.Check(context.Background(), printer.Logger, ref, "falco-rules", "3.1.0")
+			if tc.blocked {
+				assert.True(t, errors.Is(err, errdefs.ErrAdvisory))
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tc.warned, bytes.Contains(buf.Bytes(), []byte("FALCO-2025-003")))
+			assert.NoError(t, c.Check(context.Background(), printer.Logger, ref, "falco-rules", "4.0.0"))
+		})
+	}
+
+	_, err := NewChecker(context.Background(), nil, nil, &config.Advisories{Policy: "deny"})
+	assert.EqualError(t, err, `invalid advisory policy "deny", allowed values: ignore, warn, block`)
+	_, err = NewChecker(context.Background(), nil, nil, &config.Advisories{Severity: "urgent"})
+	assert.EqualError(t, err, `invalid advisory severity "urgent", allowed values: low, medium, high, critical`)
+	assert.NoError(t, (*Checker)(nil).Check(context.Background(), nil, ref, "falco-rules", "3.1.0"))
+}
@@ -0,0 +1,628 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/blang/semver/v4"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+
+	"github.com/falcosecurity/falcoctl/internal/signature"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/nodestatus"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const (
+	// Finalizer is set on the FalcoArtifactSet resources delivering to nodes, to remove their
+	// artifacts from the nodes once deleted.
+	Finalizer = "falco.org/controller"
+	// AnnotationArtifactSet is the ConfigMap annotation holding the FalcoArtifactSet ("namespace/name") managing it.
+	AnnotationArtifactSet = "falco.org/artifact-set"
+	// AnnotationDelivered is the ConfigMap annotation holding the JSON delivered artifacts, as digest references.
+	AnnotationDelivered = "falco.org/delivered-artifacts"
+
+	// DefaultResync is the default interval between two reconciliations of the same resource.
+	DefaultResync = 5 * time.Minute
+)
+
+// errNotManaged is returned when the ConfigMap destination exists and is not managed by the FalcoArtifactSet.
+var errNotManaged = errors.New("not managed by this resource")
+
+// Verifier verifies the signature of a digest reference.
+type Verifier func(ctx context.Context, ref string, signature *index.Signature) error
+
+// Config configures the controller.
+type Config struct {
+	// Dynamic is the client used for the FalcoArtifactSet resources.
+	Dynamic dynamic.Interface
+	// Client is the client used for nodes and ConfigMaps.
+	Client kubernetes.Interface
+	// Indexes resolves the artifact names.
+	Indexes *index.MergedIndexes
+	// RegistryClient is the client used to access the registries.
+	RegistryClient remote.Client
+	// PlainHTTP enables plain http connections to the registries.
+	PlainHTTP bool
+	// Verifier verifies the signatures. Defaults to the cosign verification.
+	Verifier Verifier
+	// TmpDir is the directory holding the artifacts while they are delivered. Defaults to the system one.
+	TmpDir string
+}
+
+// Controller reconciles the FalcoArtifactSet resources.
+type Controller struct {
+	*Config
+	puller  *ocipuller.Puller
+	printer *output.Printer
+}
+
+// New returns a new Controller.
+func New(printer *output.Printer, conf *Config) (*Controller, error) {
+	if conf.Dynamic == nil || conf.Client == nil {
+		return nil, fmt.Errorf("missing Kubernetes clients")
+	}
+	if conf.Indexes == nil {
+		conf.Indexes = index.NewMergedIndexes()
+	}
+	if conf.Verifier == nil {
+		conf.Verifier = signature.Verify
+	}
+	return &Controller{
+		Config:  conf,
+		puller:  ocipuller.NewPuller(conf.RegistryClient, conf.PlainHTTP, nil),
+		printer: printer,
+	}, nil
+}
+
+// Run watches the FalcoArtifactSet resources of the namespace, all of them if empty, and reconciles them
+// on change and every resync interval, until the context is canceled.
+func (c *Controller) Run(ctx context.Context, namespace string, resync time.Duration) error {
+	queue := workqueue.NewTypedRateLimitingQueue(workqueue.DefaultTypedControllerRateLimiter[string]())
+	defer queue.ShutDown()
+
+	factory := dynamicinformer.NewFilteredDynamicSharedInformerFactory(c.Dynamic, resync, namespace, nil)
+	informer := factory.ForResource(GVR).Informer()
+	enqueue := func(obj interface{}) {
+		if key, err := cache.DeletionHandlingMetaNamespaceKeyFunc(obj); err == nil {
+			queue.Add(key)
+		}
+	}
+	if _, err := informer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc:    enqueue,
+		UpdateFunc: func(_, obj interface{}) { enqueue(obj) },
+	}); err != nil {
+		return fmt.Errorf("unable to watch %s resources: %w", Kind, err)
+	}
+
+	factory.Start(ctx.Done())
+	if !cache.WaitForCacheSync(ctx.Done(), informer.HasSynced) {
+		return fmt.Errorf("unable to sync %s resources: %w", Kind, ctx.Err())
+	}
+	c.printer.Logger.Info("Controller started", c.printer.Logger.Args("namespace", namespace, "resync", resync.String()))
+
+	go func() {
+		<-ctx.Done()
+		queue.ShutDown()
+	}()
+
+	for {
+		key, shutdown := queue.Get()
+		if shutdown {
+			return nil
+		}
+		ns, name, err := cache.SplitMetaNamespaceKey(key)
+		if err == nil {
+			err = c.Reconcile(ctx, ns, name)
+		}
+		if err != nil {
+			c.printer.Logger.Warn("Unable to reconcile", c.printer.Logger.Args("resource", key, "reason", err.Error()))
+			queue.AddRateLimited(key)
+		} else {
+			queue.Forget(key)
+		}
+		queue.Done(key)
+	}
+}
+
+// Reconcile resolves and verifies the artifacts of a FalcoArtifactSet, delivers them to its destination
+// and reports the result in its status. Failures of the artifacts are reported in the status only,
+// while the returned errors are the ones worth a retry.
+func (c *Controller) Reconcile(ctx context.Context, namespace, name string) error {
+	u, err := c.Dynamic.Resource(GVR).Namespace(namespace).Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to get %s %s/%s: %w", Kind, namespace, name, err)
+	}
+	set, err := fromUnstructured(u)
+	if err != nil {
+		return err
+	}
+
+	if set.DeletionTimestamp != nil {
+		return c.finalize(ctx, set)
+	}
+
+	if err := set.Spec.Validate(); err != nil {
+		setCondition(set, ConditionReady, false, "InvalidSpec", err.Error())
+		return c.updateStatus(ctx, set)
+	}
+
+	if set.Spec.Destination.Nodes && !hasFinalizer(set) {
+		if set, err = c.addFinalizer(ctx, set); err != nil {
+			return err
+		}
+	}
+
+	set.Status.Artifacts = c.resolve(ctx, set)
+	resolved, verified := true, true
+	var failures []string
+	for _, a := range set.Status.Artifacts {
+		if a.Digest == "" {
+			resolved = false
+		}
+		if a.Verification == VerificationFailed {
+			verified = false
+		}
+		if a.Error != "" {
+			failures = append(failures, fmt.Sprintf("%s: %s", a.Name, a.Error))
+		}
+	}
+	setCondition(set, ConditionResolved, resolved, reason(resolved, "Resolved", "ResolutionFailed"), strings.Join(failures, "; "))
+	setCondition(set, ConditionVerified, verified, reason(verified, "Verified", "VerificationFailed"), strings.Join(failures, "; "))
+
+	// Deliver all the artifacts or none of them, to never leave the destination in a partial state.
+	var deliveryErr error
+	switch {
+	case !resolved || !verified:
+		setCondition(set, ConditionReady, false, "ArtifactsFailed", "destination not updated, some artifacts failed")
+	default:
+		if set.Spec.Destination.ConfigMap != nil {
+			deliveryErr = c.deliverConfigMap(ctx, set)
+		} else {
+			deliveryErr = c.deliverNodes(ctx, set)
+		}
+		switch {
+		case errors.Is(deliveryErr, errNotManaged):
+			// Retrying does not help until the ConfigMap is removed or handed over, which the resync picks up.
+			setCondition(set, ConditionReady, false, "ConfigMapConflict", deliveryErr.Error())
+			deliveryErr = nil
+		case deliveryErr != nil:
+			setCondition(set, ConditionReady, false, "DeliveryFailed", deliveryErr.Error())
+		default:
+			setCondition(set, ConditionReady, true, "Delivered", "")
+		}
+	}
+
+	if err := c.updateStatus(ctx, set); err != nil {
+		return err
+	}
+	return deliveryErr
+}
+
+// resolve resolves the artifacts of a FalcoArtifactSet to digests, verifying their signatures.
+func (c *Controller) resolve(ctx context.Context, set *FalcoArtifactSet) []ArtifactStatus {
+	statuses := make([]ArtifactStatus, len(set.Spec.Artifacts))
+	for i, a := range set.Spec.Artifacts {
+		st := &statuses[i]
+		st.Name = a.Name
+		repo, err := c.repository(a.Name)
+		if err != nil {
+			st.Error = err.Error()
+			continue
+		}
+		st.Ref = repo
+		if st.Tag, err = c.tag(ctx, repo, a.Version); err != nil {
+			st.Error = err.Error()
+			continue
+		}
+		desc, err := c.puller.Descriptor(ctx, fmt.Sprintf("%s:%s", repo, st.Tag))
+		if err != nil {
+			st.Error = fmt.Sprintf("unable to resolve %s:%s: %s", repo, st.Tag, err)
+			continue
+		}
+		st.Digest = desc.Digest.String()
+		if version, ok := c.Indexes.VersionForRef(fmt.Sprintf("%s:%s", repo, st.Tag)); ok && version.Digest != "" && version.Digest != st.Digest {
+			st.Verification = VerificationFailed
+			st.Error = fmt.Sprintf("digest %s of %s:%s does not match the digest %s listed by the index", st.Digest, repo, st.Tag, version.Digest)
+			continue
+		}
+		st.Verification, err = c.verify(ctx, set, a.Name, fmt.Sprintf("%s@%s", repo, st.Digest))
+		if err != nil {
+			st.Error = err.Error()
+		}
+	}
+	return statuses
+}
+
+// repository returns the repository of an artifact, given its index name or its reference.
+func (c *Controller) repository(name string) (string, error) {
+	if _, err := registry.ParseReference(name); err == nil {
+		return utils.RepositoryFromRef(name)
+	}
+	if strings.ContainsAny(name, ":@") {
+		return "", fmt.Errorf("artifact name %q cannot have a tag or digest, use version instead", name)
+	}
+	ref, err := c.Indexes.ResolveReference(name)
+	if err != nil {
+		return "", err
+	}
+	return utils.RepositoryFromRef(ref)
+}
+
+// tag returns the tag of the repository matching the version: the version itself, or the greatest
+// semver tag in the range if the version is a range.
+func (c *Controller) tag(ctx context.Context, repo, version string) (string, error) {
+	if version == "" {
+		return oci.DefaultTag, nil
+	}
+	if !isRange(version) {
+		return version, nil
+	}
+	rng, err := semver.ParseRange(version)
+	if err != nil {
+		return "", fmt.Errorf("invalid version range %q: %w", version, err)
+	}
+
+	r, err := repository.NewRepository(repo, repository.WithClient(c.RegistryClient), repository.WithPlainHTTP(c.PlainHTTP))
+	if err != nil {
+		return "", err
+	}
+	tags, err := r.Tags(ctx)
+	if err != nil {
+		return "", fmt.Errorf("unable to list tags of %q: %w", repo, errdefs.Classify(err))
+	}
+
+	var best *semver.Version
+	var bestTag string
+	for _, t := range tags {
+		v, err := semver.Parse(t)
+		if err != nil || !rng(v) {
+			continue
+		}
+		if best == nil || v.GT(*best) {
+			best, bestTag = &v, t
+		}
+	}
+	if best == nil {
+		return "", errdefs.Errorf(errdefs.ErrNotFound, "no tag of %q matches %q", repo, version)
+	}
+	return bestTag, nil
+}
+
+// isRange reports whether a version is a semver range rather than a tag.
+func isRange(version string) bool {
+	return strings.ContainsAny(version, "<>=! ")
+}
+
+// verify applies the signature policy of the FalcoArtifactSet to a digest reference.
+func (c *Controller) verify(ctx context.Context, set *FalcoArtifactSet, name, digestRef string) (string, error) {
+	policy := set.Spec.Signature
+	if policy.Mode == SignatureDisabled {
+		return VerificationSkipped, nil
+	}
+
+	sig := policy.Cosign.toIndexSignature()
+	if sig == nil {
+		sig = c.Indexes.SignatureForIndexRef(name)
+	}
+	if sig == nil || sig.Cosign == nil {
+		if policy.Mode == SignatureRequired {
+			return VerificationFailed, fmt.Errorf("no signature available for %q", name)
+		}
+		return VerificationSkipped, nil
+	}
+
+	if err := c.Verifier(ctx, digestRef, sig); err != nil {
+		return VerificationFailed, fmt.Errorf("unable to verify signature of %q: %w", digestRef, err)
+	}
+	return VerificationPassed, nil
+}
+
+// digestRefs returns the digest references of the resolved artifacts.
+func digestRefs(set *FalcoArtifactSet) []string {
+	refs := make([]string, 0, len(set.Status.Artifacts))
+	for _, a := range set.Status.Artifacts {
+		refs = append(refs, fmt.Sprintf("%s@%s", a.Ref, a.Digest))
+	}
+	return refs
+}
+
+// deliverConfigMap writes the files of the artifacts to the ConfigMap destination. An existing ConfigMap is
+// updated only if managed by the FalcoArtifactSet, otherwise an error wrapping errNotManaged is returned.
+func (c *Controller) deliverConfigMap(ctx context.Context, set *FalcoArtifactSet) error {
+	dest := set.Spec.Destination.ConfigMap
+	namespace := dest.Namespace
+	if namespace == "" {
+		namespace = set.Namespace
+	}
+
+	cms := c.Client.CoreV1().ConfigMaps(namespace)
+	current, err := cms.Get(ctx, dest.Name, metav1.GetOptions{})
+	exists := err == nil
+	if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("unable to get ConfigMap %s/%s: %w", namespace, dest.Name, err)
+	}
+	if exists && !managed(current, set) {
+		return fmt.Errorf("ConfigMap %s/%s already exists and is %w", namespace, dest.Name, errNotManaged)
+	}
+
+	tmpDir, err := os.MkdirTemp(c.TmpDir, "falcoctl-controller")
+	if err != nil {
+		return fmt.Errorf("unable to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	data := make(map[string]string)
+	refs := digestRefs(set)
+	for i, ref := range refs {
+		dir := filepath.Join(tmpDir, fmt.Sprint(i))
+		files, err := c.pull(ctx, ref, dir)
+		if err != nil {
+			return err
+		}
+		for name, content := range files {
+			if _, ok := data[name]; ok {
+				return fmt.Errorf("file %q is provided by more than one artifact", name)
+			}
+			data[name] = content
+		}
+	}
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        dest.Name,
+			Namespace:   namespace,
+			Annotations: map[string]string{AnnotationArtifactSet: setKey(set), AnnotationDelivered: mustJSON(refs)},
+		},
+		Data: data,
+	}
+	// Owner references cannot cross namespaces.
+	if namespace == set.Namespace {
+		cm.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(set, GVR.GroupVersion().WithKind(Kind))}
+	}
+
+	if !exists {
+		_, err = cms.Create(ctx, cm, metav1.CreateOptions{})
+	} else {
+		current.Data = cm.Data
+		current.BinaryData = nil
+		if current.Annotations == nil {
+			current.Annotations = make(map[string]string)
+		}
+		current.Annotations[AnnotationArtifactSet] = cm.Annotations[AnnotationArtifactSet]
+		current.Annotations[AnnotationDelivered] = cm.Annotations[AnnotationDelivered]
+		if len(cm.OwnerReferences) > 0 && metav1.GetControllerOf(current) == nil {
+			current.OwnerReferences = append(current.OwnerReferences, cm.OwnerReferences...)
+		}
+		_, err = cms.Update(ctx, current, metav1.UpdateOptions{})
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write ConfigMap %s/%s: %w", namespace, dest.Name, err)
+	}
+	return nil
+}
+
+// managed reports whether a ConfigMap is managed by the FalcoArtifactSet, through its annotation or its
+// controller reference.
+func managed(cm *corev1.ConfigMap, set *FalcoArtifactSet) bool {
+	if ref := metav1.GetControllerOf(cm); ref != nil && ref.Kind == Kind && ref.Name == set.Name && ref.UID == set.UID {
+		return true
+	}
+	return cm.Annotations[AnnotationArtifactSet] == setKey(set)
+}
+
+// pull pulls a rulesfile artifact and returns its files, by name.
+func (c *Controller) pull(ctx context.Context, ref, dir string) (map[string]string, error) {
+	res, err := c.puller.Pull(ctx, ref, dir, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return nil, fmt.Errorf("unable to pull artifact %q: %w", ref, err)
+	}
+	if res.Type != oci.Rulesfile {
+		return nil, fmt.Errorf("artifact %q of type %q cannot be delivered to a ConfigMap", ref, res.Type)
+	}
+
+	f, err := os.Open(filepath.Join(dir, res.Filename))
+	if err != nil {
+		return nil, fmt.Errorf("unable to open file %q: %w", res.Filename, err)
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read %q: %w", res.Filename, err)
+	}
+
+	files := make(map[string]string)
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %q: %w", res.Filename, err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		content, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read %q from %q: %w", hdr.Name, res.Filename, err)
+		}
+		name := path.Base(hdr.Name)
+		if _, ok := files[name]; ok {
+			return nil, fmt.Errorf("artifact %q has more than one file named %q", ref, name)
+		}
+		files[name] = string(content)
+	}
+	return files, nil
+}
+
+// deliverNodes publishes the artifacts on the selected nodes and counts the nodes having them installed.
+// The artifacts of the FalcoArtifactSet are removed from the nodes no longer selected.
+func (c *Controller) deliverNodes(ctx context.Context, set *FalcoArtifactSet) error {
+	selector := labels.SelectorFromSet(set.Spec.NodeSelector)
+	nodes, err := c.Client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to list nodes: %w", err)
+	}
+
+	refs := digestRefs(set)
+	set.Status.Nodes, set.Status.NodesSynced = 0, 0
+	for i := range nodes.Items {
+		node := &nodes.Items[i]
+		selected := selector.Matches(labels.Set(node.Labels))
+		if selected {
+			if err := c.setDesired(ctx, node.Name, setKey(set), refs); err != nil {
+				return err
+			}
+			set.Status.Nodes++
+			if installed(node, set.Status.Artifacts) {
+				set.Status.NodesSynced++
+			}
+		} else if _, ok := nodestatus.Desired(node)[setKey(set)]; ok {
+			if err := c.setDesired(ctx, node.Name, setKey(set), nil); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// finalize removes the artifacts of a deleted FalcoArtifactSet from the nodes, then its finalizer.
+func (c *Controller) finalize(ctx context.Context, set *FalcoArtifactSet) error {
+	if !hasFinalizer(set) {
+		return nil
+	}
+	nodes, err := c.Client.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fmt.Errorf("unable to list nodes: %w", err)
+	}
+	for i := range nodes.Items {
+		if _, ok := nodestatus.Desired(&nodes.Items[i])[setKey(set)]; ok {
+			if err := c.setDesired(ctx, nodes.Items[i].Name, setKey(set), nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	finalizers := set.Finalizers[:0]
+	for _, f := range set.Finalizers {
+		if f != Finalizer {
+			finalizers = append(finalizers, f)
+		}
+	}
+	set.Finalizers = finalizers
+	_, err = c.update(ctx, set)
+	return err
+}
+
+// addFinalizer adds the finalizer to a FalcoArtifactSet, returning the updated resource.
+func (c *Controller) addFinalizer(ctx context.Context, set *FalcoArtifactSet) (*FalcoArtifactSet, error) {
+	set.Finalizers = append(set.Finalizers, Finalizer)
+	return c.update(ctx, set)
+}
+
+func hasFinalizer(set *FalcoArtifactSet) bool {
+	for _, f := range set.Finalizers {
+		if f == Finalizer {
+			return true
+		}
+	}
+	return false
+}
+
+// update updates the metadata and spec of a FalcoArtifactSet.
+func (c *Controller) update(ctx context.Context, set *FalcoArtifactSet) (*FalcoArtifactSet, error) {
+	u, err := toUnstructured(set)
+	if err != nil {
+		return nil, err
+	}
+	u, err = c.Dynamic.Resource(GVR).Namespace(set.Namespace).Update(ctx, u, metav1.UpdateOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to update %s %s/%s: %w", Kind, set.Namespace, set.Name, err)
+	}
+	return fromUnstructured(u)
+}
+
+// updateStatus writes the status of a FalcoArtifactSet.
+func (c *Controller) updateStatus(ctx context.Context, set *FalcoArtifactSet) error {
+	set.Status.ObservedGeneration = set.Generation
+	u, err := toUnstructured(set)
+	if err != nil {
+		return err
+	}
+	if _, err := c.Dynamic.Resource(GVR).Namespace(set.Namespace).UpdateStatus(ctx, u, metav1.UpdateOptions{}); err != nil {
+		return fmt.Errorf("unable to update status of %s %s/%s: %w", Kind, set.Namespace, set.Name, err)
+	}
+	return nil
+}
+
+func setCondition(set *FalcoArtifactSet, condType string, ok bool, reason, message string) {
+	status := metav1.ConditionFalse
+	if ok {
+		status = metav1.ConditionTrue
+	}
+	meta.SetStatusCondition(&set.Status.Conditions, metav1.Condition{
+		Type:               condType,
+		Status:             status,
+		Reason:             reason,
+		Message:            message,
+		ObservedGeneration: set.Generation,
+	})
+}
+
+func reason(ok bool, success, failure string) string {
+	if ok {
+		return success
+	}
+	return failure
+}
+
+// setKey returns the key of a FalcoArtifactSet in the desired artifacts annotation.
+func setKey(set *FalcoArtifactSet) string {
+	return set.Namespace + "/" + set.Name
+}
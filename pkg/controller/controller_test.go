@@ -0,0 +1,452 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/distribution/distribution/v3/configuration"
+	_ "github.com/distribution/distribution/v3/registry/storage/driver/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/nodestatus"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
+	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
+	testutils "github.com/falcosecurity/falcoctl/pkg/test"
+)
+
+const (
+	namespace    = "falco"
+	rulesfiletgz = "../test/data/rules.tar.gz"
+	rulesFile    = "aws_cloudtrail_rules.yaml"
+)
+
+var registryAddr string
+
+func TestMain(m *testing.M) {
+	port, err := testutils.FreePort()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	registryAddr = fmt.Sprintf("localhost:%d", port)
+
+	config := &configuration.Configuration{}
+	config.HTTP.Addr = registryAddr
+	go func() {
+		_ = testutils.StartRegistry(context.Background(), config)
+	}()
+	for i := 0; ; i++ {
+		res, err := http.Get(fmt.Sprintf("http://%s", registryAddr))
+		if err == nil {
+			res.Body.Close()
+			break
+		}
+		if i == 50 {
+			fmt.Fprintln(os.Stderr, "registry not ready:", err)
+			os.Exit(1)
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	pusher := ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+	for _, tag := range []string{"0.1.0", "0.2.0", "1.0.0", "latest"} {
+		ref := fmt.Sprintf("%s/rules:%s", registryAddr, tag)
+		if _, err := pusher.Push(context.Background(), oci.Rulesfile, ref,
+			ocipusher.WithFilepaths([]string{rulesfiletgz}),
+			ocipusher.WithArtifactConfig(oci.ArtifactConfig{Name: "rules", Version: tag})); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	os.Exit(m.Run())
+}
+
+func newSet(name string, spec Spec) *FalcoArtifactSet {
+	return &FalcoArtifactSet{
+		TypeMeta:   metav1.TypeMeta{APIVersion: GVR.GroupVersion().String(), Kind: Kind},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace, Generation: 1},
+		Spec:       spec,
+	}
+}
+
+func newController(t *testing.T, verifier Verifier, objects []runtime.Object, sets ...*FalcoArtifactSet) *Controller {
+	var dynObjects []runtime.Object
+	for _, set := range sets {
+		u, err := toUnstructured(set)
+		require.NoError(t, err)
+		dynObjects = append(dynObjects, u)
+	}
+	dyn := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{GVR: Kind + "List"}, dynObjects...)
+
+	idx := index.New("test")
+	idx.Upsert(&index.Entry{Name: "rules", Type: "rulesfile", Registry: registryAddr, Repository: "rules",
+		Versions: []index.Version{{Version: "0.1.0", Digest: "sha256:0000000000000000000000000000000000000000000000000000000000000000"}}})
+	signed := index.New("signed")
+	signed.Upsert(&index.Entry{Name: "signed-rules", Type: "rulesfile", Registry: registryAddr, Repository: "rules",
+		Signature: &index.Signature{Cosign: &index.CosignSignature{KeyRef: "key.pub"}}})
+	indexes := index.NewMergedIndexes()
+	indexes.Merge(idx, signed)
+
+	c, err := New(nil, &Config{
+		Dynamic:        dyn,
+		Client:         fake.NewSimpleClientset(objects...),
+		Indexes:        indexes,
+		RegistryClient: authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)),
+		PlainHTTP:      true,
+		Verifier:       verifier,
+		TmpDir:         t.TempDir(),
+	})
+	require.NoError(t, err)
+	return c
+}
+
+func getSet(t *testing.T, c *Controller, name string) *FalcoArtifactSet {
+	u, err := c.Dynamic.Resource(GVR).Namespace(namespace).Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	set, err := fromUnstructured(u)
+	require.NoError(t, err)
+	return set
+}
+
+func condition(set *FalcoArtifactSet, condType string) metav1.ConditionStatus {
+	if cond := meta.FindStatusCondition(set.Status.Conditions, condType); cond != nil {
+		return cond.Status
+	}
+	return metav1.ConditionUnknown
+}
+
+func TestReconcileConfigMap(t *testing.T) {
+	ctx := context.Background()
+	c := newController(t, nil, nil, newSet("rules", Spec{
+		Artifacts:   []Artifact{{Name: "rules", Version: ">=0.1.0 <1.0.0"}},
+		Destination: Destination{ConfigMap: &ConfigMapDestination{Name: "falco-rules"}},
+	}))
+
+	require.NoError(t, c.Reconcile(ctx, namespace, "rules"))
+
+	set := getSet(t, c, "rules")
+	require.Len(t, set.Status.Artifacts, 1)
+	st := set.Status.Artifacts[0]
+	assert.Equal(t, registryAddr+"/rules", st.Ref)
+	assert.Equal(t, "0.2.0", st.Tag)
+	assert.Contains(t, st.Digest, "sha256:")
+	assert.Equal(t, VerificationSkipped, st.Verification)
+	assert.Empty(t, st.Error)
+	assert.Equal(t, int64(1), set.Status.ObservedGeneration)
+	assert.Equal(t, metav1.ConditionTrue, condition(set, ConditionResolved))
+	assert.Equal(t, metav1.ConditionTrue, condition(set, ConditionVerified))
+	assert.Equal(t, metav1.ConditionTrue, condition(set, ConditionReady))
+
+	cm, err := c.Client.CoreV1().ConfigMaps(namespace).Get(ctx, "falco-rules", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Contains(t, cm.Data, rulesFile)
+	assert.Equal(t, namespace+"/rules", cm.Annotations[AnnotationArtifactSet])
+	assert.Equal(t, fmt.Sprintf(`[%q]`, st.Ref+"@"+st.Digest), cm.Annotations[AnnotationDelivered])
+	require.Len(t, cm.OwnerReferences, 1)
+	assert.Equal(t, Kind, cm.OwnerReferences[0].Kind)
+
+	// Reconciling again updates the existing ConfigMap.
+	cm.Data = map[string]string{"stale.yaml": ""}
+	_, err = c.Client.CoreV1().ConfigMaps(namespace).Update(ctx, cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, c.Reconcile(ctx, namespace, "rules"))
+	cm, err = c.Client.CoreV1().ConfigMaps(namespace).Get(ctx, "falco-rules", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{rulesFile}, keys(cm.Data))
+	assert.Len(t, cm.OwnerReferences, 1)
+}
+
+func TestReconcileForeignConfigMap(t *testing.T) {
+	ctx := context.Background()
+	foreign := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{Name: "falco-rules", Namespace: namespace},
+		Data:       map[string]string{"custom.yaml": "- rule: custom"},
+		BinaryData: map[string][]byte{"blob": []byte("data")},
+	}
+	c := newController(t, nil, []runtime.Object{foreign}, newSet("rules", Spec{
+		Artifacts:   []Artifact{{Name: "rules", Version: "0.2.0"}},
+		Destination: Destination{ConfigMap: &ConfigMapDestination{Name: "falco-rules"}},
+	}))
+
+	require.NoError(t, c.Reconcile(ctx, namespace, "rules"))
+
+	cond := meta.FindStatusCondition(getSet(t, c, "rules").Status.Conditions, ConditionReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, "ConfigMapConflict", cond.Reason)
+	assert.Contains(t, cond.Message, "not managed by this resource")
+
+	cm, err := c.Client.CoreV1().ConfigMaps(namespace).Get(ctx, "falco-rules", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, foreign.Data, cm.Data)
+	assert.Equal(t, foreign.BinaryData, cm.BinaryData)
+	assert.Empty(t, cm.OwnerReferences)
+
+	// A ConfigMap handed over through the annotation is updated.
+	cm.Annotations = map[string]string{AnnotationArtifactSet: namespace + "/rules"}
+	_, err = c.Client.CoreV1().ConfigMaps(namespace).Update(ctx, cm, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, c.Reconcile(ctx, namespace, "rules"))
+	assert.Equal(t, metav1.ConditionTrue, condition(getSet(t, c, "rules"), ConditionReady))
+	cm, err = c.Client.CoreV1().ConfigMaps(namespace).Get(ctx, "falco-rules", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{rulesFile}, keys(cm.Data))
+}
+
+func TestReconcileFailures(t *testing.T) {
+	ctx := context.Background()
+	verifyErr := errors.New("bad signature")
+	verifier := func(_ context.Context, _ string, sig *index.Signature) error {
+		if sig.Cosign.KeyRef == "bad.pub" {
+			return verifyErr
+		}
+		return nil
+	}
+
+	tests := []struct {
+		name         string
+		spec         Spec
+		resolved     metav1.ConditionStatus
+		verified     metav1.ConditionStatus
+		verification string
+		errContains  string
+	}{
+		{
+			name:        "unknown artifact",
+			spec:        Spec{Artifacts: []Artifact{{Name: "unknown"}}},
+			resolved:    metav1.ConditionFalse,
+			verified:    metav1.ConditionTrue,
+			errContains: "cannot find unknown",
+		},
+		{
+			name:        "no matching tag",
+			spec:        Spec{Artifacts: []Artifact{{Name: "rules", Version: ">=2.0.0"}}},
+			resolved:    metav1.ConditionFalse,
+			verified:    metav1.ConditionTrue,
+			errContains: `matches ">=2.0.0"`,
+		},
+		{
+			name:         "digest not listed by the index",
+			spec:         Spec{Artifacts: []Artifact{{Name: "rules", Version: "0.1.0"}}},
+			resolved:     metav1.ConditionTrue,
+			verified:     metav1.ConditionFalse,
+			verification: VerificationFailed,
+			errContains:  "does not match the digest sha256:0000",
+		},
+		{
+			name: "required signature missing",
+			spec: Spec{Artifacts: []Artifact{{Name: "rules"}},
+				Signature: SignaturePolicy{Mode: SignatureRequired}},
+			resolved:     metav1.ConditionTrue,
+			verified:     metav1.ConditionFalse,
+			verification: VerificationFailed,
+			errContains:  "no signature available",
+		},
+		{
+			name: "signature from the policy not verified",
+			spec: Spec{Artifacts: []Artifact{{Name: registryAddr + "/rules", Version: "1.0.0"}},
+				Signature: SignaturePolicy{Cosign: &CosignSignature{KeyRef: "bad.pub"}}},
+			resolved:     metav1.ConditionTrue,
+			verified:     metav1.ConditionFalse,
+			verification: VerificationFailed,
+			errContains:  "bad signature",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.spec.Destination = Destination{ConfigMap: &ConfigMapDestination{Name: "falco-rules"}}
+			c := newController(t, verifier, nil, newSet("rules", tt.spec))
+
+			require.NoError(t, c.Reconcile(ctx, namespace, "rules"))
+
+			set := getSet(t, c, "rules")
+			require.Len(t, set.Status.Artifacts, 1)
+			assert.Equal(t, tt.verification, set.Status.Artifacts[0].Verification)
+			assert.Contains(t, set.Status.Artifacts[0].Error, tt.errContains)
+			assert.Equal(t, tt.resolved, condition(set, ConditionResolved))
+			assert.Equal(t, tt.verified, condition(set, ConditionVerified))
+			assert.Equal(t, metav1.ConditionFalse, condition(set, ConditionReady))
+
+			// The destination is left untouched.
+			_, err := c.Client.CoreV1().ConfigMaps(namespace).Get(ctx, "falco-rules", metav1.GetOptions{})
+			assert.True(t, apierrors.IsNotFound(err))
+		})
+	}
+}
+
+func TestReconcileSignatureFromIndex(t *testing.T) {
+	var verified []string
+	verifier := func(_ context.Context, ref string, sig *index.Signature) error {
+		assert.Equal(t, "key.pub", sig.Cosign.KeyRef)
+		verified = append(verified, ref)
+		return nil
+	}
+	c := newController(t, verifier, nil, newSet("rules", Spec{
+		Artifacts:   []Artifact{{Name: "signed-rules", Version: "1.0.0"}},
+		Destination: Destination{ConfigMap: &ConfigMapDestination{Name: "falco-rules"}},
+	}))
+
+	require.NoError(t, c.Reconcile(context.Background(), namespace, "rules"))
+
+	set := getSet(t, c, "rules")
+	st := set.Status.Artifacts[0]
+	assert.Equal(t, VerificationPassed, st.Verification)
+	assert.Equal(t, []string{st.Ref + "@" + st.Digest}, verified)
+	assert.Equal(t, metav1.ConditionTrue, condition(set, ConditionReady))
+}
+
+func TestReconcileInvalidSpec(t *testing.T) {
+	c := newController(t, nil, nil, newSet("rules", Spec{Artifacts: []Artifact{{Name: "rules"}}}))
+
+	require.NoError(t, c.Reconcile(context.Background(), namespace, "rules"))
+
+	set := getSet(t, c, "rules")
+	cond := meta.FindStatusCondition(set.Status.Conditions, ConditionReady)
+	require.NotNil(t, cond)
+	assert.Equal(t, metav1.ConditionFalse, cond.Status)
+	assert.Equal(t, "InvalidSpec", cond.Reason)
+	assert.Equal(t, "no destination declared", cond.Message)
+}
+
+func TestReconcileNodes(t *testing.T) {
+	ctx := context.Background()
+	nodes := []runtime.Object{
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-1", Labels: map[string]string{"falco": "true"}}},
+		&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: "node-2"}},
+	}
+	c := newController(t, nil, nodes, newSet("rules", Spec{
+		Artifacts:    []Artifact{{Name: "rules", Version: "0.2.0"}},
+		NodeSelector: map[string]string{"falco": "true"},
+		Destination:  Destination{Nodes: true},
+	}))
+
+	require.NoError(t, c.Reconcile(ctx, namespace, "rules"))
+
+	set := getSet(t, c, "rules")
+	assert.Equal(t, []string{Finalizer}, set.Finalizers)
+	assert.Equal(t, 1, set.Status.Nodes)
+	assert.Equal(t, 0, set.Status.NodesSynced)
+	assert.Equal(t, metav1.ConditionTrue, condition(set, ConditionReady))
+	digestRef := set.Status.Artifacts[0].Ref + "@" + set.Status.Artifacts[0].Digest
+
+	node := getNode(t, c, "node-1")
+	assert.Equal(t, map[string][]string{namespace + "/rules": {digestRef}}, nodestatus.Desired(node))
+	assert.NotContains(t, getNode(t, c, "node-2").Annotations, nodestatus.AnnotationDesired)
+
+	// The node-local falcoctl reports the artifact as installed, with the digest of the manifest of its platform.
+	installedArtifacts, err := json.Marshal(map[string]nodestatus.Artifact{
+		digestRef: {Ref: digestRef, Type: "rulesfile", RootDigest: set.Status.Artifacts[0].Digest,
+			Digest: "sha256:1111111111111111111111111111111111111111111111111111111111111111"},
+	})
+	require.NoError(t, err)
+	node.Annotations[nodestatus.AnnotationArtifacts] = string(installedArtifacts)
+	_, err = c.Client.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, c.Reconcile(ctx, namespace, "rules"))
+	assert.Equal(t, 1, getSet(t, c, "rules").Status.NodesSynced)
+
+	// Nodes no longer selected lose the artifacts.
+	node = getNode(t, c, "node-1")
+	node.Labels = nil
+	_, err = c.Client.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, c.Reconcile(ctx, namespace, "rules"))
+	assert.NotContains(t, getNode(t, c, "node-1").Annotations, nodestatus.AnnotationDesired)
+	assert.Equal(t, 0, getSet(t, c, "rules").Status.Nodes)
+
+	// Deleted resources lose their artifacts and finalizer.
+	node = getNode(t, c, "node-1")
+	node.Labels = map[string]string{"falco": "true"}
+	_, err = c.Client.CoreV1().Nodes().Update(ctx, node, metav1.UpdateOptions{})
+	require.NoError(t, err)
+	require.NoError(t, c.Reconcile(ctx, namespace, "rules"))
+	require.Contains(t, getNode(t, c, "node-1").Annotations, nodestatus.AnnotationDesired)
+
+	set = getSet(t, c, "rules")
+	now := metav1.Now()
+	set.DeletionTimestamp = &now
+	_, err = c.update(ctx, set)
+	require.NoError(t, err)
+	require.NoError(t, c.Reconcile(ctx, namespace, "rules"))
+	assert.NotContains(t, getNode(t, c, "node-1").Annotations, nodestatus.AnnotationDesired)
+	assert.Empty(t, getSet(t, c, "rules").Finalizers)
+}
+
+func TestValidate(t *testing.T) {
+	configMap := Destination{ConfigMap: &ConfigMapDestination{Name: "rules"}}
+	tests := []struct {
+		name string
+		spec Spec
+		err  string
+	}{
+		{"valid", Spec{Artifacts: []Artifact{{Name: "rules"}}, Destination: configMap}, ""},
+		{"no artifacts", Spec{Destination: configMap}, "no artifacts declared"},
+		{"artifact without name", Spec{Artifacts: []Artifact{{}}, Destination: configMap}, "artifact without name"},
+		{"invalid signature mode", Spec{Artifacts: []Artifact{{Name: "rules"}}, Destination: configMap,
+			Signature: SignaturePolicy{Mode: "Sometimes"}}, `invalid signature mode "Sometimes"`},
+		{"two destinations", Spec{Artifacts: []Artifact{{Name: "rules"}},
+			Destination: Destination{ConfigMap: configMap.ConfigMap, Nodes: true}}, "only one destination can be declared"},
+		{"configMap without name", Spec{Artifacts: []Artifact{{Name: "rules"}},
+			Destination: Destination{ConfigMap: &ConfigMapDestination{}}}, "configMap destination without name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.spec.Validate()
+			if tt.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.err)
+			}
+		})
+	}
+}
+
+func getNode(t *testing.T, c *Controller, name string) *corev1.Node {
+	node, err := c.Client.CoreV1().Nodes().Get(context.Background(), name, metav1.GetOptions{})
+	require.NoError(t, err)
+	return node
+}
+
+func keys(m map[string]string) []string {
+	result := make([]string, 0, len(m))
+	for k := range m {
+		result = append(result, k)
+	}
+	return result
+}
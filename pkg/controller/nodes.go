@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/nodestatus"
+)
+
+// setDesired sets the artifacts of a FalcoArtifactSet in the desired artifacts annotation of a node,
+// or removes them if refs is empty.
+func (c *Controller) setDesired(ctx context.Context, nodeName, key string, refs []string) error {
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := c.Client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		entries := nodestatus.Desired(node)
+		if len(refs) == 0 {
+			delete(entries, key)
+		} else {
+			entries[key] = refs
+		}
+		encoded := ""
+		if len(entries) > 0 {
+			encoded = mustJSON(entries)
+		}
+		if node.Annotations[nodestatus.AnnotationDesired] == encoded {
+			return nil
+		}
+		// A null value removes the annotation.
+		var value interface{}
+		if encoded != "" {
+			value = encoded
+		}
+
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"resourceVersion": node.ResourceVersion,
+				"annotations":     map[string]interface{}{nodestatus.AnnotationDesired: value},
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("unable to marshal node patch: %w", err)
+		}
+		_, err = c.Client.CoreV1().Nodes().Patch(ctx, nodeName, types.MergePatchType, patch, metav1.PatchOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to patch node %q: %w", nodeName, err)
+	}
+	return nil
+}
+
+// installed reports whether a node reports all the artifacts as installed, through the
+// artifacts annotation of the node-local falcoctl instances. The artifacts are matched by repository
+// and root digest, the one resolved from their tag.
+func installed(node *corev1.Node, artifacts []ArtifactStatus) bool {
+	var current map[string]nodestatus.Artifact
+	if err := json.Unmarshal([]byte(node.Annotations[nodestatus.AnnotationArtifacts]), &current); err != nil {
+		return false
+	}
+	digests := make(map[string]bool, len(current))
+	for _, a := range current {
+		if repo, err := utils.RepositoryFromRef(a.Ref); err == nil && a.RootDigest != "" {
+			digests[fmt.Sprintf("%s@%s", repo, a.RootDigest)] = true
+		}
+	}
+	for _, a := range artifacts {
+		if !digests[fmt.Sprintf("%s@%s", a.Ref, a.Digest)] {
+			return false
+		}
+	}
+	return true
+}
+
+// mustJSON marshals values that cannot fail to be marshaled.
+func mustJSON(v interface{}) string {
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return string(data)
+}
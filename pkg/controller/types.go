@@ -0,0 +1,220 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package controller
+
+import (
+	_ "embed"
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+)
+
+const (
+	// Group is the API group of the FalcoArtifactSet resource.
+	Group = "falco.org"
+	// Version is the API version of the FalcoArtifactSet resource.
+	Version = "v1alpha1"
+	// Kind is the kind of the FalcoArtifactSet resource.
+	Kind = "FalcoArtifactSet"
+	// Resource is the plural name of the FalcoArtifactSet resource.
+	Resource = "falcoartifactsets"
+)
+
+// GVR identifies the FalcoArtifactSet resource.
+var GVR = schema.GroupVersionResource{Group: Group, Version: Version, Resource: Resource}
+
+// Signature policies.
+const (
+	// SignatureIfAvailable verifies the artifacts having a signature, either in the policy or in their index entry.
+	SignatureIfAvailable = "IfAvailable"
+	// SignatureRequired verifies all the artifacts, and fails the ones without a signature.
+	SignatureRequired = "Required"
+	// SignatureDisabled skips the verification.
+	SignatureDisabled = "Disabled"
+)
+
+// Condition types reported on the resource.
+const (
+	// ConditionResolved reports whether all the artifacts were resolved to a digest.
+	ConditionResolved = "Resolved"
+	// ConditionVerified reports whether all the artifacts passed the signature policy.
+	ConditionVerified = "Verified"
+	// ConditionReady reports whether all the artifacts were delivered to the destination.
+	ConditionReady = "Ready"
+)
+
+// Verification results of an artifact.
+const (
+	VerificationPassed  = "Passed"
+	VerificationFailed  = "Failed"
+	VerificationSkipped = "Skipped"
+)
+
+// FalcoArtifactSet declares a set of Falco artifacts to be deployed in the cluster.
+type FalcoArtifactSet struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   Spec   `json:"spec"`
+	Status Status `json:"status,omitempty"`
+}
+
+// Spec is the desired state of a FalcoArtifactSet.
+type Spec struct {
+	// Artifacts to be deployed.
+	Artifacts []Artifact `json:"artifacts"`
+	// Signature is the signature policy applied to the artifacts.
+	Signature SignaturePolicy `json:"signature,omitempty"`
+	// NodeSelector selects the nodes the artifacts are delivered to, with the nodes destination.
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+	// Destination is where the artifacts are delivered.
+	Destination Destination `json:"destination"`
+}
+
+// Artifact is an artifact of a FalcoArtifactSet.
+type Artifact struct {
+	// Name is the name of the artifact in the configured indexes, or its reference without tag.
+	Name string `json:"name"`
+	// Version is a tag, or a semver range resolved to the greatest matching tag, e.g. ">=0.7.0 <0.8.0".
+	// It defaults to the latest tag.
+	Version string `json:"version,omitempty"`
+}
+
+// SignaturePolicy is the signature policy of a FalcoArtifactSet.
+type SignaturePolicy struct {
+	// Mode is one of IfAvailable (default), Required and Disabled.
+	Mode string `json:"mode,omitempty"`
+	// Cosign overrides the signatures found in the index entries of the artifacts.
+	Cosign *CosignSignature `json:"cosign,omitempty"`
+}
+
+// CosignSignature contains the cosign verification parameters, equivalent to index.CosignSignature.
+type CosignSignature struct {
+	CertificateOidcIssuer       string `json:"certificateOidcIssuer,omitempty"`
+	CertificateOidcIssuerRegexp string `json:"certificateOidcIssuerRegexp,omitempty"`
+	CertificateIdentity         string `json:"certificateIdentity,omitempty"`
+	CertificateIdentityRegexp   string `json:"certificateIdentityRegexp,omitempty"`
+	CertificateGithubWorkflow   string `json:"certificateGithubWorkflow,omitempty"`
+	KeyRef                      string `json:"key,omitempty"`
+	IgnoreTlog                  bool   `json:"ignoreTlog,omitempty"`
+}
+
+// Destination is where the artifacts of a FalcoArtifactSet are delivered. Exactly one field must be set.
+type Destination struct {
+	// ConfigMap receives the files of the rulesfile artifacts.
+	ConfigMap *ConfigMapDestination `json:"configMap,omitempty"`
+	// Nodes delivers the resolved references to the selected nodes, through the nodestatus.AnnotationDesired annotation.
+	Nodes bool `json:"nodes,omitempty"`
+}
+
+// ConfigMapDestination is a ConfigMap receiving the files of the rulesfile artifacts.
+type ConfigMapDestination struct {
+	Name string `json:"name"`
+	// Namespace defaults to the namespace of the FalcoArtifactSet.
+	Namespace string `json:"namespace,omitempty"`
+}
+
+// Status is the observed state of a FalcoArtifactSet.
+type Status struct {
+	ObservedGeneration int64              `json:"observedGeneration,omitempty"`
+	Artifacts          []ArtifactStatus   `json:"artifacts,omitempty"`
+	Conditions         []metav1.Condition `json:"conditions,omitempty"`
+	// Nodes is the number of selected nodes, with the nodes destination.
+	Nodes int `json:"nodes,omitempty"`
+	// NodesSynced is the number of selected nodes reporting the resolved digests as installed.
+	NodesSynced int `json:"nodesSynced,omitempty"`
+}
+
+// ArtifactStatus is the observed state of an artifact of a FalcoArtifactSet.
+type ArtifactStatus struct {
+	Name         string `json:"name"`
+	Ref          string `json:"ref,omitempty"`
+	Tag          string `json:"tag,omitempty"`
+	Digest       string `json:"digest,omitempty"`
+	Verification string `json:"verification,omitempty"`
+	Error        string `json:"error,omitempty"`
+}
+
+// Validate checks the spec of the FalcoArtifactSet.
+func (s *Spec) Validate() error {
+	if len(s.Artifacts) == 0 {
+		return fmt.Errorf("no artifacts declared")
+	}
+	for _, a := range s.Artifacts {
+		if a.Name == "" {
+			return fmt.Errorf("artifact without name")
+		}
+	}
+	switch s.Signature.Mode {
+	case "", SignatureIfAvailable, SignatureRequired, SignatureDisabled:
+	default:
+		return fmt.Errorf("invalid signature mode %q, allowed values: %s, %s, %s",
+			s.Signature.Mode, SignatureIfAvailable, SignatureRequired, SignatureDisabled)
+	}
+	switch {
+	case s.Destination.ConfigMap == nil && !s.Destination.Nodes:
+		return fmt.Errorf("no destination declared")
+	case s.Destination.ConfigMap != nil && s.Destination.Nodes:
+		return fmt.Errorf("only one destination can be declared")
+	case s.Destination.ConfigMap != nil && s.Destination.ConfigMap.Name == "":
+		return fmt.Errorf("configMap destination without name")
+	}
+	return nil
+}
+
+// toIndexSignature converts the cosign parameters to an index signature.
+func (c *CosignSignature) toIndexSignature() *index.Signature {
+	if c == nil {
+		return nil
+	}
+	return &index.Signature{Cosign: &index.CosignSignature{
+		CertificateOidcIssuer:       c.CertificateOidcIssuer,
+		CertificateOidcIssuerRegexp: c.CertificateOidcIssuerRegexp,
+		CertificateIdentity:         c.CertificateIdentity,
+		CertificateIdentityRegexp:   c.CertificateIdentityRegexp,
+		CertificateGithubWorkflow:   c.CertificateGithubWorkflow,
+		KeyRef:                      c.KeyRef,
+		IgnoreTlog:                  c.IgnoreTlog,
+	}}
+}
+
+// fromUnstructured converts an unstructured object to a FalcoArtifactSet.
+func fromUnstructured(u *unstructured.Unstructured) (*FalcoArtifactSet, error) {
+	var set FalcoArtifactSet
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(u.Object, &set); err != nil {
+		return nil, fmt.Errorf("unable to decode %s %s/%s: %w", Kind, u.GetNamespace(), u.GetName(), err)
+	}
+	return &set, nil
+}
+
+// toUnstructured converts a FalcoArtifactSet to an unstructured object.
+func toUnstructured(set *FalcoArtifactSet) (*unstructured.Unstructured, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(set)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode %s %s/%s: %w", Kind, set.Namespace, set.Name, err)
+	}
+	return &unstructured.Unstructured{Object: obj}, nil
+}
+
+// CRD is the CustomResourceDefinition of the FalcoArtifactSet resource.
+//
+//go:embed crd.yaml
+var CRD []byte
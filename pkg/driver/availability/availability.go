@@ -0,0 +1,256 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package availability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/blang/semver"
+	"github.com/falcosecurity/driverkit/pkg/kernelrelease"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+
+	driverdistro "github.com/falcosecurity/falcoctl/pkg/driver/distro"
+	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+)
+
+// defaultKernelVersion is used in place of the kernel version ('uname -v'), not exposed by the node info.
+// It is the build number found in the kernel version of most distros; distros whose driver names
+// depend on the actual kernel version are reported as unknown.
+const defaultKernelVersion = "1"
+
+// modernBpfMinKernel is the kernel release from which the modern eBPF probe is assumed to be supported.
+var modernBpfMinKernel = semver.Version{Major: 5, Minor: 8}
+
+// NoPool is the pool reported for nodes without any of the pool labels.
+const NoPool = "<none>"
+
+// DefaultPoolLabels are the well-known node labels holding the name of the node pool.
+var DefaultPoolLabels = []string{
+	"cloud.google.com/gke-nodepool",
+	"eks.amazonaws.com/nodegroup",
+	"kubernetes.azure.com/agentpool",
+	"node.kubernetes.io/instance-type",
+}
+
+// Options defines the drivers to be checked.
+type Options struct {
+	DriverName    string
+	DriverVersion string
+	Repos         []string
+	// Types are the allowed driver types; the modern eBPF probe, which has no artifacts, is assumed to be
+	// supported by the kernel releases from 5.8 on.
+	Types []drivertype.DriverType
+	// PoolLabels are the node labels looked up, in order, for the node pool name.
+	PoolLabels []string
+	// Client is the http client used to check the repos; http.DefaultClient if nil.
+	Client *http.Client
+}
+
+// Driver is the availability of a driver type for a node.
+type Driver struct {
+	Type      string `json:"type"`
+	Filename  string `json:"filename,omitempty"`
+	Supported bool   `json:"supported"`
+	URL       string `json:"url,omitempty"`
+	Available bool   `json:"available"`
+	// Unknown is true when the driver name cannot be determined from the node info.
+	Unknown bool `json:"unknown,omitempty"`
+}
+
+// Node is the availability report of a node.
+type Node struct {
+	Name          string   `json:"name"`
+	Pool          string   `json:"pool"`
+	Distro        string   `json:"distro"`
+	KernelRelease string   `json:"kernelRelease"`
+	Arch          string   `json:"arch"`
+	Drivers       []Driver `json:"drivers"`
+	// NeedsCompile is true when no prebuilt driver is available for the node.
+	NeedsCompile bool `json:"needsCompile"`
+	// Unknown is true when the availability cannot be determined from the node info, because the driver
+	// names depend on the kernel version of the node and the modern eBPF probe cannot be used.
+	Unknown bool `json:"unknown"`
+}
+
+// Pool is the coverage of a node pool, ie: how many nodes have a prebuilt driver available.
+// Nodes whose availability is unknown are not part of the coverage.
+type Pool struct {
+	Name    string `json:"name"`
+	Nodes   int    `json:"nodes"`
+	Covered int    `json:"covered"`
+	Unknown int    `json:"unknown"`
+}
+
+// Report is the availability report of a cluster.
+type Report struct {
+	Nodes []Node `json:"nodes"`
+	Pools []Pool `json:"pools"`
+}
+
+// Check lists the cluster nodes and checks, for each of them, which prebuilt drivers are available in the repos.
+func Check(ctx context.Context, cl kubernetes.Interface, opts Options) (*Report, error) {
+	nodes, err := cl.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list nodes: %w", err)
+	}
+
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	poolLabels := opts.PoolLabels
+	if len(poolLabels) == 0 {
+		poolLabels = DefaultPoolLabels
+	}
+
+	report := &Report{}
+	pools := make(map[string]*Pool)
+	// Cache the results of the requests, since nodes of the same pool usually share the same drivers.
+	availability := make(map[string]bool)
+	for i := range nodes.Items {
+		node, err := checkNode(ctx, client, &nodes.Items[i], poolLabels, opts, availability)
+		if err != nil {
+			return nil, err
+		}
+		report.Nodes = append(report.Nodes, *node)
+
+		pool, ok := pools[node.Pool]
+		if !ok {
+			pool = &Pool{Name: node.Pool}
+			pools[node.Pool] = pool
+		}
+		switch {
+		case node.Unknown:
+			pool.Unknown++
+		case node.NeedsCompile:
+			pool.Nodes++
+		default:
+			pool.Nodes++
+			pool.Covered++
+		}
+	}
+
+	for _, pool := range pools {
+		report.Pools = append(report.Pools, *pool)
+	}
+	sort.Slice(report.Pools, func(i, j int) bool {
+		return report.Pools[i].Name < report.Pools[j].Name
+	})
+	return report, nil
+}
+
+func checkNode(ctx context.Context, client *http.Client, n *corev1.Node, poolLabels []string,
+	opts Options, availability map[string]bool) (*Node, error) {
+	info := n.Status.NodeInfo
+	kr := kernelrelease.FromString(info.KernelVersion)
+	kr.KernelVersion = defaultKernelVersion
+	kr.Architecture = kernelrelease.Architecture(info.Architecture)
+
+	node := &Node{
+		Name:          n.Name,
+		Pool:          NoPool,
+		KernelRelease: info.KernelVersion,
+		Arch:          kr.Architecture.ToNonDeb(),
+		NeedsCompile:  true,
+	}
+	for _, label := range poolLabels {
+		if pool, ok := n.Labels[label]; ok && pool != "" {
+			node.Pool = pool
+			break
+		}
+	}
+
+	distro, err := driverdistro.FromOSImage(info.OSImage, kr)
+	if err != nil && !errors.Is(err, driverdistro.ErrUnsupported) {
+		return nil, fmt.Errorf("unable to determine distro of node %q: %w", n.Name, err)
+	}
+	node.Distro = distro.String()
+
+	kernelVersionDependent := driverdistro.KernelVersionDependent(distro)
+	for _, driverType := range opts.Types {
+		switch {
+		case driverType.String() == drivertype.TypeModernBpf:
+			// The modern eBPF probe is embedded in Falco and needs no artifact: its support can only be probed
+			// on the node itself, so it is assumed from the kernel release.
+			supported := kr.GTE(modernBpfMinKernel)
+			node.Drivers = append(node.Drivers, Driver{Type: driverType.String(), Supported: supported, Available: supported})
+			if supported {
+				node.NeedsCompile = false
+			}
+		case !driverType.HasArtifacts():
+			continue
+		case kernelVersionDependent:
+			node.Drivers = append(node.Drivers, Driver{
+				Type:      driverType.String(),
+				Supported: driverType.Supported(kr),
+				Unknown:   true,
+			})
+		default:
+			driver, err := checkDriver(ctx, client, distro, kr, driverType, opts, availability)
+			if err != nil {
+				return nil, err
+			}
+			if driver.Available {
+				node.NeedsCompile = false
+			}
+			node.Drivers = append(node.Drivers, *driver)
+		}
+	}
+	// Without the modern eBPF probe, whether the node needs to compile the driver cannot be determined.
+	if kernelVersionDependent && node.NeedsCompile {
+		node.NeedsCompile = false
+		node.Unknown = true
+	}
+	return node, nil
+}
+
+// checkDriver looks up the prebuilt driver of the given type for the node in the repos.
+//
+//nolint:gocritic // the function shall not be able to modify kr
+func checkDriver(ctx context.Context, client *http.Client, distro driverdistro.Distro, kr kernelrelease.KernelRelease,
+	driverType drivertype.DriverType, opts Options, availability map[string]bool) (*Driver, error) {
+	driver := &Driver{
+		Type:      driverType.String(),
+		Filename:  driverdistro.Filename(distro, kr, opts.DriverName, driverType),
+		Supported: driverType.Supported(kr),
+	}
+	if !driver.Supported {
+		return driver, nil
+	}
+	for _, repo := range opts.Repos {
+		url := driverdistro.URL(repo, opts.DriverVersion, driver.Filename, kr)
+		available, ok := availability[url]
+		if !ok {
+			var err error
+			if available, err = driverdistro.Exists(ctx, client, url); err != nil {
+				return nil, err
+			}
+			availability[url] = available
+		}
+		if available {
+			driver.URL = url
+			driver.Available = true
+			break
+		}
+	}
+	return driver, nil
+}
@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package availability
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/fake"
+
+	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+)
+
+func newNode(name, pool, osImage, kernelVersion, arch string) *corev1.Node {
+	return &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   name,
+			Labels: map[string]string{"eks.amazonaws.com/nodegroup": pool},
+		},
+		Status: corev1.NodeStatus{
+			NodeInfo: corev1.NodeSystemInfo{
+				OSImage:       osImage,
+				KernelVersion: kernelVersion,
+				Architecture:  arch,
+			},
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	const available = "/7.0.0+driver/x86_64/falco_amazonlinux2_5.10.205-195.804.amzn2.x86_64_1.ko"
+	var requests int
+	repo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		assert.Equal(t, http.MethodHead, r.Method)
+		if r.URL.Path == available {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer repo.Close()
+
+	cl := fake.NewSimpleClientset(
+		newNode("node-1", "amzn", "Amazon Linux 2", "5.10.205-195.804.amzn2.x86_64", "amd64"),
+		newNode("node-2", "amzn", "Amazon Linux 2", "5.10.205-195.804.amzn2.x86_64", "amd64"),
+		newNode("node-3", "bottlerocket", "Bottlerocket OS 1.19.0 (aws-k8s-1.28)", "6.1.66", "amd64"),
+		newNode("node-4", "unknown", "Some OS", "6.1.66", "arm64"),
+		newNode("node-5", "amzn", "Ubuntu 22.04.3 LTS", "5.15.0-91-generic", "amd64"),
+	)
+
+	kmod, err := drivertype.Parse(drivertype.TypeKmod)
+	require.NoError(t, err)
+
+	report, err := Check(context.Background(), cl, Options{
+		DriverName:    "falco",
+		DriverVersion: "7.0.0+driver",
+		Repos:         []string{repo.URL},
+		Types:         []drivertype.DriverType{kmod},
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Nodes, 5)
+
+	node := report.Nodes[0]
+	assert.Equal(t, "amazonlinux2", node.Distro)
+	assert.Equal(t, "x86_64", node.Arch)
+	assert.False(t, node.NeedsCompile)
+	require.Len(t, node.Drivers, 1)
+	assert.Equal(t, "kmod", node.Drivers[0].Type)
+	assert.Equal(t, repo.URL+"/7.0.0%2Bdriver/x86_64/falco_amazonlinux2_5.10.205-195.804.amzn2.x86_64_1.ko", node.Drivers[0].URL)
+
+	node = report.Nodes[2]
+	assert.Equal(t, "bottlerocket", node.Distro)
+	assert.Equal(t, "falco_bottlerocket_6.1.66_1_1.19.0-aws.ko", node.Drivers[0].Filename)
+	assert.True(t, node.NeedsCompile)
+
+	node = report.Nodes[3]
+	assert.Equal(t, "undetermined", node.Distro)
+	assert.Equal(t, "aarch64", node.Arch)
+	assert.True(t, node.NeedsCompile)
+
+	// The driver names of Ubuntu depend on the kernel version, not exposed by the node info.
+	node = report.Nodes[4]
+	assert.Equal(t, "ubuntu-generic", node.Distro)
+	assert.True(t, node.Unknown)
+	assert.False(t, node.NeedsCompile)
+	require.Len(t, node.Drivers, 1)
+	assert.True(t, node.Drivers[0].Unknown)
+	assert.False(t, node.Drivers[0].Available)
+	assert.Empty(t, node.Drivers[0].Filename)
+
+	assert.Equal(t, []Pool{
+		{Name: "amzn", Nodes: 2, Covered: 2, Unknown: 1},
+		{Name: "bottlerocket", Nodes: 1, Covered: 0},
+		{Name: "unknown", Nodes: 1, Covered: 0},
+	}, report.Pools)
+	// Nodes sharing the same driver are checked once.
+	assert.Equal(t, 3, requests)
+}
+
+func TestCheckModernBpf(t *testing.T) {
+	repo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer repo.Close()
+
+	cl := fake.NewSimpleClientset(
+		newNode("node-1", "workers", "Bottlerocket OS 1.19.0 (aws-k8s-1.28)", "6.1.66", "amd64"),
+		newNode("node-2", "workers", "Ubuntu 22.04.3 LTS", "5.15.0-91-generic", "amd64"),
+		newNode("node-3", "legacy", "Amazon Linux 2", "4.14.336-257.562.amzn2.x86_64", "amd64"),
+		newNode("node-4", "legacy", "Ubuntu 20.04.6 LTS", "5.4.0-170-generic", "amd64"),
+	)
+
+	kmod, err := drivertype.Parse(drivertype.TypeKmod)
+	require.NoError(t, err)
+	modernBpf, err := drivertype.Parse(drivertype.TypeModernBpf)
+	require.NoError(t, err)
+
+	report, err := Check(context.Background(), cl, Options{
+		DriverName:    "falco",
+		DriverVersion: "7.0.0+driver",
+		Repos:         []string{repo.URL},
+		Types:         []drivertype.DriverType{modernBpf, kmod},
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Nodes, 4)
+
+	// The modern eBPF probe is assumed to be supported from kernel 5.8 on, whatever the distro.
+	for _, node := range report.Nodes[:2] {
+		assert.False(t, node.NeedsCompile, node.Name)
+		assert.False(t, node.Unknown, node.Name)
+		require.Len(t, node.Drivers, 2, node.Name)
+		assert.Equal(t, Driver{Type: "modern_ebpf", Supported: true, Available: true}, node.Drivers[0])
+		assert.False(t, node.Drivers[1].Available)
+	}
+
+	node := report.Nodes[2]
+	assert.True(t, node.NeedsCompile)
+	assert.False(t, node.Drivers[0].Supported)
+
+	// Without the modern eBPF probe, the Ubuntu driver names depend on the kernel version.
+	node = report.Nodes[3]
+	assert.True(t, node.Unknown)
+	assert.False(t, node.NeedsCompile)
+	assert.True(t, node.Drivers[1].Unknown)
+
+	assert.Equal(t, []Pool{
+		{Name: "legacy", Nodes: 1, Covered: 0, Unknown: 1},
+		{Name: "workers", Nodes: 2, Covered: 2},
+	}, report.Pools)
+}
+
+func TestCheckUnreachableRepo(t *testing.T) {
+	repo := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	repoURL := repo.URL
+	repo.Close()
+
+	kmod, err := drivertype.Parse(drivertype.TypeKmod)
+	require.NoError(t, err)
+
+	_, err = Check(context.Background(), fake.NewSimpleClientset(
+		newNode("node-1", "amzn", "Amazon Linux 2", "5.10.205-195.804.amzn2.x86_64", "amd64"),
+	), Options{
+		DriverName:    "falco",
+		DriverVersion: "7.0.0+driver",
+		Repos:         []string{repoURL},
+		Types:         []drivertype.DriverType{kmod},
+	})
+	assert.ErrorIs(t, err, errdefs.ErrNetwork)
+}
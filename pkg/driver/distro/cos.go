@@ -67,6 +67,9 @@ func (c *cos) customizeBuild(ctx context.Context,
 		// nothing to do
 		return nil, nil
 	}
+	if c.buildID == "" {
+		return nil, fmt.Errorf("unable to download COS kernel headers: unknown build ID")
+	}
 	printer.Logger.Info("COS detected, using COS kernel headers.", printer.Logger.Args("build ID", c.buildID))
 	bpfKernelSrcURL := fmt.Sprintf("https://storage.googleapis.com/cos-tools/%s/kernel-headers.tgz", c.buildID)
 
@@ -32,12 +32,16 @@ import (
 	"github.com/falcosecurity/driverkit/cmd"
 	"github.com/falcosecurity/driverkit/pkg/driverbuilder"
 	"github.com/falcosecurity/driverkit/pkg/kernelrelease"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/net/context"
 	"gopkg.in/ini.v1"
 
 	"github.com/falcosecurity/falcoctl/internal/utils"
 	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
 	"github.com/falcosecurity/falcoctl/pkg/output"
+	"github.com/falcosecurity/falcoctl/pkg/tracing"
 )
 
 const (
@@ -50,7 +54,7 @@ var (
 	distros  = map[string]Distro{}
 	hostRoot = string(os.PathSeparator)
 	// ErrUnsupported is the error returned when the target distro is not supported.
-	ErrUnsupported = errors.New("failed to determine distro")
+	ErrUnsupported = errdefs.Wrap(errdefs.ErrDriverUnsupported, errors.New("failed to determine distro"))
 	// ErrAlreadyPresent is the error returned when a driver is already present on filesystem.
 	ErrAlreadyPresent = errors.New("driver already present")
 )
@@ -143,6 +147,15 @@ func toFilename(d Distro, kr *kernelrelease.KernelRelease, driverName string, dr
 	return fmt.Sprintf("%s_%s_%s_%s%s", driverName, d, fixedKR.String(), fixedKR.KernelVersion, driverType.Extension())
 }
 
+// LocalPath returns the path where the driver for the specified distro and kernel release
+// is stored once downloaded or built.
+//
+//nolint:gocritic // the method shall not be able to modify kr
+func LocalPath(d Distro, kr kernelrelease.KernelRelease, driverName string, driverType drivertype.DriverType, driverVer string) string {
+	driverFileName := toFilename(d, &kr, driverName, driverType)
+	return toLocalPath(driverVer, driverFileName, kr.Architecture.ToNonDeb())
+}
+
 // copyDataToLocalPath will copy a src Reader to a destination file, creating it and its paths if needed.
 // Moreover, it will also take care of closing the reader.
 func copyDataToLocalPath(destination string, src io.ReadCloser) error {
@@ -170,10 +183,12 @@ func Build(ctx context.Context,
 	driverType drivertype.DriverType,
 	driverVer string,
 	downloadHeaders bool,
-) (string, error) {
+) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "distro.Build", driverAttributes(d, &kr, driverName, driverType, driverVer)...)
+	defer func() { tracing.End(span, err) }()
+
 	printer.Logger.Info("Trying to compile the requested driver")
-	driverFileName := toFilename(d, &kr, driverName, driverType)
-	destPath := toLocalPath(driverVer, driverFileName, kr.Architecture.ToNonDeb())
+	destPath := LocalPath(d, kr, driverName, driverType, driverVer)
 	if exist, _ := utils.FileExists(destPath); exist {
 		return destPath, ErrAlreadyPresent
 	}
@@ -244,16 +259,23 @@ func Download(ctx context.Context,
 	driverType drivertype.DriverType,
 	driverVer string, repos []string,
 	httpHeaders string,
-) (string, error) {
+) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "distro.Download", driverAttributes(d, &kr, driverName, driverType, driverVer)...)
+	defer func() { tracing.End(span, err) }()
+
 	driverFileName := toFilename(d, &kr, driverName, driverType)
 	// Skip if existent
 	destination := toLocalPath(driverVer, driverFileName, kr.Architecture.ToNonDeb())
 	if exist, _ := utils.FileExists(destination); exist {
 		return destination, ErrAlreadyPresent
 	}
+	if offline.Enabled() {
+		return destination, offline.Errorf("prebuilt driver %q", driverFileName)
+	}
 
 	// Try to download from any specified repository,
 	// stopping at first successful http GET.
+	client := httpClient()
 	for _, repo := range repos {
 		driverURL := toURL(repo, driverVer, driverFileName, kr.Architecture.ToNonDeb())
 		printer.Logger.Info("Trying to download a driver.", printer.Logger.Args("url", driverURL))
@@ -274,7 +296,7 @@ func Download(ctx context.Context,
 			}
 			req.Header = header
 		}
-		resp, err := http.DefaultClient.Do(req)
+		resp, err := client.Do(req)
 		if err != nil || resp.StatusCode != 200 {
 			if err == nil {
 				_ = resp.Body.Close()
@@ -286,7 +308,31 @@ func Download(ctx context.Context,
 		}
 		return destination, copyDataToLocalPath(destination, resp.Body)
 	}
-	return destination, fmt.Errorf("unable to find a prebuilt driver")
+	return destination, errdefs.Errorf(errdefs.ErrNotFound, "unable to find a prebuilt driver")
+}
+
+// httpClient returns a copy of http.DefaultClient, keeping the options set by the driver commands such as
+// the timeout, whose requests are traced.
+func httpClient() *http.Client {
+	client := *http.DefaultClient
+	transport := client.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	client.Transport = tracing.Transport(transport)
+	return &client
+}
+
+// driverAttributes returns the span attributes describing a driver.
+func driverAttributes(d Distro, kr *kernelrelease.KernelRelease, driverName string, driverType drivertype.DriverType,
+	driverVer string) []attribute.KeyValue {
+	return []attribute.KeyValue{
+		attribute.String("driver.name", driverName),
+		attribute.String("driver.type", driverType.String()),
+		attribute.String("driver.version", driverVer),
+		attribute.String("kernel.release", kr.String()),
+		attribute.String("distro", d.String()),
+	}
 }
 
 func customizeDownloadKernelSrcBuild(printer *output.Printer, kr *kernelrelease.KernelRelease) error {
@@ -336,6 +382,9 @@ func downloadKernelSrc(ctx context.Context,
 	stripComponents int,
 ) (map[string]string, error) {
 	env := make(map[string]string)
+	if offline.Enabled() {
+		return env, offline.Errorf("kernel sources from %q", url)
+	}
 
 	printer.Logger.Info("Downloading kernel sources.", printer.Logger.Args("url", url))
 	err := os.MkdirAll("/tmp/kernel", 0o750)
@@ -352,7 +401,7 @@ func downloadKernelSrc(ctx context.Context,
 	if err != nil {
 		return env, err
 	}
-	resp, err := http.DefaultClient.Do(req)
+	resp, err := httpClient().Do(req)
 	if err != nil {
 		return env, err
 	}
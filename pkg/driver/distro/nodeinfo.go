@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driverdistro
+
+import (
+	"regexp"
+
+	"github.com/falcosecurity/driverkit/pkg/kernelrelease"
+	"gopkg.in/ini.v1"
+
+	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+)
+
+// osImageMatcher maps an OS image, as reported by a Kubernetes node, to the os-release content
+// it would expose on the node itself.
+type osImageMatcher struct {
+	re *regexp.Regexp
+	id string
+	// keys are the os-release keys filled with the regexp submatches, in order.
+	keys []string
+	// unset are the os-release keys needed by the distro that the OS image does not report; they are left empty.
+	unset []string
+	// new returns a new, uninitialized, distro.
+	new func() Distro
+}
+
+var osImageMatchers = []osImageMatcher{
+	// Amazon Linux 2, Amazon Linux 2023.5.20240624
+	{
+		re: regexp.MustCompile(`^Amazon Linux (\d+)`), id: "amzn", keys: []string{"VERSION_ID"},
+		new: func() Distro { return &amzn{generic: &generic{}} },
+	},
+	// Bottlerocket OS 1.19.0 (aws-k8s-1.28)
+	{
+		re: regexp.MustCompile(`^Bottlerocket OS (\S+) \(([^)]+)\)`), id: "bottlerocket", keys: []string{"VERSION_ID", "VARIANT_ID"},
+		new: func() Distro { return &bottlerocket{generic: &generic{}} },
+	},
+	// CentOS Linux 7 (Core)
+	{
+		re: regexp.MustCompile(`^CentOS`), id: "centos",
+		new: func() Distro { return &centos{generic: &generic{}} },
+	},
+	// Container-Optimized OS from Google (the build ID is not reported, see KernelVersionDependent)
+	{
+		re: regexp.MustCompile(`^Container-Optimized OS`), id: "cos", unset: []string{"BUILD_ID"},
+		new: func() Distro { return &cos{generic: &generic{}} },
+	},
+	// Debian GNU/Linux 12 (bookworm)
+	{
+		re: regexp.MustCompile(`^Debian`), id: "debian",
+		new: func() Distro { return &debian{generic: &generic{}} },
+	},
+	// Flatcar Container Linux by Kinvolk 3510.2.1 (Oklo)
+	{
+		re: regexp.MustCompile(`^Flatcar Container Linux by Kinvolk (\d+\.\d+\.\d+)`), id: "flatcar", keys: []string{"VERSION_ID"},
+		new: func() Distro { return &flatcar{generic: &generic{}} },
+	},
+	// Oracle Linux Server 8.8
+	{
+		re: regexp.MustCompile(`^Oracle Linux`), id: "ol",
+		new: func() Distro { return &ol{generic: &generic{}} },
+	},
+	// Red Hat Enterprise Linux 8.8 (Ootpa)
+	{
+		re: regexp.MustCompile(`^Red Hat Enterprise Linux`), id: "rhel",
+		new: func() Distro { return &rhel{generic: &generic{}} },
+	},
+	// Talos (v1.5.0)
+	{
+		re: regexp.MustCompile(`^Talos \(v?([^)]+)\)`), id: "talos", keys: []string{"VERSION_ID"},
+		new: func() Distro { return &talos{generic: &generic{}} },
+	},
+	// Ubuntu 22.04.3 LTS
+	{
+		re: regexp.MustCompile(`^Ubuntu`), id: "ubuntu",
+		new: func() Distro { return &ubuntu{generic: &generic{}} },
+	},
+}
+
+// FromOSImage returns the Distro matching the OS image reported by a Kubernetes node
+// (ie: the OSImage field of the node info), without looking at the local filesystem.
+// When the OS image is not recognized, a generic distro is returned together with ErrUnsupported.
+//
+//nolint:gocritic // the method shall not be able to modify kr
+func FromOSImage(osImage string, kr kernelrelease.KernelRelease) (Distro, error) {
+	for _, m := range osImageMatchers {
+		matches := m.re.FindStringSubmatch(osImage)
+		if matches == nil {
+			continue
+		}
+		cfg := ini.Empty()
+		section := cfg.Section("")
+		if _, err := section.NewKey("ID", m.id); err != nil {
+			return nil, err
+		}
+		for i, key := range m.keys {
+			value := ""
+			if i+1 < len(matches) {
+				value = matches[i+1]
+			}
+			if _, err := section.NewKey(key, value); err != nil {
+				return nil, err
+			}
+		}
+		for _, key := range m.unset {
+			if _, err := section.NewKey(key, ""); err != nil {
+				return nil, err
+			}
+		}
+		distro := m.new()
+		if err := distro.init(kr, m.id, cfg); err != nil {
+			return nil, err
+		}
+		return distro, nil
+	}
+
+	distro := &generic{}
+	if err := distro.init(kr, UndeterminedDistro, nil); err != nil {
+		return nil, err
+	}
+	return distro, ErrUnsupported
+}
+
+// Filename returns the name of the prebuilt driver for the specified distro and kernel release.
+//
+//nolint:gocritic // the method shall not be able to modify kr
+func Filename(d Distro, kr kernelrelease.KernelRelease, driverName string, driverType drivertype.DriverType) string {
+	return toFilename(d, &kr, driverName, driverType)
+}
+
+// URL returns the url of the prebuilt driver with the given file name in the specified repo.
+//
+//nolint:gocritic // the method shall not be able to modify kr
+func URL(repo, driverVer, fileName string, kr kernelrelease.KernelRelease) string {
+	return toURL(repo, driverVer, fileName, kr.Architecture.ToNonDeb())
+}
+
+// KernelVersionDependent returns whether the name of the prebuilt drivers for the distro depends on the
+// kernel version ('uname -v') or, for COS, on the build ID, which are not reported by Kubernetes nodes:
+// for these distros, the driver name cannot be determined from the node info alone.
+func KernelVersionDependent(d Distro) bool {
+	switch d.(type) {
+	case *ubuntu, *debian, *cos:
+		return true
+	default:
+		return false
+	}
+}
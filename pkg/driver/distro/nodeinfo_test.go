@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driverdistro
+
+import (
+	"testing"
+
+	"github.com/falcosecurity/driverkit/pkg/kernelrelease"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFromOSImage(t *testing.T) {
+	type testCase struct {
+		osImage        string
+		kernelRelease  string
+		targetExpected string
+		kvExpected     string
+		errExpected    error
+	}
+	testCases := []testCase{
+		{osImage: "Amazon Linux 2", kernelRelease: "5.10.205-195.804.amzn2.x86_64", targetExpected: "amazonlinux2", kvExpected: "1"},
+		{osImage: "Amazon Linux 2023.5.20240624", kernelRelease: "6.1.94-99.176.amzn2023.x86_64", targetExpected: "amazonlinux2023", kvExpected: "1"},
+		{osImage: "Bottlerocket OS 1.19.0 (aws-k8s-1.28)", kernelRelease: "6.1.66", targetExpected: "bottlerocket", kvExpected: "1_1.19.0-aws"},
+		{osImage: "Container-Optimized OS from Google", kernelRelease: "6.1.58+", targetExpected: "cos", kvExpected: "1"},
+		{osImage: "Flatcar Container Linux by Kinvolk 3510.2.1 (Oklo)", kernelRelease: "5.15.119-flatcar", targetExpected: "flatcar", kvExpected: "1"},
+		{osImage: "Talos (v1.5.0)", kernelRelease: "6.1.44-talos", targetExpected: "talos", kvExpected: "1_1.5.0"},
+		{osImage: "Ubuntu 22.04.3 LTS", kernelRelease: "5.15.0-1051-azure", targetExpected: "ubuntu-azure", kvExpected: "1"},
+		{osImage: "Windows Server 2022 Datacenter", kernelRelease: "10.0.20348.2113", targetExpected: UndeterminedDistro, kvExpected: "1",
+			errExpected: ErrUnsupported},
+	}
+
+	for _, tCase := range testCases {
+		kr := kernelrelease.FromString(tCase.kernelRelease)
+		kr.KernelVersion = "1"
+		d, err := FromOSImage(tCase.osImage, kr)
+		if tCase.errExpected != nil {
+			assert.ErrorIs(t, err, tCase.errExpected)
+		} else {
+			require.NoError(t, err)
+		}
+		assert.Equal(t, tCase.targetExpected, d.String(), tCase.osImage)
+		fixedKr := d.FixupKernel(kr)
+		assert.Equal(t, tCase.kvExpected, fixedKr.KernelVersion, tCase.osImage)
+	}
+}
+
+func TestKernelVersionDependent(t *testing.T) {
+	kr := kernelrelease.FromString("5.15.0-91-generic")
+	for osImage, expected := range map[string]bool{
+		"Ubuntu 22.04.3 LTS":                 true,
+		"Debian GNU/Linux 12 (bookworm)":     true,
+		"Container-Optimized OS from Google": true,
+		"Amazon Linux 2":                     false,
+		"Talos (v1.5.0)":                     false,
+	} {
+		d, err := FromOSImage(osImage, kr)
+		require.NoError(t, err)
+		assert.Equal(t, expected, KernelVersionDependent(d), osImage)
+	}
+}
+
+func TestFromOSImageFresh(t *testing.T) {
+	first, err := FromOSImage("Talos (v1.5.0)", kernelrelease.FromString("6.1.44-talos"))
+	require.NoError(t, err)
+	second, err := FromOSImage("Talos (v1.6.0)", kernelrelease.FromString("6.1.44-talos"))
+	require.NoError(t, err)
+
+	kr := kernelrelease.FromString("6.1.44-talos")
+	kr.KernelVersion = "1"
+	assert.Equal(t, "1_1.5.0", first.FixupKernel(kr).KernelVersion)
+	assert.Equal(t, "1_1.6.0", second.FixupKernel(kr).KernelVersion)
+	assert.NotSame(t, distros["talos"], first)
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driverdistro
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
+)
+
+// Get returns the content of the given url of a driver repo. A missing url yields an error
+// of class errdefs.ErrNotFound, while failing to reach the repo yields one of class errdefs.ErrNetwork.
+func Get(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	resp, err := do(ctx, client, http.MethodGet, url)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, errdefs.Errorf(errdefs.ErrNotFound, "%s not found", url)
+	default:
+		return nil, fmt.Errorf("unexpected status code %d from %q", resp.StatusCode, url)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// Exists returns whether the given url of a driver repo can be fetched, using a HEAD request.
+// Failing to reach the repo yields an error of class errdefs.ErrNetwork, rather than false.
+func Exists(ctx context.Context, client *http.Client, url string) (bool, error) {
+	resp, err := do(ctx, client, http.MethodHead, url)
+	if err != nil {
+		return false, err
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode == http.StatusOK, nil
+}
+
+// do sends a request to a driver repo. Transport errors and server errors are of class errdefs.ErrNetwork,
+// while in offline mode no request is sent and the error is of class errdefs.ErrOffline.
+func do(ctx context.Context, client *http.Client, method, url string) (*http.Response, error) {
+	if offline.Enabled() {
+		return nil, offline.Errorf("%q", url)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, errdefs.Wrap(errdefs.ErrNetwork, fmt.Errorf("unable to query %q: %w", url, err))
+	}
+	if resp.StatusCode >= http.StatusInternalServerError {
+		_ = resp.Body.Close()
+		return nil, errdefs.Errorf(errdefs.ErrNetwork, "unable to query %q: %s", url, resp.Status)
+	}
+	return resp, nil
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driverdistro
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/falcosecurity/driverkit/pkg/kernelrelease"
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+	"github.com/falcosecurity/falcoctl/pkg/test"
+)
+
+func TestGetExists(t *testing.T) {
+	server := test.NewDialCountingServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/found":
+			_, _ = w.Write([]byte("content"))
+		case "/broken":
+			w.WriteHeader(http.StatusBadGateway)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	data, err := Get(ctx, server.Client(), server.URL+"/found")
+	require.NoError(t, err)
+	assert.Equal(t, "content", string(data))
+	_, err = Get(ctx, server.Client(), server.URL+"/missing")
+	assert.ErrorIs(t, err, errdefs.ErrNotFound)
+	_, err = Get(ctx, server.Client(), server.URL+"/broken")
+	assert.ErrorIs(t, err, errdefs.ErrNetwork)
+
+	found, err := Exists(ctx, server.Client(), server.URL+"/found")
+	require.NoError(t, err)
+	assert.True(t, found)
+	found, err = Exists(ctx, server.Client(), server.URL+"/missing")
+	require.NoError(t, err)
+	assert.False(t, found)
+	_, err = Exists(ctx, server.Client(), server.URL+"/broken")
+	assert.ErrorIs(t, err, errdefs.ErrNetwork)
+}
+
+func TestOffline(t *testing.T) {
+	server := test.NewDialCountingServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte("content"))
+	}))
+	defer server.Close()
+	t.Setenv("HOME", t.TempDir())
+	offline.Enable(true)
+	t.Cleanup(func() { offline.Enable(false) })
+	ctx := context.Background()
+
+	_, err := Get(ctx, server.Client(), server.URL+"/found")
+	assert.ErrorIs(t, err, errdefs.ErrOffline)
+	_, err = Exists(ctx, server.Client(), server.URL+"/found")
+	assert.ErrorIs(t, err, errdefs.ErrOffline)
+
+	kmod, err := drivertype.Parse("kmod")
+	require.NoError(t, err)
+	printer := output.NewPrinter(pterm.LogLevelInfo, pterm.LogFormatterColorful, nil)
+	kr := kernelrelease.FromString("5.15.0-91-generic")
+	kr.Architecture = kernelrelease.Architecture("amd64")
+	_, err = Download(ctx, &generic{targetID: "generic"}, printer, kr, "falco", kmod, "7.0.0+driver",
+		[]string{server.URL}, "")
+	assert.ErrorIs(t, err, errdefs.ErrOffline)
+	assert.Contains(t, err.Error(), "offline: prebuilt driver \"falco_generic_5.15.0-91-generic_")
+
+	assert.Zero(t, server.Dials())
+}
+
+func TestDownloadTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := test.NewDialCountingServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+	t.Setenv("HOME", t.TempDir())
+
+	timeout := http.DefaultClient.Timeout
+	http.DefaultClient.Timeout = 100 * time.Millisecond
+	t.Cleanup(func() { http.DefaultClient.Timeout = timeout })
+
+	kmod, err := drivertype.Parse("kmod")
+	require.NoError(t, err)
+	printer := output.NewPrinter(pterm.LogLevelInfo, pterm.LogFormatterColorful, nil)
+	kr := kernelrelease.FromString("5.15.0-91-generic")
+	kr.Architecture = kernelrelease.Architecture("amd64")
+
+	start := time.Now()
+	_, err = Download(context.Background(), &generic{targetID: "generic"}, printer, kr, "falco", kmod, "7.0.0+driver",
+		[]string{server.URL}, "")
+	assert.ErrorIs(t, err, errdefs.ErrNotFound)
+	assert.Less(t, time.Since(start), 5*time.Second)
+}
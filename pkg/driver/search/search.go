@@ -0,0 +1,342 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver"
+	"github.com/falcosecurity/driverkit/pkg/kernelrelease"
+
+	driverdistro "github.com/falcosecurity/falcoctl/pkg/driver/distro"
+	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+	driverversion "github.com/falcosecurity/falcoctl/pkg/driver/version"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+)
+
+// IndexFile is the name of the optional file, in each driver version and architecture directory of a repo,
+// listing the prebuilt drivers it holds, one file name per line.
+const IndexFile = "index.txt"
+
+// MaxNearest is the maximum number of nearest matches reported for each repo.
+const MaxNearest = 5
+
+// Options defines the target and the repos to be searched.
+type Options struct {
+	DriverName string
+	// Versions are the driver versions always searched; versions found in the repos are added to them.
+	Versions []string
+	Repos    []string
+	// Types are the allowed driver types; types without artifacts are not searched.
+	Types  []drivertype.DriverType
+	Distro driverdistro.Distro
+	Kr     kernelrelease.KernelRelease
+	// Client is the http client used to query the repos; http.DefaultClient if nil.
+	Client *http.Client
+}
+
+// Match is a prebuilt driver found in a repo.
+type Match struct {
+	Repo     string `json:"repo"`
+	Version  string `json:"version"`
+	Type     string `json:"type"`
+	Filename string `json:"filename"`
+	URL      string `json:"url"`
+	// Exact is true when the driver has been built for the target kernel.
+	Exact bool `json:"exact"`
+}
+
+// Result holds the drivers found for the target.
+type Result struct {
+	// Exact are the drivers built for the target kernel, across driver versions and types.
+	Exact []Match `json:"exact"`
+	// Nearest are the drivers built for the same distro and the closest kernels.
+	// They are only filled when there is no exact match and the repo can be listed.
+	Nearest []Match `json:"nearest"`
+}
+
+// Search looks up the prebuilt drivers for the target in each repo.
+// Drivers are listed through the repo index file or an S3-style listing when available,
+// otherwise the expected file names are probed.
+func Search(ctx context.Context, opts *Options) (*Result, error) {
+	client := opts.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	arch := opts.Kr.Architecture.ToNonDeb()
+
+	wanted := make(map[string]drivertype.DriverType)
+	for _, driverType := range opts.Types {
+		if driverType.HasArtifacts() && driverType.Supported(opts.Kr) {
+			wanted[driverdistro.Filename(opts.Distro, opts.Kr, opts.DriverName, driverType)] = driverType
+		}
+	}
+
+	result := &Result{}
+	for _, repo := range opts.Repos {
+		repo = strings.TrimSuffix(repo, "/")
+		versions := repoVersions(ctx, client, repo, opts.Versions)
+
+		var candidates []Match
+		for _, version := range versions {
+			files, listed, err := listFiles(ctx, client, repo, version, arch)
+			if err != nil {
+				return nil, err
+			}
+			for filename, driverType := range wanted {
+				match := Match{
+					Repo:     repo,
+					Version:  version,
+					Type:     driverType.String(),
+					Filename: filename,
+					URL:      driverdistro.URL(repo, version, filename, opts.Kr),
+					Exact:    true,
+				}
+				if listed {
+					if _, ok := files[filename]; ok {
+						result.Exact = append(result.Exact, match)
+					}
+				} else if found, err := driverdistro.Exists(ctx, client, match.URL); err != nil {
+					return nil, err
+				} else if found {
+					result.Exact = append(result.Exact, match)
+				}
+			}
+			for filename := range files {
+				if _, ok := wanted[filename]; ok {
+					continue
+				}
+				if driverType := candidateType(filename, opts); driverType != nil {
+					candidates = append(candidates, Match{
+						Repo:     repo,
+						Version:  version,
+						Type:     driverType.String(),
+						Filename: filename,
+						URL:      driverdistro.URL(repo, version, filename, opts.Kr),
+					})
+				}
+			}
+		}
+		result.Nearest = append(result.Nearest, nearest(candidates, opts)...)
+	}
+
+	sortMatches(result.Exact)
+	if len(result.Exact) > 0 {
+		result.Nearest = nil
+	}
+	return result, nil
+}
+
+// candidateType returns the driver type of a file built for the target distro, or nil.
+func candidateType(filename string, opts *Options) drivertype.DriverType {
+	prefix := fmt.Sprintf("%s_%s_", opts.DriverName, opts.Distro)
+	if !strings.HasPrefix(filename, prefix) {
+		return nil
+	}
+	for _, driverType := range opts.Types {
+		if driverType.HasArtifacts() && strings.HasSuffix(filename, driverType.Extension()) {
+			return driverType
+		}
+	}
+	return nil
+}
+
+// nearest returns the candidates whose file name shares the longest prefix with the expected one,
+// ie: the drivers built for the closest kernel releases.
+func nearest(candidates []Match, opts *Options) []Match {
+	score := func(m *Match) int {
+		driverType, err := drivertype.Parse(m.Type)
+		if err != nil {
+			return 0
+		}
+		return commonPrefixLen(m.Filename, driverdistro.Filename(opts.Distro, opts.Kr, opts.DriverName, driverType))
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		si, sj := score(&candidates[i]), score(&candidates[j])
+		if si != sj {
+			return si > sj
+		}
+		return candidates[i].Filename < candidates[j].Filename
+	})
+	if len(candidates) > MaxNearest {
+		candidates = candidates[:MaxNearest]
+	}
+	return candidates
+}
+
+func commonPrefixLen(a, b string) int {
+	i := 0
+	for i < len(a) && i < len(b) && a[i] == b[i] {
+		i++
+	}
+	return i
+}
+
+// sortMatches sorts the matches by repo, greatest driver version first, and type.
+func sortMatches(matches []Match) {
+	sort.SliceStable(matches, func(i, j int) bool {
+		if matches[i].Repo != matches[j].Repo {
+			return matches[i].Repo < matches[j].Repo
+		}
+		if matches[i].Version != matches[j].Version {
+			return versionGreater(matches[i].Version, matches[j].Version)
+		}
+		return matches[i].Type < matches[j].Type
+	})
+}
+
+func versionGreater(a, b string) bool {
+	sa, errA := semver.Parse(a)
+	sb, errB := semver.Parse(b)
+	if errA != nil || errB != nil {
+		return a > b
+	}
+	return sa.GT(sb)
+}
+
+// repoVersions returns the given driver versions, plus the ones listed in the repo metadata file
+// or through an S3-style listing, greatest first.
+func repoVersions(ctx context.Context, client *http.Client, repo string, versions []string) []string {
+	seen := make(map[string]struct{})
+	var res []string
+	add := func(version string) {
+		if _, ok := seen[version]; version != "" && !ok {
+			seen[version] = struct{}{}
+			res = append(res, version)
+		}
+	}
+	for _, version := range versions {
+		add(version)
+	}
+
+	// The metadata file is optional: the search goes on with the known versions if it cannot be retrieved.
+	if drivers, err := driverversion.RepoDrivers(ctx, client, repo); err == nil {
+		for _, driver := range drivers {
+			add(driver.Version)
+		}
+	}
+
+	if listing, ok := listS3(ctx, client, repo+"/"); ok {
+		for _, prefix := range listing.prefixes {
+			add(prefix)
+		}
+	}
+
+	sort.SliceStable(res, func(i, j int) bool {
+		return versionGreater(res[i], res[j])
+	})
+	return res
+}
+
+// listFiles returns the file names held by the version and architecture directory of a repo,
+// and whether the repo could be listed at all.
+func listFiles(ctx context.Context, client *http.Client, repo, version, arch string) (map[string]struct{}, bool, error) {
+	files := make(map[string]struct{})
+
+	dir := fmt.Sprintf("%s/%s/%s/", repo, url.QueryEscape(version), arch)
+	// Only network errors are returned, since they would affect any other request to the repo.
+	data, err := driverdistro.Get(ctx, client, dir+IndexFile)
+	if errors.Is(err, errdefs.ErrNetwork) {
+		return nil, false, err
+	}
+	if err == nil {
+		scanner := bufio.NewScanner(bytes.NewReader(data))
+		for scanner.Scan() {
+			if line := strings.TrimSpace(scanner.Text()); line != "" {
+				files[line] = struct{}{}
+			}
+		}
+		return files, true, scanner.Err()
+	}
+
+	if listing, ok := listS3(ctx, client, fmt.Sprintf("%s/%s/%s/", repo, version, arch)); ok {
+		for _, key := range listing.keys {
+			files[key] = struct{}{}
+		}
+		return files, true, nil
+	}
+	return files, false, nil
+}
+
+// s3Listing holds the entries of a directory, relative to it.
+type s3Listing struct {
+	keys     []string
+	prefixes []string
+}
+
+// listBucketResult is the subset of the S3 ListObjectsV2 response used to list a directory.
+type listBucketResult struct {
+	XMLName  xml.Name `xml:"ListBucketResult"`
+	Contents []struct {
+		Key string `xml:"Key"`
+	} `xml:"Contents"`
+	CommonPrefixes []struct {
+		Prefix string `xml:"Prefix"`
+	} `xml:"CommonPrefixes"`
+	IsTruncated           bool   `xml:"IsTruncated"`
+	NextContinuationToken string `xml:"NextContinuationToken"`
+}
+
+// listS3 lists the given directory url, assuming the host exposes an S3-style listing at its root
+// and the url path maps to the object keys. It returns false when the listing is not available.
+func listS3(ctx context.Context, client *http.Client, dir string) (*s3Listing, bool) {
+	u, err := url.Parse(dir)
+	if err != nil {
+		return nil, false
+	}
+	prefix := strings.TrimPrefix(u.Path, "/")
+
+	listing := &s3Listing{}
+	token := ""
+	for {
+		query := url.Values{"list-type": {"2"}, "prefix": {prefix}, "delimiter": {"/"}}
+		if token != "" {
+			query.Set("continuation-token", token)
+		}
+		listURL := url.URL{Scheme: u.Scheme, Host: u.Host, Path: "/", RawQuery: query.Encode()}
+		data, err := driverdistro.Get(ctx, client, listURL.String())
+		if err != nil {
+			return nil, false
+		}
+		var res listBucketResult
+		if err := xml.Unmarshal(data, &res); err != nil {
+			return nil, false
+		}
+		for _, c := range res.Contents {
+			if key := strings.TrimPrefix(c.Key, prefix); key != "" {
+				listing.keys = append(listing.keys, key)
+			}
+		}
+		for _, p := range res.CommonPrefixes {
+			if dir := strings.TrimSuffix(strings.TrimPrefix(p.Prefix, prefix), "/"); dir != "" {
+				listing.prefixes = append(listing.prefixes, dir)
+			}
+		}
+		if !res.IsTruncated || res.NextContinuationToken == "" {
+			return listing, true
+		}
+		token = res.NextContinuationToken
+	}
+}
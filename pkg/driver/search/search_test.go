@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/falcosecurity/driverkit/pkg/kernelrelease"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	driverdistro "github.com/falcosecurity/falcoctl/pkg/driver/distro"
+	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+)
+
+const (
+	kmodFile = "falco_ubuntu-generic_5.15.0-91-generic_1.ko"
+	bpfFile  = "falco_ubuntu-generic_5.15.0-91-generic_1.o"
+)
+
+func newOptions(t *testing.T, repo string) *Options {
+	kr := kernelrelease.FromString("5.15.0-91-generic")
+	kr.KernelVersion = "1"
+	kr.Architecture = "amd64"
+	distro, err := driverdistro.FromOSImage("Ubuntu 22.04.3 LTS", kr)
+	require.NoError(t, err)
+	kmod, err := drivertype.Parse(drivertype.TypeKmod)
+	require.NoError(t, err)
+	bpf, err := drivertype.Parse(drivertype.TypeBpf)
+	require.NoError(t, err)
+	return &Options{
+		DriverName: "falco",
+		Versions:   []string{"7.0.0+driver"},
+		Repos:      []string{repo},
+		Types:      []drivertype.DriverType{kmod, bpf},
+		Distro:     distro,
+		Kr:         kr,
+	}
+}
+
+func TestSearchIndexFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/driver/driver-versions.json":
+			_, _ = w.Write([]byte(`{"drivers":[{"version":"6.0.0+driver","api_version":"5.0.0","schema_version":"2.12.0"}]}`))
+		case "/driver/7.0.0+driver/x86_64/" + IndexFile:
+			_, _ = fmt.Fprintf(w, "%s\n", kmodFile)
+		case "/driver/6.0.0+driver/x86_64/" + IndexFile:
+			_, _ = fmt.Fprintf(w, "%s\n%s\n", kmodFile, bpfFile)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	result, err := Search(context.Background(), newOptions(t, server.URL+"/driver"))
+	require.NoError(t, err)
+	require.Len(t, result.Exact, 3)
+	assert.Equal(t, "7.0.0+driver", result.Exact[0].Version)
+	assert.Equal(t, drivertype.TypeKmod, result.Exact[0].Type)
+	assert.Equal(t, server.URL+"/driver/7.0.0%2Bdriver/x86_64/"+kmodFile, result.Exact[0].URL)
+	assert.Equal(t, "6.0.0+driver", result.Exact[1].Version)
+	assert.Equal(t, drivertype.TypeBpf, result.Exact[1].Type)
+	assert.Equal(t, "6.0.0+driver", result.Exact[2].Version)
+	assert.Equal(t, drivertype.TypeKmod, result.Exact[2].Type)
+	assert.Empty(t, result.Nearest)
+}
+
+func TestSearchS3Listing(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" || r.URL.Query().Get("list-type") != "2" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		switch r.URL.Query().Get("prefix") {
+		case "driver/":
+			_, _ = w.Write([]byte(`<ListBucketResult>` +
+				`<CommonPrefixes><Prefix>driver/7.0.0+driver/</Prefix></CommonPrefixes>` +
+				`<CommonPrefixes><Prefix>driver/8.0.0+driver/</Prefix></CommonPrefixes>` +
+				`</ListBucketResult>`))
+		case "driver/7.0.0+driver/x86_64/":
+			_, _ = w.Write([]byte(`<ListBucketResult>` +
+				`<Contents><Key>driver/7.0.0+driver/x86_64/falco_ubuntu-generic_5.4.0-1-generic_1.ko</Key></Contents>` +
+				`<Contents><Key>driver/7.0.0+driver/x86_64/falco_ubuntu-generic_5.15.0-90-generic_1.ko</Key></Contents>` +
+				`<Contents><Key>driver/7.0.0+driver/x86_64/falco_debian_5.15.0-91-generic_1.ko</Key></Contents>` +
+				`</ListBucketResult>`))
+		case "driver/8.0.0+driver/x86_64/":
+			_, _ = w.Write([]byte(`<ListBucketResult></ListBucketResult>`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	result, err := Search(context.Background(), newOptions(t, server.URL+"/driver"))
+	require.NoError(t, err)
+	assert.Empty(t, result.Exact)
+	require.Len(t, result.Nearest, 2)
+	assert.Equal(t, "falco_ubuntu-generic_5.15.0-90-generic_1.ko", result.Nearest[0].Filename)
+	assert.Equal(t, "falco_ubuntu-generic_5.4.0-1-generic_1.ko", result.Nearest[1].Filename)
+	assert.False(t, result.Nearest[0].Exact)
+}
+
+func TestSearchProbe(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodHead && r.URL.Path == "/7.0.0+driver/x86_64/"+bpfFile {
+			w.WriteHeader(http.StatusOK)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	result, err := Search(context.Background(), newOptions(t, server.URL))
+	require.NoError(t, err)
+	require.Len(t, result.Exact, 1)
+	assert.Equal(t, bpfFile, result.Exact[0].Filename)
+	assert.Equal(t, drivertype.TypeBpf, result.Exact[0].Type)
+}
@@ -16,7 +16,9 @@
 package drivertype
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
 
@@ -38,15 +40,21 @@ func (b *bpf) String() string {
 }
 
 func (b *bpf) Cleanup(printer *output.Printer, driverName string) error {
-	symlinkPath := filepath.Join(homedir.Get(), ".falco", fmt.Sprintf("%s-bpf.o", driverName))
-	printer.Logger.Info("Removing eBPF probe symlink", printer.Logger.Args("path", symlinkPath))
-	_ = os.Remove(symlinkPath)
+	_ = b.Unload(printer, driverName)
 	return nil
 }
 
-func (b *bpf) Load(printer *output.Printer, src, driverName string, fallback bool) error {
+// Load symlinks the eBPF probe where Falco expects it.
+// It is a no-op if the symlink already points to src.
+func (b *bpf) Load(printer *output.Printer, src, driverName string, fallback bool, _ []string) error {
 	if !fallback {
-		symlinkPath := filepath.Join(homedir.Get(), ".falco", fmt.Sprintf("%s-bpf.o", driverName))
+		symlinkPath := b.symlinkPath(driverName)
+		if dest, err := os.Readlink(symlinkPath); err == nil && dest == src {
+			printer.Logger.Info("eBPF probe already symlinked", printer.Logger.Args("src", src, "dest", symlinkPath))
+			return nil
+		}
+		// Drop any stale symlink before creating the new one.
+		_ = os.Remove(symlinkPath)
 		printer.Logger.Info("Symlinking eBPF probe", printer.Logger.Args("src", src, "dest", symlinkPath))
 		err := os.Symlink(src, symlinkPath)
 		if err == nil {
@@ -59,6 +67,20 @@ func (b *bpf) Load(printer *output.Printer, src, driverName string, fallback boo
 	return nil
 }
 
+// Unload removes the eBPF probe symlink, if present.
+func (b *bpf) Unload(printer *output.Printer, driverName string) error {
+	symlinkPath := b.symlinkPath(driverName)
+	printer.Logger.Info("Removing eBPF probe symlink", printer.Logger.Args("path", symlinkPath))
+	if err := os.Remove(symlinkPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (b *bpf) symlinkPath(driverName string) string {
+	return filepath.Join(homedir.Get(), ".falco", fmt.Sprintf("%s-bpf.o", driverName))
+}
+
 func (b *bpf) Extension() string {
 	return ".o"
 }
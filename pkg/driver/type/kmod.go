@@ -18,8 +18,13 @@ package drivertype
 import (
 	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -30,8 +35,26 @@ import (
 )
 
 const (
-	maxRmmodWait  = 10
+	maxRmmodWait = 10
+
+	modprobeDir    = "etc/modprobe.d"
+	modulesLoadDir = "etc/modules-load.d"
+	managedHeader  = "# Managed by falcoctl, do not edit."
+)
+
+// ErrStillLoaded is returned by Unload when the driver is still loaded after all the attempts to unload it.
+var ErrStillLoaded = errors.New("driver still loaded")
+
+var (
+	// rmmodWaitTime is the time waited between the attempts to remove the kernel module.
 	rmmodWaitTime = 5 * time.Second
+	// sysModuleDir is where the kernel exposes the attributes of the loaded modules.
+	sysModuleDir = "/sys/module"
+	// modinfo returns the value of the given field of the kernel module at path.
+	modinfo = func(field, path string) (string, error) {
+		out, err := exec.Command("modinfo", "-F", field, path).Output() //nolint:gosec // false positive
+		return strings.TrimSpace(string(out)), err
+	}
 )
 
 func init() {
@@ -49,41 +72,16 @@ func (k *kmod) String() string {
 // Then, using dkms, it tries to fetch all
 // dkms-installed versions of the module to clean them up.
 func (k *kmod) Cleanup(printer *output.Printer, driverName string) error {
-	lsmod, err := exec.LookPath("lsmod")
-	if err != nil {
-		return err
-	}
-	rmmod, err := exec.LookPath("rmmod")
-	if err != nil {
+	err := k.Unload(printer, driverName)
+	switch {
+	case errors.Is(err, ErrStillLoaded):
+		// The dkms-installed versions are removed anyway, the loaded module is used until it is unloaded.
+		printer.Logger.Warn("Kernel module is still loaded, you could have incompatibility issues.")
+	case err != nil:
 		return err
 	}
 
 	kmodName := strings.ReplaceAll(driverName, "-", "_")
-	printer.Logger.Info("Check if kernel module is still loaded.")
-	lsmodCmdArgs := fmt.Sprintf(`%s | cut -d' ' -f1 | grep -qx %q`, lsmod, kmodName)
-	_, err = exec.Command("bash", "-c", lsmodCmdArgs).Output() //nolint:gosec // false positive
-	if err == nil {
-		unloaded := false
-		// Module is still loaded, try to remove it
-		for i := 0; i < maxRmmodWait; i++ {
-			printer.Logger.Info("Kernel module is still loaded.")
-			printer.Logger.Info("Trying to unload it with 'rmmod'.")
-			if _, err = exec.Command(rmmod, kmodName).Output(); err == nil { //nolint:gosec // false positive
-				printer.Logger.Info("OK! Unloading module succeeded.")
-				unloaded = true
-				break
-			}
-			printer.Logger.Info("Nothing to do...'falcoctl' will wait until you remove the kernel module to have a clean termination.")
-			printer.Logger.Info("Check that no process is using the kernel module with 'lsmod'.")
-			printer.Logger.Info("Sleep 5 seconds...")
-			time.Sleep(rmmodWaitTime)
-		}
-		if !unloaded {
-			printer.Logger.Warn("Kernel module is still loaded, you could have incompatibility issues.")
-		}
-	} else {
-		printer.Logger.Info("OK! There is no module loaded.")
-	}
 
 	dkms, err := exec.LookPath("dkms")
 	if err != nil {
@@ -118,12 +116,13 @@ func (k *kmod) Cleanup(printer *output.Printer, driverName string) error {
 	return nil
 }
 
-func (k *kmod) Load(printer *output.Printer, src, driverName string, fallback bool) error {
+func (k *kmod) Load(printer *output.Printer, src, driverName string, fallback bool, params []string) error {
 	if fallback {
 		// Try to modprobe any existent version of the kmod; this is a fallback
 		// when both download and build of kmod fail.
 		printer.Logger.Info("Trying to load a pre existent system module, if present.")
-		_, err := exec.Command("modprobe", driverName).Output()
+		modprobeArgs := append([]string{driverName}, params...)
+		_, err := exec.Command("modprobe", modprobeArgs...).Output()
 		if err == nil {
 			printer.Logger.Info("Success: module found and loaded with modprobe.")
 		} else {
@@ -132,19 +131,174 @@ func (k *kmod) Load(printer *output.Printer, src, driverName string, fallback bo
 		return err
 	}
 
+	kmodName := strings.ReplaceAll(driverName, "-", "_")
+	if loaded, _ := k.loaded(kmodName); loaded {
+		if err := sameModule(kmodName, src); err != nil {
+			return err
+		}
+		printer.Logger.Info("Kernel module already loaded, nothing to do.", printer.Logger.Args("driver", driverName))
+		return nil
+	}
+
 	chconCmdArgs := fmt.Sprintf(`chcon -t modules_object_t %q`, src)
 	// We don't want to catch any error from this call
 	// chcon(1): change file SELinux security context
 	_, _ = exec.Command("bash", "-c", chconCmdArgs).Output() //nolint:gosec // false positive
-	_, err := exec.Command("insmod", src).Output()
+	insmodArgs := append([]string{src}, params...)
+	_, err := exec.Command("insmod", insmodArgs...).Output()
 	if err == nil {
-		printer.Logger.Info("Success: module found and loaded in dkms.", printer.Logger.Args("driver", src))
+		printer.Logger.Info("Success: module found and loaded in dkms.", printer.Logger.Args("driver", src, "params", params))
 	} else {
 		printer.Logger.Warn("Unable to insmod module.", printer.Logger.Args("driver", src, "err", err))
 	}
 	return err
 }
 
+// Persist stores the module configuration under the host root so that it survives reboots.
+// Module parameters are written to a falcoctl-managed modprobe.d file; when loading on boot is requested,
+// the module is installed in the extra modules directory of the kernel, the modules dependencies
+// are regenerated and a modules-load.d entry is added.
+func (k *kmod) Persist(printer *output.Printer, src, driverName string, opts PersistOptions) error {
+	kmodName := strings.ReplaceAll(driverName, "-", "_")
+	confName := fmt.Sprintf("falcoctl-%s.conf", kmodName)
+
+	if opts.PersistParams {
+		modprobeConf := filepath.Join(opts.HostRoot, modprobeDir, confName)
+		if len(opts.Params) == 0 {
+			if err := os.Remove(modprobeConf); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return err
+			}
+		} else {
+			content := fmt.Sprintf("%s\noptions %s %s\n", managedHeader, kmodName, strings.Join(opts.Params, " "))
+			if err := writeConfFile(modprobeConf, content); err != nil {
+				return err
+			}
+			printer.Logger.Info("Module parameters persisted.", printer.Logger.Args("path", modprobeConf))
+		}
+	}
+
+	if !opts.LoadOnBoot {
+		return nil
+	}
+
+	extraDir := filepath.Join(opts.HostRoot, "lib", "modules", opts.KernelRelease, "extra")
+	dest := filepath.Join(extraDir, kmodName+k.Extension())
+	if err := os.MkdirAll(extraDir, 0o755); err != nil { //nolint:gosec // kernel modules directories are world readable
+		return err
+	}
+	if err := copyFile(src, dest); err != nil {
+		return fmt.Errorf("unable to install kernel module in %q: %w", extraDir, err)
+	}
+	printer.Logger.Info("Kernel module installed.", printer.Logger.Args("path", dest))
+
+	if depmod, err := exec.LookPath("depmod"); err != nil {
+		printer.Logger.Warn("Skipping depmod (depmod not found), the module may not be found at boot.")
+	} else if out, err := exec.Command(depmod, "-b", opts.HostRoot, opts.KernelRelease).CombinedOutput(); err != nil { //nolint:gosec // false positive
+		return fmt.Errorf("unable to run depmod: %w: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	modulesLoadConf := filepath.Join(opts.HostRoot, modulesLoadDir, confName)
+	if err := writeConfFile(modulesLoadConf, fmt.Sprintf("%s\n%s\n", managedHeader, kmodName)); err != nil {
+		return err
+	}
+	printer.Logger.Info("Kernel module configured to be loaded at boot.", printer.Logger.Args("path", modulesLoadConf))
+	return nil
+}
+
+// writeConfFile writes a configuration file, creating its parent directory if needed.
+func writeConfFile(path, content string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { //nolint:gosec // configuration directories are world readable
+		return err
+	}
+	return os.WriteFile(path, []byte(content), 0o644) //nolint:gosec // configuration files are world readable
+}
+
+func copyFile(src, dest string) error {
+	in, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+	out, err := os.Create(filepath.Clean(dest))
+	if err != nil {
+		return err
+	}
+	if _, err = io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	return out.Close()
+}
+
+// Unload tries to rmmod the loaded kmod, if present, without touching dkms.
+// It returns an error wrapping ErrStillLoaded if the kmod is still loaded after all the attempts.
+func (k *kmod) Unload(printer *output.Printer, driverName string) error {
+	rmmod, err := exec.LookPath("rmmod")
+	if err != nil {
+		return err
+	}
+
+	kmodName := strings.ReplaceAll(driverName, "-", "_")
+	printer.Logger.Info("Check if kernel module is still loaded.")
+	loaded, err := k.loaded(kmodName)
+	if err != nil {
+		return err
+	}
+	if !loaded {
+		printer.Logger.Info("OK! There is no module loaded.")
+		return nil
+	}
+
+	// Module is still loaded, try to remove it
+	for i := 0; i < maxRmmodWait; i++ {
+		printer.Logger.Info("Kernel module is still loaded.")
+		printer.Logger.Info("Trying to unload it with 'rmmod'.")
+		if _, err = exec.Command(rmmod, kmodName).Output(); err == nil { //nolint:gosec // false positive
+			printer.Logger.Info("OK! Unloading module succeeded.")
+			return nil
+		}
+		printer.Logger.Info("Nothing to do...'falcoctl' will wait until you remove the kernel module to have a clean termination.")
+		printer.Logger.Info("Check that no process is using the kernel module with 'lsmod'.")
+		printer.Logger.Info("Sleep 5 seconds...")
+		time.Sleep(rmmodWaitTime)
+	}
+	return fmt.Errorf("%w: unable to remove kernel module %q with 'rmmod': %w", ErrStillLoaded, kmodName, err)
+}
+
+// loaded returns whether the given kernel module is currently loaded.
+func (k *kmod) loaded(kmodName string) (bool, error) {
+	lsmod, err := exec.LookPath("lsmod")
+	if err != nil {
+		return false, err
+	}
+	lsmodCmdArgs := fmt.Sprintf(`%s | cut -d' ' -f1 | grep -qx %q`, lsmod, kmodName)
+	_, err = exec.Command("bash", "-c", lsmodCmdArgs).Output() //nolint:gosec // false positive
+	return err == nil, nil
+}
+
+// sameModule checks that the loaded kernel module kmodName is the one at src, comparing their srcversion
+// or, if the module does not expose it, their version.
+func sameModule(kmodName, src string) error {
+	for _, field := range []string{"srcversion", "version"} {
+		data, err := os.ReadFile(filepath.Join(sysModuleDir, kmodName, field))
+		if err != nil {
+			continue
+		}
+		loaded := strings.TrimSpace(string(data))
+		requested, err := modinfo(field, src)
+		if err != nil {
+			return fmt.Errorf("unable to read the %s of kernel module %q: %w", field, src, err)
+		}
+		if loaded != requested {
+			return fmt.Errorf("kernel module %q is already loaded with %s %q, while %q has %s %q: "+
+				"unload it first with 'falcoctl driver unload'", kmodName, field, loaded, src, field, requested)
+		}
+		return nil
+	}
+	return fmt.Errorf("kernel module %q is already loaded and its version cannot be compared with %q: "+
+		"unload it first with 'falcoctl driver unload'", kmodName, src)
+}
+
 func (k *kmod) Extension() string {
 	return ".ko"
 }
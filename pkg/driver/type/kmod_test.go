@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package drivertype
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/pterm/pterm"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+func TestKmodPersist(t *testing.T) {
+	// Make sure that depmod is not run against the fake host root.
+	t.Setenv("PATH", "")
+
+	hostRoot := t.TempDir()
+	src := filepath.Join(t.TempDir(), "falco.ko")
+	require.NoError(t, os.WriteFile(src, []byte("kmod"), 0o600))
+	printer := output.NewPrinter(pterm.LogLevelInfo, pterm.LogFormatterColorful, nil)
+	k := &kmod{}
+
+	modprobeConf := filepath.Join(hostRoot, "etc", "modprobe.d", "falcoctl-falco_test.conf")
+	modulesLoadConf := filepath.Join(hostRoot, "etc", "modules-load.d", "falcoctl-falco_test.conf")
+	installed := filepath.Join(hostRoot, "lib", "modules", "6.1.0-10-cloud-amd64", "extra", "falco_test.ko")
+
+	err := k.Persist(printer, src, "falco-test", PersistOptions{
+		HostRoot:      hostRoot,
+		KernelRelease: "6.1.0-10-cloud-amd64",
+		Params:        []string{"g_buffer_bytes_dim=16777216"},
+		PersistParams: true,
+	})
+	require.NoError(t, err)
+	content, err := os.ReadFile(modprobeConf)
+	require.NoError(t, err)
+	assert.Equal(t, managedHeader+"\noptions falco_test g_buffer_bytes_dim=16777216\n", string(content))
+	assert.NoFileExists(t, installed)
+	assert.NoFileExists(t, modulesLoadConf)
+
+	err = k.Persist(printer, src, "falco-test", PersistOptions{
+		HostRoot:      hostRoot,
+		KernelRelease: "6.1.0-10-cloud-amd64",
+		PersistParams: true,
+		LoadOnBoot:    true,
+	})
+	require.NoError(t, err)
+	assert.NoFileExists(t, modprobeConf)
+	content, err = os.ReadFile(installed)
+	require.NoError(t, err)
+	assert.Equal(t, "kmod", string(content))
+	content, err = os.ReadFile(modulesLoadConf)
+	require.NoError(t, err)
+	assert.Equal(t, managedHeader+"\nfalco_test\n", string(content))
+}
+
+func TestSameModule(t *testing.T) {
+	oldSysModuleDir, oldModinfo := sysModuleDir, modinfo
+	t.Cleanup(func() { sysModuleDir, modinfo = oldSysModuleDir, oldModinfo })
+
+	sysModuleDir = t.TempDir()
+	modinfo = func(field, _ string) (string, error) {
+		if field == "srcversion" {
+			return "ABC123", nil
+		}
+		return "7.0.0", nil
+	}
+
+	// Nothing exposed by the loaded module: it cannot be compared.
+	require.Error(t, sameModule("falco", "/falco.ko"))
+
+	require.NoError(t, os.MkdirAll(filepath.Join(sysModuleDir, "falco"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(sysModuleDir, "falco", "version"), []byte("7.0.0\n"), 0o600))
+	assert.NoError(t, sameModule("falco", "/falco.ko"))
+
+	// The srcversion takes precedence over the version.
+	require.NoError(t, os.WriteFile(filepath.Join(sysModuleDir, "falco", "srcversion"), []byte("DEF456\n"), 0o600))
+	assert.ErrorContains(t, sameModule("falco", "/falco.ko"), `srcversion "DEF456"`)
+
+	require.NoError(t, os.WriteFile(filepath.Join(sysModuleDir, "falco", "srcversion"), []byte("ABC123\n"), 0o600))
+	assert.NoError(t, sameModule("falco", "/falco.ko"))
+}
+
+func TestKmodUnloadStillLoaded(t *testing.T) {
+	oldRmmodWaitTime := rmmodWaitTime
+	t.Cleanup(func() { rmmodWaitTime = oldRmmodWaitTime })
+	rmmodWaitTime = 0
+
+	// Fake lsmod always listing the module and rmmod always failing to remove it.
+	bin := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "lsmod"), []byte("#!/bin/sh\necho 'falco_test 16384 1'\n"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(bin, "rmmod"), []byte("#!/bin/sh\nexit 1\n"), 0o700))
+	t.Setenv("PATH", bin+string(os.PathListSeparator)+os.Getenv("PATH"))
+	printer := output.NewPrinter(pterm.LogLevelInfo, pterm.LogFormatterColorful, nil)
+
+	err := (&kmod{}).Unload(printer, "falco-test")
+	assert.ErrorIs(t, err, ErrStillLoaded)
+}
@@ -42,7 +42,11 @@ func (m *modernBpf) Cleanup(_ *output.Printer, _ string) error {
 	return nil
 }
 
-func (m *modernBpf) Load(_ *output.Printer, _, _ string, _ bool) error {
+func (m *modernBpf) Load(_ *output.Printer, _, _ string, _ bool, _ []string) error {
+	return nil
+}
+
+func (m *modernBpf) Unload(_ *output.Printer, _ string) error {
 	return nil
 }
 
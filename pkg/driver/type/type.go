@@ -22,6 +22,7 @@ import (
 	"github.com/falcosecurity/driverkit/cmd"
 	"github.com/falcosecurity/driverkit/pkg/kernelrelease"
 
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 	"github.com/falcosecurity/falcoctl/pkg/output"
 )
 
@@ -34,13 +35,33 @@ var driverTypes = map[string]DriverType{}
 type DriverType interface {
 	fmt.Stringer
 	Cleanup(printer *output.Printer, driverName string) error
-	Load(printer *output.Printer, src, driverName string, fallback bool) error
+	Load(printer *output.Printer, src, driverName string, fallback bool, params []string) error
+	Unload(printer *output.Printer, driverName string) error
 	Extension() string
 	HasArtifacts() bool
 	ToOutput(destPath string) cmd.OutputOptions
 	Supported(kr kernelrelease.KernelRelease) bool
 }
 
+// PersistOptions defines how the driver configuration is persisted on the host, so that it survives reboots.
+type PersistOptions struct {
+	// HostRoot is the root of the host filesystem.
+	HostRoot string
+	// KernelRelease is the release of the kernel the driver has been built for.
+	KernelRelease string
+	// Params are the driver parameters, in the key=value form.
+	Params []string
+	// PersistParams writes the parameters to a falcoctl-managed configuration file.
+	PersistParams bool
+	// LoadOnBoot installs the driver so that it is loaded at boot.
+	LoadOnBoot bool
+}
+
+// Persister is implemented by the driver types whose configuration can be persisted across reboots.
+type Persister interface {
+	Persist(printer *output.Printer, src, driverName string, opts PersistOptions) error
+}
+
 // GetTypes return the list of supported driver types.
 func GetTypes() []string {
 	driverTypesSlice := make([]string, 0)
@@ -55,5 +76,5 @@ func Parse(driverType string) (DriverType, error) {
 	if dType, ok := driverTypes[driverType]; ok {
 		return dType, nil
 	}
-	return nil, fmt.Errorf("unsupported driver type specified: %s", driverType)
+	return nil, errdefs.Errorf(errdefs.ErrDriverUnsupported, "unsupported driver type specified: %s", driverType)
 }
@@ -0,0 +1,258 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driverversion
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/blang/semver"
+
+	driverdistro "github.com/falcosecurity/falcoctl/pkg/driver/distro"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
+)
+
+// MetadataFile is the name of the file, at the root of a driver repo, listing the API and schema versions
+// implemented by each driver version.
+//
+// Example:
+//
+//	{"drivers": [{"version": "7.0.0+driver", "api_version": "8.0.0", "schema_version": "2.15.0"}]}
+const MetadataFile = "driver-versions.json"
+
+const (
+	apiVersionFile    = "API_VERSION"
+	schemaVersionFile = "SCHEMA_VERSION"
+)
+
+// Requirements are the driver API and schema versions required by Falco.
+type Requirements struct {
+	APIVersion    semver.Version
+	SchemaVersion semver.Version
+	// DefaultDriverVersion is the driver version Falco has been built with, if known.
+	DefaultDriverVersion string
+}
+
+// falcoVersions is the subset of the versions exposed by Falco,
+// either through "falco --version --format json" or the webserver "/versions" endpoint.
+type falcoVersions struct {
+	DriverAPIVersion     string `json:"driver_api_version"`
+	DriverSchemaVersion  string `json:"driver_schema_version"`
+	DefaultDriverVersion string `json:"default_driver_version"`
+}
+
+// NewRequirements returns the requirements for the given driver API and schema versions.
+func NewRequirements(apiVersion, schemaVersion string) (*Requirements, error) {
+	api, err := semver.Parse(apiVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid driver API version %q: %w", apiVersion, err)
+	}
+	schema, err := semver.Parse(schemaVersion)
+	if err != nil {
+		return nil, fmt.Errorf("invalid driver schema version %q: %w", schemaVersion, err)
+	}
+	return &Requirements{APIVersion: api, SchemaVersion: schema}, nil
+}
+
+// ParseRequirements parses the JSON versions exposed by Falco.
+func ParseRequirements(data []byte) (*Requirements, error) {
+	var versions falcoVersions
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal Falco versions: %w", err)
+	}
+	if versions.DriverAPIVersion == "" || versions.DriverSchemaVersion == "" {
+		return nil, errors.New("the versions exposed by Falco do not contain the required driver API and schema versions")
+	}
+	req, err := NewRequirements(versions.DriverAPIVersion, versions.DriverSchemaVersion)
+	if err != nil {
+		return nil, err
+	}
+	req.DefaultDriverVersion = versions.DefaultDriverVersion
+	return req, nil
+}
+
+// FromFalcoBinary retrieves the requirements from the version output of the given Falco binary.
+func FromFalcoBinary(ctx context.Context, path string) (*Requirements, error) {
+	out, err := exec.CommandContext(ctx, path, "--version", "--format", "json").Output() //nolint:gosec // the binary is chosen by the user
+	if err != nil {
+		return nil, fmt.Errorf("unable to get versions from Falco binary %q: %w", path, err)
+	}
+	return ParseRequirements(out)
+}
+
+// FromURL retrieves the requirements from the versions endpoint of the Falco webserver.
+// In offline mode, it fails without sending any request.
+func FromURL(ctx context.Context, client *http.Client, url string) (*Requirements, error) {
+	if offline.Enabled() {
+		return nil, offline.Errorf("Falco versions %q", url)
+	}
+	data, err := driverdistro.Get(ctx, client, url)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get versions from URL %q: %w", url, err)
+	}
+	return ParseRequirements(data)
+}
+
+// Driver holds the API and schema versions implemented by a driver version.
+type Driver struct {
+	Version       string
+	APIVersion    semver.Version
+	SchemaVersion semver.Version
+}
+
+type driverMetadata struct {
+	Version       string `json:"version"`
+	APIVersion    string `json:"api_version"`
+	SchemaVersion string `json:"schema_version"`
+}
+
+// Compatible returns whether the driver satisfies the requirements, using the same rules as Falco:
+// same major version, and minor and patch versions greater or equal than the required ones.
+func (d *Driver) Compatible(req *Requirements) bool {
+	return compatible(d.APIVersion, req.APIVersion) && compatible(d.SchemaVersion, req.SchemaVersion)
+}
+
+func compatible(actual, required semver.Version) bool {
+	return actual.Major == required.Major && actual.GTE(required)
+}
+
+// LocalDrivers returns the drivers whose sources are found in the directories matching the given glob pattern
+// (eg: /usr/src/falco-*), reading the API_VERSION and SCHEMA_VERSION files they contain.
+// The driver version is taken from the directory name, after the last "-" of the pattern.
+func LocalDrivers(pattern string) []Driver {
+	var drivers []Driver
+	prefix := filepath.Base(strings.TrimSuffix(pattern, "*"))
+	paths, _ := filepath.Glob(pattern)
+	for _, path := range paths {
+		api, err := readVersionFile(filepath.Join(path, apiVersionFile))
+		if err != nil {
+			continue
+		}
+		schema, err := readVersionFile(filepath.Join(path, schemaVersionFile))
+		if err != nil {
+			continue
+		}
+		drivers = append(drivers, Driver{
+			Version:       strings.TrimPrefix(filepath.Base(path), prefix),
+			APIVersion:    api,
+			SchemaVersion: schema,
+		})
+	}
+	return drivers
+}
+
+func readVersionFile(path string) (semver.Version, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return semver.Version{}, err
+	}
+	return semver.Parse(strings.TrimSpace(string(data)))
+}
+
+// RepoDrivers returns the drivers listed in the metadata file of the given repo.
+// A repo without metadata file has no drivers. In offline mode, it fails without sending any request.
+func RepoDrivers(ctx context.Context, client *http.Client, repo string) ([]Driver, error) {
+	url := fmt.Sprintf("%s/%s", strings.TrimSuffix(repo, "/"), MetadataFile)
+	if offline.Enabled() {
+		return nil, offline.Errorf("driver metadata %q", url)
+	}
+	data, err := driverdistro.Get(ctx, client, url)
+	if errors.Is(err, errdefs.ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to get driver metadata from URL %q: %w", url, err)
+	}
+
+	var metadata struct {
+		Drivers []driverMetadata `json:"drivers"`
+	}
+	if err := json.Unmarshal(data, &metadata); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal driver metadata from URL %q: %w", url, err)
+	}
+	drivers := make([]Driver, 0, len(metadata.Drivers))
+	for _, m := range metadata.Drivers {
+		api, err := semver.Parse(m.APIVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid API version for driver %q: %w", m.Version, err)
+		}
+		schema, err := semver.Parse(m.SchemaVersion)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema version for driver %q: %w", m.Version, err)
+		}
+		drivers = append(drivers, Driver{Version: m.Version, APIVersion: api, SchemaVersion: schema})
+	}
+	return drivers, nil
+}
+
+// Select returns the driver version to be used with the given requirements:
+// the default driver version of Falco if known, or else the greatest compatible version among the drivers.
+func Select(req *Requirements, drivers []Driver) (string, error) {
+	if req.DefaultDriverVersion != "" {
+		return req.DefaultDriverVersion, nil
+	}
+
+	var (
+		selected   string
+		selectedSv semver.Version
+	)
+	for i := range drivers {
+		if !drivers[i].Compatible(req) {
+			continue
+		}
+		sv, err := semver.Parse(drivers[i].Version)
+		if err != nil {
+			continue
+		}
+		if selected == "" || sv.GT(selectedSv) {
+			selected, selectedSv = drivers[i].Version, sv
+		}
+	}
+	if selected == "" {
+		return "", errdefs.Errorf(errdefs.ErrRequirementsUnmet,
+			"no known driver version implements driver API version %s and schema version %s", req.APIVersion, req.SchemaVersion)
+	}
+	return selected, nil
+}
+
+// Validate checks that the given driver version satisfies the requirements.
+// It returns false if the API and schema versions of the driver version are not known.
+func Validate(version string, req *Requirements, drivers []Driver) (bool, error) {
+	if version == req.DefaultDriverVersion {
+		return true, nil
+	}
+	for i := range drivers {
+		if drivers[i].Version != version {
+			continue
+		}
+		if !drivers[i].Compatible(req) {
+			return true, errdefs.Errorf(errdefs.ErrRequirementsUnmet,
+				"driver version %s implements driver API version %s and schema version %s, "+
+					"while Falco requires driver API version %s and schema version %s",
+				version, drivers[i].APIVersion, drivers[i].SchemaVersion, req.APIVersion, req.SchemaVersion)
+		}
+		return true, nil
+	}
+	return false, nil
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package driverversion
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/blang/semver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
+	"github.com/falcosecurity/falcoctl/pkg/test"
+)
+
+const falcoVersionsJSON = `{"default_driver_version":"7.0.0+driver","driver_api_version":"8.0.0",` +
+	`"driver_schema_version":"2.15.0","engine_version":"31","falco_version":"0.37.0"}`
+
+func TestParseRequirements(t *testing.T) {
+	req, err := ParseRequirements([]byte(falcoVersionsJSON))
+	require.NoError(t, err)
+	assert.Equal(t, semver.MustParse("8.0.0"), req.APIVersion)
+	assert.Equal(t, semver.MustParse("2.15.0"), req.SchemaVersion)
+	assert.Equal(t, "7.0.0+driver", req.DefaultDriverVersion)
+
+	_, err = ParseRequirements([]byte(`{"falco_version":"0.37.0"}`))
+	assert.Error(t, err)
+}
+
+func TestSelectAndValidate(t *testing.T) {
+	req, err := NewRequirements("8.0.0", "2.15.0")
+	require.NoError(t, err)
+	drivers := []Driver{
+		{Version: "6.0.0+driver", APIVersion: semver.MustParse("5.0.0"), SchemaVersion: semver.MustParse("2.12.0")},
+		{Version: "7.0.0+driver", APIVersion: semver.MustParse("8.0.0"), SchemaVersion: semver.MustParse("2.15.0")},
+		{Version: "7.1.0+driver", APIVersion: semver.MustParse("8.1.0"), SchemaVersion: semver.MustParse("2.16.0")},
+		{Version: "8.0.0+driver", APIVersion: semver.MustParse("9.0.0"), SchemaVersion: semver.MustParse("3.0.0")},
+	}
+
+	version, err := Select(req, drivers)
+	require.NoError(t, err)
+	assert.Equal(t, "7.1.0+driver", version)
+
+	known, err := Validate("7.0.0+driver", req, drivers)
+	assert.True(t, known)
+	assert.NoError(t, err)
+
+	known, err = Validate("8.0.0+driver", req, drivers)
+	assert.True(t, known)
+	assert.ErrorIs(t, err, errdefs.ErrRequirementsUnmet)
+
+	known, err = Validate("9.9.9+driver", req, drivers)
+	assert.False(t, known)
+	assert.NoError(t, err)
+
+	_, err = Select(req, drivers[:1])
+	assert.ErrorIs(t, err, errdefs.ErrRequirementsUnmet)
+
+	// The default driver version of Falco always wins.
+	req.DefaultDriverVersion = "7.0.0+driver"
+	version, err = Select(req, drivers)
+	require.NoError(t, err)
+	assert.Equal(t, "7.0.0+driver", version)
+}
+
+func TestLocalDrivers(t *testing.T) {
+	srcDir := t.TempDir()
+	driverDir := filepath.Join(srcDir, "falco-7.0.0+driver")
+	require.NoError(t, os.MkdirAll(driverDir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(driverDir, apiVersionFile), []byte("8.0.0\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(driverDir, schemaVersionFile), []byte("2.15.0\n"), 0o600))
+	// Directories without version files are skipped.
+	require.NoError(t, os.MkdirAll(filepath.Join(srcDir, "falco-6.0.0+driver"), 0o750))
+
+	drivers := LocalDrivers(filepath.Join(srcDir, "falco-*"))
+	assert.Equal(t, []Driver{{
+		Version:       "7.0.0+driver",
+		APIVersion:    semver.MustParse("8.0.0"),
+		SchemaVersion: semver.MustParse("2.15.0"),
+	}}, drivers)
+}
+
+func TestRemoteSources(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/with-metadata/" + MetadataFile:
+			_, _ = w.Write([]byte(`{"drivers":[{"version":"7.0.0+driver","api_version":"8.0.0","schema_version":"2.15.0"}]}`))
+		case "/versions":
+			_, _ = w.Write([]byte(falcoVersionsJSON))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	ctx := context.Background()
+
+	drivers, err := RepoDrivers(ctx, server.Client(), server.URL+"/with-metadata")
+	require.NoError(t, err)
+	require.Len(t, drivers, 1)
+	assert.Equal(t, "7.0.0+driver", drivers[0].Version)
+
+	drivers, err = RepoDrivers(ctx, server.Client(), server.URL+"/without-metadata")
+	require.NoError(t, err)
+	assert.Empty(t, drivers)
+
+	req, err := FromURL(ctx, server.Client(), server.URL+"/versions")
+	require.NoError(t, err)
+	assert.Equal(t, "7.0.0+driver", req.DefaultDriverVersion)
+}
+
+func TestRemoteSourcesOffline(t *testing.T) {
+	server := test.NewDialCountingServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(falcoVersionsJSON))
+	}))
+	defer server.Close()
+	offline.Enable(true)
+	t.Cleanup(func() { offline.Enable(false) })
+	ctx := context.Background()
+
+	_, err := RepoDrivers(ctx, server.Client(), server.URL)
+	assert.ErrorIs(t, err, errdefs.ErrOffline)
+	_, err = FromURL(ctx, server.Client(), server.URL+"/versions")
+	assert.ErrorIs(t, err, errdefs.ErrOffline)
+	assert.Contains(t, err.Error(), "offline: Falco versions")
+
+	assert.Zero(t, server.Dials())
+}
@@ -0,0 +1,243 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errdefs
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+
+	"oras.land/oras-go/v2/errdef"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+var (
+	// ErrNotFound is the class of errors returned when an artifact, an index, a reference or a driver cannot be found.
+	ErrNotFound = errors.New("not found")
+	// ErrUnauthorized is the class of errors returned when a remote endpoint rejects the provided credentials.
+	ErrUnauthorized = errors.New("unauthorized")
+	// ErrSignatureInvalid is the class of errors returned when the signature of an artifact cannot be verified.
+	ErrSignatureInvalid = errors.New("signature invalid")
+	// ErrRequirementsUnmet is the class of errors returned when an artifact requires something the running Falco does not provide.
+	ErrRequirementsUnmet = errors.New("requirements unmet")
+	// ErrDependencyConflict is the class of errors returned when the dependencies of a set of artifacts cannot be satisfied.
+	ErrDependencyConflict = errors.New("dependency conflict")
+	// ErrNetwork is the class of errors returned when a remote endpoint cannot be reached.
+	ErrNetwork = errors.New("network error")
+	// ErrPermission is the class of errors returned when falcoctl is not allowed to access a path on the filesystem.
+	ErrPermission = errors.New("permission denied")
+	// ErrDriverUnsupported is the class of errors returned when the running system cannot use the requested driver.
+	ErrDriverUnsupported = errors.New("driver unsupported")
+	// ErrOffline is the class of errors returned when offline mode is enabled and a resource is only available remotely.
+	ErrOffline = errors.New("offline")
+	// ErrDigestMismatch is the class of errors returned when a pulled artifact does not have the digest expected by its index.
+	ErrDigestMismatch = errors.New("digest mismatch")
+	// ErrAdvisory is the class of errors returned when an installed or selected artifact version is affected by an advisory.
+	ErrAdvisory = errors.New("advisory")
+	// ErrFileConflict is the class of errors returned when installing an artifact would overwrite a file it does not own,
+	// such as a file modified locally or owned by another artifact.
+	ErrFileConflict = errors.New("file conflict")
+)
+
+// Exit codes returned by falcoctl. Each error class has its own exit code,
+// errors not belonging to any class exit with ExitGeneric.
+const (
+	// ExitOK is returned when the command succeeds.
+	ExitOK = 0
+	// ExitGeneric is returned for errors not belonging to any known class.
+	ExitGeneric = 1
+	// ExitNotFound is returned for errors of class ErrNotFound.
+	ExitNotFound = 2
+	// ExitUnauthorized is returned for errors of class ErrUnauthorized.
+	ExitUnauthorized = 3
+	// ExitSignatureInvalid is returned for errors of class ErrSignatureInvalid.
+	ExitSignatureInvalid = 4
+	// ExitRequirementsUnmet is returned for errors of class ErrRequirementsUnmet.
+	ExitRequirementsUnmet = 5
+	// ExitDependencyConflict is returned for errors of class ErrDependencyConflict.
+	ExitDependencyConflict = 6
+	// ExitNetwork is returned for errors of class ErrNetwork.
+	ExitNetwork = 7
+	// ExitPermission is returned for errors of class ErrPermission.
+	ExitPermission = 8
+	// ExitDriverUnsupported is returned for errors of class ErrDriverUnsupported.
+	ExitDriverUnsupported = 9
+	// ExitOffline is returned for errors of class ErrOffline.
+	ExitOffline = 10
+	// ExitDigestMismatch is returned for errors of class ErrDigestMismatch.
+	ExitDigestMismatch = 11
+	// ExitAdvisory is returned for errors of class ErrAdvisory.
+	ExitAdvisory = 12
+	// ExitFileConflict is returned for errors of class ErrFileConflict.
+	ExitFileConflict = 13
+)
+
+type class struct {
+	err  error
+	name string
+	code int
+}
+
+var classes = []class{
+	{err: ErrNotFound, name: "not_found", code: ExitNotFound},
+	{err: ErrUnauthorized, name: "unauthorized", code: ExitUnauthorized},
+	{err: ErrSignatureInvalid, name: "signature_invalid", code: ExitSignatureInvalid},
+	{err: ErrRequirementsUnmet, name: "requirements_unmet", code: ExitRequirementsUnmet},
+	{err: ErrDependencyConflict, name: "dependency_conflict", code: ExitDependencyConflict},
+	{err: ErrNetwork, name: "network", code: ExitNetwork},
+	{err: ErrPermission, name: "permission", code: ExitPermission},
+	{err: ErrDriverUnsupported, name: "driver_unsupported", code: ExitDriverUnsupported},
+	{err: ErrOffline, name: "offline", code: ExitOffline},
+	{err: ErrDigestMismatch, name: "digest_mismatch", code: ExitDigestMismatch},
+	{err: ErrAdvisory, name: "advisory", code: ExitAdvisory},
+	{err: ErrFileConflict, name: "file_conflict", code: ExitFileConflict},
+}
+
+// Error is an error tagged with one of the classes defined in this package.
+// The message is the one of the wrapped error, so that tagging an error does
+// not change what gets printed to the user.
+type Error struct {
+	// Class is one of the sentinel errors defined in this package.
+	Class error
+	// Err is the underlying error.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *Error) Error() string {
+	return e.Err.Error()
+}
+
+// Unwrap allows errors.Is and errors.As to match both the class and the underlying error.
+func (e *Error) Unwrap() []error {
+	return []error{e.Class, e.Err}
+}
+
+// Wrap tags err with the given class. It returns nil if err is nil.
+func Wrap(class, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &Error{Class: class, Err: err}
+}
+
+// Errorf formats an error the same way fmt.Errorf does and tags it with the given class.
+func Errorf(class error, format string, a ...any) error {
+	return &Error{Class: class, Err: fmt.Errorf(format, a...)}
+}
+
+// Classify tags err with the class inferred from its chain, see ClassOf.
+// If no class can be inferred err is returned untouched.
+func Classify(err error) error {
+	if err == nil {
+		return nil
+	}
+	var e *Error
+	if errors.As(err, &e) {
+		return err
+	}
+	if c := ClassOf(err); c != nil {
+		return Wrap(c, err)
+	}
+	return err
+}
+
+// ClassOf returns the class of err, or nil if err does not belong to any class.
+// Errors explicitly tagged with a class take precedence, otherwise the class is
+// inferred from well known errors in the chain: filesystem permission errors,
+// network errors and the errors returned by OCI registries.
+func ClassOf(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e.Class
+	}
+
+	for _, c := range classes {
+		if errors.Is(err, c.err) {
+			return c.err
+		}
+	}
+
+	var respErr *errcode.ErrorResponse
+	if errors.As(err, &respErr) {
+		switch respErr.StatusCode {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return ErrUnauthorized
+		case http.StatusNotFound:
+			return ErrNotFound
+		}
+	}
+
+	var netErr net.Error
+	switch {
+	case errors.Is(err, fs.ErrPermission):
+		return ErrPermission
+	case errors.Is(err, fs.ErrNotExist), errors.Is(err, errdef.ErrNotFound):
+		return ErrNotFound
+	case errors.As(err, &netErr):
+		return ErrNetwork
+	}
+
+	return nil
+}
+
+// ExitCode returns the exit code associated to the class of err.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitOK
+	}
+	return lookup(ClassOf(err)).code
+}
+
+// ClassName returns the name of the class of err, as found in its JSON representation.
+func ClassName(err error) string {
+	return lookup(ClassOf(err)).name
+}
+
+// JSONError is the machine-readable representation of an error.
+type JSONError struct {
+	Class   string `json:"class"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ToJSON returns the machine-readable representation of err. It returns nil if err is nil.
+func ToJSON(err error) *JSONError {
+	if err == nil {
+		return nil
+	}
+	c := lookup(ClassOf(err))
+	return &JSONError{
+		Class:   c.name,
+		Code:    c.code,
+		Message: err.Error(),
+	}
+}
+
+func lookup(err error) class {
+	for _, c := range classes {
+		if c.err == err {
+			return c
+		}
+	}
+	return class{name: "unknown", code: ExitGeneric}
+}
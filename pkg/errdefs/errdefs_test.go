@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errdefs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"oras.land/oras-go/v2/registry/remote/errcode"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		code int
+	}{
+		{name: "nil error", err: nil, code: ExitOK},
+		{name: "unclassified error", err: errors.New("boom"), code: ExitGeneric},
+		{name: "tagged error", err: Errorf(ErrNotFound, "unable to find a prebuilt driver"), code: ExitNotFound},
+		{name: "wrapped tagged error", err: fmt.Errorf("failed: %w", Wrap(ErrSignatureInvalid, errors.New("bad"))), code: ExitSignatureInvalid},
+		{name: "sentinel", err: fmt.Errorf("%w: foo", ErrDependencyConflict), code: ExitDependencyConflict},
+		{name: "permission", err: &fs.PathError{Op: "open", Path: "/foo", Err: fs.ErrPermission}, code: ExitPermission},
+		{name: "not exist", err: &fs.PathError{Op: "open", Path: "/foo", Err: fs.ErrNotExist}, code: ExitNotFound},
+		{name: "network", err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}, code: ExitNetwork},
+		{name: "registry unauthorized", err: &errcode.ErrorResponse{StatusCode: http.StatusUnauthorized}, code: ExitUnauthorized},
+		{name: "registry not found", err: &errcode.ErrorResponse{StatusCode: http.StatusNotFound}, code: ExitNotFound},
+		{name: "requirements", err: Errorf(ErrRequirementsUnmet, "incompatible versions"), code: ExitRequirementsUnmet},
+		{name: "driver", err: Errorf(ErrDriverUnsupported, "unsupported driver type specified: foo"), code: ExitDriverUnsupported},
+		{name: "offline", err: Errorf(ErrOffline, "offline: index \"falcosecurity\" not available locally"), code: ExitOffline},
+		{name: "digest", err: Errorf(ErrDigestMismatch, "digest of \"ghcr.io/falcosecurity/rules/falco-rules:3.0.0\" does not match the index"), code: ExitDigestMismatch},
+		{name: "advisory", err: Errorf(ErrAdvisory, "version 0.9.0 of \"cloudtrail\" is affected by advisories FALCO-2025-001"), code: ExitAdvisory},
+		{name: "file conflict", err: Errorf(ErrFileConflict, "\"/etc/falco/falco_rules.yaml\" has been modified locally"), code: ExitFileConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.code, ExitCode(tt.err))
+		})
+	}
+}
+
+func TestErrorKeepsMessageAndChain(t *testing.T) {
+	cause := errors.New("cannot satisfy dependencies")
+	err := fmt.Errorf("%w: foo depends on bar:1.0.0", Wrap(ErrDependencyConflict, cause))
+
+	assert.Equal(t, "cannot satisfy dependencies: foo depends on bar:1.0.0", err.Error())
+	assert.ErrorIs(t, err, cause)
+	assert.ErrorIs(t, err, ErrDependencyConflict)
+	assert.Equal(t, ErrDependencyConflict, ClassOf(err))
+}
+
+func TestClassify(t *testing.T) {
+	assert.NoError(t, Classify(nil))
+
+	plain := errors.New("boom")
+	assert.Equal(t, plain, Classify(plain))
+
+	// Explicit classes must not be overridden.
+	tagged := Wrap(ErrNetwork, &fs.PathError{Op: "open", Path: "/foo", Err: fs.ErrPermission})
+	assert.Equal(t, ErrNetwork, ClassOf(Classify(tagged)))
+
+	inferred := Classify(&errcode.ErrorResponse{StatusCode: http.StatusForbidden})
+	assert.ErrorIs(t, inferred, ErrUnauthorized)
+}
+
+func TestToJSON(t *testing.T) {
+	assert.Nil(t, ToJSON(nil))
+
+	data, err := json.Marshal(ToJSON(Errorf(ErrNotFound, "index %q not found", "falcosecurity")))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"class":"not_found","code":2,"message":"index \"falcosecurity\" not found"}`, string(data))
+
+	data, err = json.Marshal(ToJSON(errors.New("boom")))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"class":"unknown","code":1,"message":"boom"}`, string(data))
+}
@@ -22,52 +22,68 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/falcosecurity/falcoctl/internal/config"
 	"github.com/falcosecurity/falcoctl/internal/consts"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 	indexConf "github.com/falcosecurity/falcoctl/pkg/index/config"
 	"github.com/falcosecurity/falcoctl/pkg/index/fetch"
 	"github.com/falcosecurity/falcoctl/pkg/index/index"
 )
 
 // Cache manages the index files.
+//
+// The indexes are loaded lazily: the lookups by name and repository and the searches use the compact files
+// stored next to the index files and decode only the entries they return, while Merged loads all of them.
 type Cache struct {
-	*index.MergedIndexes
 	fetcher          *fetch.Fetcher
 	localIndexes     *indexConf.Config
 	localIndexesFile string
 	indexesDir       string
+	// indexes are the cached indexes, in merge order: an entry of an index overrides the entries
+	// with the same name of the previous ones.
+	indexes []*cachedIndex
+	// merged are all the entries of the indexes, loaded on the first call to Merged.
+	merged *index.MergedIndexes
+	mu     sync.Mutex
 	// Track the new indexes that need to be saved locally when writing the cache to file.
 	fetchedIndexes []*index.Index
 	// Track the indexes that have been removed, needed when writing the cache to file.
 	removedIndexes []string
 }
 
-// New creates a new cache object. For each entry in the indexes.yaml file it loads the respective index file
+// cachedIndex is an index of the cache, whose entries are decoded from its compact representation
+// when requested.
+type cachedIndex struct {
+	name    string
+	compact *compactIndex
+	entries []*index.Entry
+}
+
+// New creates a new cache object. For each entry in the indexes.yaml file it opens the respective index file
 // found on the disk or fetches it if not found. If there is an entry in the indexes.yaml file but its index file does not exist on the disk
 // then it will error.
 func New(ctx context.Context, indexFile, indexesDir string) (*Cache, error) {
 	var err error
 	var idx *index.Index
+	var ci *cachedIndex
 
 	indexConfig, err := indexConf.New(indexFile)
 	if err != nil {
 		return nil, fmt.Errorf("an error occurred while loading index file %q from disk: %w", indexFile, err)
 	}
 
-	c := &Cache{
-		fetcher:          fetch.NewFetcher(),
-		localIndexes:     indexConfig,
-		localIndexesFile: indexFile,
-		indexesDir:       indexesDir,
-		MergedIndexes:    index.NewMergedIndexes(),
-	}
+	c := newCache(indexFile, indexesDir, indexConfig)
 
-	// Load existing indexes in memory and merge them.
+	// Open the existing indexes.
 	for _, cfg := range c.localIndexes.Configs {
-		// If the index is in the local persistent cache we just load it.
-		if idx, err = c.loadIndex(cfg.Name); err != nil && errors.Is(err, fs.ErrNotExist) {
+		// If the index is in the local persistent cache we just open it.
+		if ci, err = c.openIndex(cfg.Name); err != nil && errors.Is(err, fs.ErrNotExist) {
 			// If the index is not found in the local persistent cache we fetch it from the url.
 			ts := time.Now().Format(consts.TimeFormat)
 			if idx, err = c.fetcher.Fetch(ctx, cfg); err != nil {
@@ -80,11 +96,13 @@ func New(ctx context.Context, indexFile, indexesDir string) (*Cache, error) {
 			}
 			c.localIndexes.Upsert(cfg)
 			c.fetchedIndexes = append(c.fetchedIndexes, idx)
+			if ci, err = newCachedIndex(idx); err != nil {
+				return nil, err
+			}
 		} else if err != nil {
 			return nil, fmt.Errorf("an error occurred while loading cache from disk: %w", err)
 		}
-		// After a successful load/fetch we merge it.
-		c.Merge(idx)
+		c.indexes = append(c.indexes, ci)
 	}
 
 	return c, nil
@@ -95,26 +113,23 @@ func New(ctx context.Context, indexFile, indexesDir string) (*Cache, error) {
 func NewFromConfig(ctx context.Context, indexFile, indexesDir string, indexes []config.Index) (*Cache, error) {
 	var err error
 	var idx *index.Index
-	indexConfig := &indexConf.Config{}
+	var ci *cachedIndex
 
-	c := &Cache{
-		fetcher:          fetch.NewFetcher(),
-		localIndexes:     indexConfig,
-		localIndexesFile: indexFile,
-		indexesDir:       indexesDir,
-		MergedIndexes:    index.NewMergedIndexes(),
-	}
+	c := newCache(indexFile, indexesDir, &indexConf.Config{})
 
 	for i := range indexes {
 		cfg := &indexes[i]
-		// If the index is in the local persistent cache we just load it.
+		// If the index is in the local persistent cache we just open it.
 		ts := time.Now().Format(consts.TimeFormat)
-		if idx, err = c.loadIndex(cfg.Name); err != nil && errors.Is(err, fs.ErrNotExist) {
+		if ci, err = c.openIndex(cfg.Name); err != nil && errors.Is(err, fs.ErrNotExist) {
 			// If the index is not found in the local persistent cache we fetch it from the url.
 			if idx, err = c.fetcher.Fetch(ctx, indexConf.EntryFromIndex(cfg)); err != nil {
 				return nil, fmt.Errorf("unable to fetch index %q with URL %q: %w", cfg.Name, cfg.URL, err)
 			}
 			c.fetchedIndexes = append(c.fetchedIndexes, idx)
+			if ci, err = newCachedIndex(idx); err != nil {
+				return nil, err
+			}
 		} else if err != nil {
 			return nil, fmt.Errorf("an error occurred while loading cache from disk: %w", err)
 		}
@@ -124,13 +139,29 @@ func NewFromConfig(ctx context.Context, indexFile, indexesDir string, indexes []
 			UpdatedTimestamp: ts,
 			URL:              cfg.URL,
 		})
-		// After a successful load/fetch we merge it.
-		c.Merge(idx)
+		c.indexes = append(c.indexes, ci)
 	}
 
 	return c, nil
 }
 
+// NewFromMergedIndexes creates a new cache object serving the given merged indexes, which is not backed by
+// any file and cannot be written.
+func NewFromMergedIndexes(merged *index.MergedIndexes) *Cache {
+	c := newCache("", "", &indexConf.Config{})
+	c.merged = merged
+	return c
+}
+
+func newCache(indexFile, indexesDir string, indexConfig *indexConf.Config) *Cache {
+	return &Cache{
+		fetcher:          fetch.NewFetcher(),
+		localIndexes:     indexConfig,
+		localIndexesFile: indexFile,
+		indexesDir:       indexesDir,
+	}
+}
+
 // Add adds a new index file to the cache. If the index file already exists in the cache it
 // does nothing. On the other hand, it fetches the index file using the provided URL and adds
 // it to the in memory cache. It does not write it to the filesystem. It is idempotent.
@@ -156,6 +187,11 @@ func (c *Cache) Add(ctx context.Context, name, backend, url string) error {
 		return fmt.Errorf("unable to fetch index %q with URL %q: %w", name, url, err)
 	}
 
+	ci, err := newCachedIndex(remoteIndex)
+	if err != nil {
+		return err
+	}
+
 	// Keep track of the newly created index file.
 	ts := time.Now().Format(consts.TimeFormat)
 	entry = &indexConf.Entry{
@@ -170,7 +206,10 @@ func (c *Cache) Add(ctx context.Context, name, backend, url string) error {
 	// Save it for later write operation.
 	c.fetchedIndexes = append(c.fetchedIndexes, remoteIndex)
 
-	c.Merge(remoteIndex)
+	c.mu.Lock()
+	c.indexes = append(c.indexes, ci)
+	c.merged = nil
+	c.mu.Unlock()
 
 	// If the index has been removed before we make sure to delete it from the removedIndexes array.
 	for i, idxName := range c.removedIndexes {
@@ -184,31 +223,23 @@ func (c *Cache) Add(ctx context.Context, name, backend, url string) error {
 
 // Remove removes an index file from the cache if it exists.
 func (c *Cache) Remove(name string) error {
-	var idx *index.Index
-	var err error
-	newMergedIndex := index.NewMergedIndexes()
 	// Check if the index is in the local cache.
 	entry := c.localIndexes.Get(name)
 	if entry == nil {
 		return nil
 	}
 
-	// Create a new merged indexes without the one we are removing.
-	for _, cfg := range c.localIndexes.Configs {
-		if cfg.Name != name {
-			if idx, err = c.loadIndex(cfg.Name); err != nil && errors.Is(err, fs.ErrNotExist) {
-				idx = findIndexInSlice(c.fetchedIndexes, cfg.Name)
-				if idx == nil {
-					return fmt.Errorf("index %q not found in the local persisten storage neither in the fetched indexes", cfg.Name)
-				}
-			} else if err != nil {
-				return err
-			}
-			newMergedIndex.Merge(idx)
+	// Drop the index from the merged ones.
+	c.mu.Lock()
+	for i, ci := range c.indexes {
+		if ci.name == name {
+			c.indexes = append(c.indexes[:i], c.indexes[i+1:]...)
+			break
 		}
 	}
+	c.merged = nil
+	c.mu.Unlock()
 
-	c.MergedIndexes = newMergedIndex
 	c.removedIndexes = append(c.removedIndexes, name)
 
 	// Remove the index from the indexes list.
@@ -228,13 +259,10 @@ func (c *Cache) Remove(name string) error {
 // given index. The new content is kept in memory, it does not overwrite the existing index file
 // on the disk.
 func (c *Cache) Update(ctx context.Context, name string) error {
-	var idx *index.Index
-	var err error
-	newMergedIndex := index.NewMergedIndexes()
 	// Check if the entry exists.
 	entry := c.localIndexes.Get(name)
 	if entry == nil {
-		return fmt.Errorf("unable to update index %s: not found in the cache, please make sure to add it before updating", name)
+		return errdefs.Errorf(errdefs.ErrNotFound, "unable to update index %s: not found in the cache, please make sure to add it before updating", name)
 	}
 
 	ts := time.Now().Format(consts.TimeFormat)
@@ -244,6 +272,11 @@ func (c *Cache) Update(ctx context.Context, name string) error {
 		return fmt.Errorf("unable to fetch index %q with URL %q: %w", name, entry.URL, err)
 	}
 
+	ci, err := newCachedIndex(updatedIndex)
+	if err != nil {
+		return err
+	}
+
 	// Update the existing index entry by setting the new timestamp.
 	entry.UpdatedTimestamp = ts
 	c.localIndexes.Upsert(entry)
@@ -251,50 +284,59 @@ func (c *Cache) Update(ctx context.Context, name string) error {
 	// Track the new fetched index for writing purposes.
 	c.fetchedIndexes = append(c.fetchedIndexes, updatedIndex)
 
-	// Create a new merged indexes without the one we are removing.
-	for _, cfg := range c.localIndexes.Configs {
-		if cfg.Name != name {
-			if idx, err = c.loadIndex(cfg.Name); err != nil && errors.Is(err, fs.ErrNotExist) {
-				idx = findIndexInSlice(c.fetchedIndexes, cfg.Name)
-				if idx == nil {
-					return fmt.Errorf("index %q not found in the local persisten storage neither in the fetched indexes", cfg.Name)
-				}
-			} else if err != nil {
-				return err
-			}
-			newMergedIndex.Merge(idx)
-		} else {
-			newMergedIndex.Merge(updatedIndex)
+	// Replace the index with the updated one.
+	c.mu.Lock()
+	for i := range c.indexes {
+		if c.indexes[i].name == name {
+			c.indexes[i] = ci
 		}
 	}
+	c.merged = nil
+	c.mu.Unlock()
 
 	return nil
 }
 
 // Write dumps the in-memory cache to disk. Based on the cache operations it does different things.
 // Add: a new entry is added to the config.IndexesFile and the fetched index file is saved under the
-// config.IndexesDir.
-// Remove: the removed entry is wiped out from the config.IndexesFile and the related index file is deleted.
-// Update: the entry in the config.IndexesFile for the updated index is updated. The related index file is
-// replaced by the new content fetched by the update operation.
+// config.IndexesDir, along with its compact file.
+// Remove: the removed entry is wiped out from the config.IndexesFile and the related index and compact files are deleted.
+// Update: the entry in the config.IndexesFile for the updated index is updated. The related index and compact
+// files are replaced by the new content fetched by the update operation.
 // Returns the indexConf.Config written to the config.IndexesFile.
 func (c *Cache) Write() (*indexConf.Config, error) {
 	for _, idx := range c.fetchedIndexes {
-		indexFileName := fmt.Sprintf("%s%s", idx.Name, ".yaml")
-		indexPath := filepath.Join(c.indexesDir, indexFileName)
+		indexPath := c.indexPath(idx.Name, ".yaml")
 
 		// Save the new index.
 		if err := idx.Write(indexPath); err != nil {
 			return nil, fmt.Errorf("an error occurred while writing index %q to file %q: %w", idx.Name, indexPath, err)
 		}
+
+		// Save its compact representation, built from the normalized entries.
+		source, err := os.ReadFile(filepath.Clean(indexPath))
+		if err != nil {
+			return nil, err
+		}
+		compact, err := newCompactIndex(idx.Entries)
+		if err != nil {
+			return nil, err
+		}
+		compactPath := c.indexPath(idx.Name, CompactFileExt)
+		if err := compact.write(compactPath, source); err != nil {
+			return nil, fmt.Errorf("an error occurred while writing compact index %q to file %q: %w", idx.Name, compactPath, err)
+		}
 	}
 
 	for _, name := range c.removedIndexes {
-		indexFileName := fmt.Sprintf("%s%s", name, ".yaml")
-		indexPath := filepath.Join(c.indexesDir, indexFileName)
+		indexPath := c.indexPath(name, ".yaml")
 		if err := os.Remove(indexPath); err != nil {
 			return nil, fmt.Errorf("an error occurred while removeing index %q from %q: %w", name, indexPath, err)
 		}
+		compactPath := c.indexPath(name, CompactFileExt)
+		if err := os.Remove(compactPath); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("an error occurred while removeing compact index %q from %q: %w", name, compactPath, err)
+		}
 		c.localIndexes.Remove(name)
 	}
 
@@ -305,25 +347,340 @@ func (c *Cache) Write() (*indexConf.Config, error) {
 	return c.localIndexes, nil
 }
 
-func (c *Cache) loadIndex(name string) (*index.Index, error) {
-	indexFileName := fmt.Sprintf("%s%s", name, ".yaml")
+// Merged returns all the entries of the indexes, merged. It decodes all of them: the lookups and the searches
+// should rather use the methods of the cache.
+func (c *Cache) Merged() (*index.MergedIndexes, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
 
-	idx := index.New(name)
-	indexPath := filepath.Join(c.indexesDir, indexFileName)
-	err := idx.Read(indexPath)
+	if c.merged != nil {
+		return c.merged, nil
+	}
+
+	merged := index.NewMergedIndexes()
+	for _, ci := range c.indexes {
+		idx := index.New(ci.name)
+		for i := range ci.compact.Names {
+			entry, err := c.entry(ci, i)
+			if err != nil {
+				return nil, err
+			}
+			idx.Upsert(entry)
+		}
+		merged.Merge(idx)
+	}
+	c.merged = merged
+
+	return merged, nil
+}
+
+// EntryByName returns the entry with the given name.
+func (c *Cache) EntryByName(name string) (*index.Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.merged != nil {
+		return c.merged.EntryByName(name)
+	}
+
+	ci, pos, ok := c.lookup(name)
+	if !ok {
+		return nil, false
+	}
+	entry, err := c.entry(ci, pos)
 	if err != nil {
-		return nil, fmt.Errorf("an error occurred while loading index %q from file %q: %w", name, indexPath, err)
+		return nil, false
+	}
+	return entry, true
+}
+
+// EntryByRepository returns the entry of the given registry and repository. If several entries match, it
+// returns the first one in the merge order, as MergedIndexes.EntryByRepository.
+func (c *Cache) EntryByRepository(reg, repo string) (*index.Entry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.merged != nil {
+		return c.merged.EntryByRepository(reg, repo)
+	}
+
+	var found *index.Entry
+	var foundOrder [2]int
+	for _, ci := range c.indexes {
+		for _, pos := range ci.compact.byRepository[reg+"/"+repo] {
+			name := ci.compact.Names[pos]
+			// Only the last entry with a name is merged.
+			owner, ownerPos, _ := c.lookup(name)
+			if owner.compact.Repositories[ownerPos] != reg+"/"+repo {
+				continue
+			}
+			order := c.mergeOrder(name)
+			if found != nil && (order[0] > foundOrder[0] || (order[0] == foundOrder[0] && order[1] >= foundOrder[1])) {
+				continue
+			}
+			entry, err := c.entry(owner, ownerPos)
+			if err != nil {
+				continue
+			}
+			found, foundOrder = entry, order
+		}
 	}
 
-	return idx, nil
+	return found, found != nil
 }
 
-func findIndexInSlice(indexes []*index.Index, name string) *index.Index {
-	for _, idx := range indexes {
-		if idx.Name == name {
-			return idx
+// EntriesByPrefix returns the entries whose name starts with the given prefix, sorted by name.
+func (c *Cache) EntriesByPrefix(prefix string) []*index.Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var entries []*index.Entry
+	if c.merged != nil {
+		for _, entry := range c.merged.Entries {
+			if strings.HasPrefix(entry.Name, prefix) {
+				entries = append(entries, entry)
+			}
+		}
+	} else {
+		seen := map[string]struct{}{}
+		for _, ci := range c.indexes {
+			for _, pos := range ci.compact.withPrefix(prefix) {
+				name := ci.compact.Names[pos]
+				if _, ok := seen[name]; ok {
+					continue
+				}
+				seen[name] = struct{}{}
+				owner, ownerPos, _ := c.lookup(name)
+				if entry, err := c.entry(owner, ownerPos); err == nil {
+					entries = append(entries, entry)
+				}
+			}
 		}
 	}
 
-	return nil
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+	return entries
+}
+
+// SearchByKeywords returns the entries matching the given keywords, with the same semantics of
+// index.Index.SearchByKeywords. Only the names and keywords of the entries are compared, using the
+// trigrams of the compact files, and only the matching entries are decoded.
+func (c *Cache) SearchByKeywords(minScore float64, keywords ...string) []*index.Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.merged != nil {
+		return c.merged.SearchByKeywords(minScore, keywords...)
+	}
+
+	var result []*index.Entry
+	for i, ci := range c.indexes {
+		for _, pos := range searchCandidates(ci.compact, minScore, keywords) {
+			name := ci.compact.Names[pos]
+			// Skip the entries overridden by the next indexes.
+			if c.overridden(name, i) {
+				continue
+			}
+			for _, keyword := range keywords {
+				if index.MatchKeyword(minScore, name, ci.compact.Keywords[pos], keyword) {
+					if entry, err := c.entry(ci, pos); err == nil {
+						result = append(result, entry)
+					}
+					break
+				}
+			}
+		}
+	}
+
+	return result
+}
+
+// EntriesWithAdvisories returns the entries listing advisories.
+func (c *Cache) EntriesWithAdvisories() []*index.Entry {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var entries []*index.Entry
+	if c.merged != nil {
+		for _, entry := range c.merged.Entries {
+			if len(entry.Advisories) > 0 {
+				entries = append(entries, entry)
+			}
+		}
+		return entries
+	}
+
+	for i, ci := range c.indexes {
+		for _, pos := range ci.compact.Advisories {
+			if c.overridden(ci.compact.Names[pos], i) {
+				continue
+			}
+			if entry, err := c.entry(ci, int(pos)); err == nil {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
+
+// IndexName returns the name of the index of an entry returned by the cache.
+func (c *Cache) IndexName(entry *index.Entry) string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, ci := range c.indexes {
+		if pos, ok := ci.compact.byName[entry.Name]; ok && ci.entries[pos] == entry {
+			return ci.name
+		}
+	}
+	if c.merged != nil {
+		if idx := c.merged.IndexByEntry(entry); idx != nil {
+			return idx.Name
+		}
+	}
+	return ""
+}
+
+// ResolveReference resolves the name of an artifact to a reference, see index.ResolveReference.
+func (c *Cache) ResolveReference(name string) (string, error) {
+	return index.ResolveReference(c, name)
+}
+
+// SignatureForIndexRef returns the signature of the entry with the given name, see index.SignatureForIndexRef.
+func (c *Cache) SignatureForIndexRef(name string) *index.Signature {
+	return index.SignatureForIndexRef(c, name)
+}
+
+// VersionForRef returns the version listed by the indexes for a reference, see index.VersionForRef.
+func (c *Cache) VersionForRef(ref string) (*index.Version, bool) {
+	return index.VersionForRef(c, ref)
+}
+
+// NewerVersionsForRef returns the versions listed by the indexes newer than the tag of a reference,
+// see index.NewerVersionsForRef.
+func (c *Cache) NewerVersionsForRef(ref string) []index.Version {
+	return index.NewerVersionsForRef(c, ref)
+}
+
+// searchCandidates returns the sorted positions of the entries of an index that may match one of the keywords.
+func searchCandidates(compact *compactIndex, minScore float64, keywords []string) []int {
+	candidates := map[int]struct{}{}
+	for _, keyword := range keywords {
+		lower := strings.ToLower(keyword)
+		// The names close enough to the keyword: the edit distance is at least the difference of the lengths.
+		for pos, name := range compact.Names {
+			nameLen := len(strings.ToLower(name))
+			longer := max(nameLen, len(lower))
+			if float64(longer-min(nameLen, len(lower))) <= float64(longer)*(1-minScore) {
+				candidates[pos] = struct{}{}
+			}
+		}
+		// The names and keywords containing the keyword.
+		if len(keyword) < 3 {
+			for pos := range compact.Names {
+				candidates[pos] = struct{}{}
+			}
+			continue
+		}
+		for pos := range compact.containing(lower) {
+			candidates[pos] = struct{}{}
+		}
+	}
+
+	positions := make([]int, 0, len(candidates))
+	for pos := range candidates {
+		positions = append(positions, pos)
+	}
+	sort.Ints(positions)
+	return positions
+}
+
+// lookup returns the index and the position of the merged entry with the given name: the one of the last
+// index having it. The lock must be held.
+func (c *Cache) lookup(name string) (*cachedIndex, int, bool) {
+	for i := len(c.indexes) - 1; i >= 0; i-- {
+		if pos, ok := c.indexes[i].compact.byName[name]; ok {
+			return c.indexes[i], pos, true
+		}
+	}
+	return nil, 0, false
+}
+
+// overridden returns true if an index after the i-th one has an entry with the given name. The lock must be held.
+func (c *Cache) overridden(name string, i int) bool {
+	for _, ci := range c.indexes[i+1:] {
+		if _, ok := ci.compact.byName[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// mergeOrder returns the position of the entry with the given name among the merged entries, as the index
+// and the position in it where the name first appears. The lock must be held.
+func (c *Cache) mergeOrder(name string) [2]int {
+	for i, ci := range c.indexes {
+		if pos, ok := ci.compact.byName[name]; ok {
+			return [2]int{i, pos}
+		}
+	}
+	return [2]int{len(c.indexes), 0}
+}
+
+// entry returns the entry at the given position of an index, decoding it once. The lock must be held.
+func (c *Cache) entry(ci *cachedIndex, pos int) (*index.Entry, error) {
+	if ci.entries[pos] != nil {
+		return ci.entries[pos], nil
+	}
+	entry, err := ci.compact.decode(pos)
+	if err != nil {
+		return nil, fmt.Errorf("an error occurred while loading index %q: %w", ci.name, err)
+	}
+	ci.entries[pos] = entry
+	return entry, nil
+}
+
+// openIndex opens an index of the local persistent cache using its compact file. If the compact file is missing
+// or does not match the index file, which is the source of truth, the index file is loaded instead and the
+// compact file rebuilt.
+func (c *Cache) openIndex(name string) (*cachedIndex, error) {
+	indexPath := c.indexPath(name, ".yaml")
+	source, err := os.ReadFile(filepath.Clean(indexPath))
+	if err != nil {
+		return nil, fmt.Errorf("an error occurred while loading index %q from file %q: cannot read index from file: %w", name, indexPath, err)
+	}
+
+	compactPath := c.indexPath(name, CompactFileExt)
+	compact, err := readCompactIndex(compactPath, source)
+	if err == nil {
+		return &cachedIndex{name: name, compact: compact, entries: make([]*index.Entry, len(compact.Names))}, nil
+	}
+
+	idx := index.New(name)
+	if err := idx.ReadBytes(source); err != nil {
+		return nil, fmt.Errorf("an error occurred while loading index %q from file %q: %w", name, indexPath, err)
+	}
+	ci, err := newCachedIndex(idx)
+	if err != nil {
+		return nil, err
+	}
+	// The compact file is only an optimization: failing to write it, e.g. in a read-only directory, is not an error.
+	_ = ci.compact.write(compactPath, source)
+
+	return ci, nil
+}
+
+// newCachedIndex returns a cached index for an index loaded in memory, whose entries are already decoded.
+func newCachedIndex(idx *index.Index) (*cachedIndex, error) {
+	compact, err := newCompactIndex(idx.Entries)
+	if err != nil {
+		return nil, fmt.Errorf("an error occurred while building the compact representation of index %q: %w", idx.Name, err)
+	}
+	return &cachedIndex{name: idx.Name, compact: compact, entries: slices.Clone(idx.Entries)}, nil
+}
+
+func (c *Cache) indexPath(name, ext string) string {
+	return filepath.Join(c.indexesDir, name+ext)
 }
@@ -0,0 +1,286 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+)
+
+func testEntries(prefix string, n int) []*index.Entry {
+	entries := make([]*index.Entry, 0, n)
+	for i := 0; i < n; i++ {
+		e := &index.Entry{
+			Name:       fmt.Sprintf("%s-%03d", prefix, i),
+			Type:       "rulesfile",
+			Registry:   "ghcr.io",
+			Repository: fmt.Sprintf("falcosecurity/%s/%03d", prefix, i),
+			Keywords:   []string{fmt.Sprintf("Keyword%d", i%7), "falco"},
+			Versions:   []index.Version{{Version: "1.0.0", Digest: "sha256:0123"}},
+		}
+		if i%10 == 0 {
+			e.Advisories = []index.Advisory{{ID: fmt.Sprintf("ADV-%d", i), Affected: "<1.0.1", Severity: index.SeverityHigh}}
+		}
+		entries = append(entries, e)
+	}
+	return entries
+}
+
+// writeIndexes writes the indexes in dir and returns their configuration, with file URLs.
+func writeIndexes(t *testing.T, dir string, indexes ...*index.Index) []config.Index {
+	t.Helper()
+	var conf []config.Index
+	for _, idx := range indexes {
+		path := filepath.Join(dir, "remote", idx.Name+".yaml")
+		require.NoError(t, idx.Write(path))
+		conf = append(conf, config.Index{Name: idx.Name, URL: "file://" + path})
+	}
+	return conf
+}
+
+func newIndex(name string, entries ...*index.Entry) *index.Index {
+	idx := index.New(name)
+	for _, e := range entries {
+		idx.Upsert(e)
+	}
+	return idx
+}
+
+func names(entries []*index.Entry) []string {
+	result := make([]string, 0, len(entries))
+	for _, e := range entries {
+		result = append(result, e.Name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// TestCompactMatchesYAML checks that the lookups using the compact files give the same results as the
+// merged YAML indexes.
+func TestCompactMatchesYAML(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	indexesDir := filepath.Join(dir, "indexes")
+
+	first := newIndex("first", testEntries("rules", 50)...)
+	// The second index overrides some entries of the first one, and moves one of them to another repository.
+	overrides := testEntries("rules", 5)
+	overrides[3].Repository = "falcosecurity/moved"
+	overrides[3].Keywords = []string{"Overridden"}
+	second := newIndex("second", append(overrides, testEntries("plugin", 30)...)...)
+	conf := writeIndexes(t, dir, first, second)
+
+	// The first cache fetches the indexes and writes them along with their compact files.
+	c, err := NewFromConfig(ctx, filepath.Join(dir, "indexes.yaml"), indexesDir, conf)
+	require.NoError(t, err)
+	_, err = c.Write()
+	require.NoError(t, err)
+	require.FileExists(t, filepath.Join(indexesDir, "first"+CompactFileExt))
+	require.FileExists(t, filepath.Join(indexesDir, "second"+CompactFileExt))
+
+	c, err = NewFromConfig(ctx, filepath.Join(dir, "indexes.yaml"), indexesDir, conf)
+	require.NoError(t, err)
+
+	expected := index.NewMergedIndexes()
+	for _, name := range []string{"first", "second"} {
+		idx := index.New(name)
+		require.NoError(t, idx.Read(filepath.Join(indexesDir, name+".yaml")))
+		expected.Merge(idx)
+	}
+
+	for _, e := range expected.Entries {
+		actual, ok := c.EntryByName(e.Name)
+		require.True(t, ok, e.Name)
+		assert.Equal(t, e, actual)
+		assert.Equal(t, expected.IndexByEntry(e).Name, c.IndexName(actual))
+
+		byRepo, ok := c.EntryByRepository(e.Registry, e.Repository)
+		expectedByRepo, expectedOk := expected.EntryByRepository(e.Registry, e.Repository)
+		assert.Equal(t, expectedOk, ok)
+		assert.Equal(t, expectedByRepo, byRepo)
+	}
+	_, ok := c.EntryByName("missing")
+	assert.False(t, ok)
+	// The overridden repository is not listed anymore.
+	_, ok = c.EntryByRepository("ghcr.io", "falcosecurity/rules/003")
+	assert.False(t, ok)
+
+	for _, keywords := range [][]string{{"rules-010"}, {"rules-01"}, {"PLUGIN-02"}, {"ru"}, {"Keyword3"}, {"keyword3"}, {"KEYWORD3"},
+		{"Overridden"}, {"rules-003", "plugin-001"}, {"nothing"}, {"a"}} {
+		for _, minScore := range []float64{0.5, 0.65, 1} {
+			assert.Equal(t, names(expected.SearchByKeywords(minScore, keywords...)), names(c.SearchByKeywords(minScore, keywords...)),
+				"keywords %v, min score %v", keywords, minScore)
+		}
+	}
+
+	var withAdvisories []*index.Entry
+	for _, e := range expected.Entries {
+		if len(e.Advisories) > 0 {
+			withAdvisories = append(withAdvisories, e)
+		}
+	}
+	assert.Equal(t, names(withAdvisories), names(c.EntriesWithAdvisories()))
+
+	assert.Equal(t, []string{"plugin-010", "plugin-011", "plugin-012"}, names(c.EntriesByPrefix("plugin-01")[:3]))
+	assert.Len(t, c.EntriesByPrefix("rules-"), 50)
+	assert.Empty(t, c.EntriesByPrefix("zzz"))
+
+	ref, err := c.ResolveReference("rules-003:1.0.0")
+	require.NoError(t, err)
+	assert.Equal(t, "ghcr.io/falcosecurity/moved:1.0.0", ref)
+	version, ok := c.VersionForRef("ghcr.io/falcosecurity/moved:1.0.0")
+	require.True(t, ok)
+	assert.Equal(t, "sha256:0123", version.Digest)
+
+	merged, err := c.Merged()
+	require.NoError(t, err)
+	assert.Equal(t, names(expected.Entries), names(merged.Entries))
+	// Once merged, the same entries are returned.
+	entry, _ := c.EntryByName("rules-003")
+	mergedEntry, _ := merged.EntryByName("rules-003")
+	assert.Same(t, entry, mergedEntry)
+}
+
+// TestSearchOrder checks that the search results are returned in the order of the indexes and of their entries,
+// matching the keywords regardless of their case.
+func TestSearchOrder(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	indexesDir := filepath.Join(dir, "indexes")
+	first := newIndex("first", testEntries("rules", 20)...)
+	second := newIndex("second", testEntries("plugin", 10)...)
+	conf := writeIndexes(t, dir, first, second)
+
+	c, err := NewFromConfig(ctx, filepath.Join(dir, "indexes.yaml"), indexesDir, conf)
+	require.NoError(t, err)
+	_, err = c.Write()
+	require.NoError(t, err)
+
+	var expected []string
+	for _, e := range append(first.Entries, second.Entries...) {
+		if e.Keywords[0] == "Keyword3" {
+			expected = append(expected, e.Name)
+		}
+	}
+	require.NotEmpty(t, expected)
+
+	for i := 0; i < 5; i++ {
+		c, err = NewFromConfig(ctx, filepath.Join(dir, "indexes.yaml"), indexesDir, conf)
+		require.NoError(t, err)
+		var actual []string
+		for _, e := range c.SearchByKeywords(1, "keyword3") {
+			actual = append(actual, e.Name)
+		}
+		assert.Equal(t, expected, actual)
+	}
+}
+
+// TestLazyLoading checks that a lookup decodes only the entry it returns.
+func TestLazyLoading(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	indexesDir := filepath.Join(dir, "indexes")
+	conf := writeIndexes(t, dir, newIndex("idx", testEntries("rules", 100)...))
+
+	c, err := NewFromConfig(ctx, filepath.Join(dir, "indexes.yaml"), indexesDir, conf)
+	require.NoError(t, err)
+	_, err = c.Write()
+	require.NoError(t, err)
+
+	c, err = NewFromConfig(ctx, filepath.Join(dir, "indexes.yaml"), indexesDir, conf)
+	require.NoError(t, err)
+	decoded := func() int {
+		n := 0
+		for _, e := range c.indexes[0].entries {
+			if e != nil {
+				n++
+			}
+		}
+		return n
+	}
+	assert.Equal(t, 0, decoded())
+
+	_, ok := c.EntryByName("rules-042")
+	require.True(t, ok)
+	assert.Equal(t, 1, decoded())
+
+	assert.Len(t, c.SearchByKeywords(1, "rules-050"), 1)
+	assert.Equal(t, 2, decoded())
+}
+
+// TestCompactValidation checks that the index files remain the source of truth.
+func TestCompactValidation(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	indexesDir := filepath.Join(dir, "indexes")
+	conf := writeIndexes(t, dir, newIndex("idx", testEntries("rules", 10)...))
+
+	c, err := NewFromConfig(ctx, filepath.Join(dir, "indexes.yaml"), indexesDir, conf)
+	require.NoError(t, err)
+	_, err = c.Write()
+	require.NoError(t, err)
+	compactPath := filepath.Join(indexesDir, "idx"+CompactFileExt)
+
+	t.Run("out of date", func(t *testing.T) {
+		// The index file is modified behind the back of the cache.
+		require.NoError(t, newIndex("idx", testEntries("other", 3)...).Write(filepath.Join(indexesDir, "idx.yaml")))
+
+		c, err := NewFromConfig(ctx, filepath.Join(dir, "indexes.yaml"), indexesDir, conf)
+		require.NoError(t, err)
+		_, ok := c.EntryByName("rules-001")
+		assert.False(t, ok)
+		_, ok = c.EntryByName("other-001")
+		assert.True(t, ok)
+
+		// The compact file has been rebuilt.
+		source, err := os.ReadFile(filepath.Join(indexesDir, "idx.yaml"))
+		require.NoError(t, err)
+		compact, err := readCompactIndex(compactPath, source)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"other-000", "other-001", "other-002"}, compact.Names)
+	})
+
+	t.Run("corrupted", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(compactPath, []byte("garbage"), 0o600))
+
+		c, err := NewFromConfig(ctx, filepath.Join(dir, "indexes.yaml"), indexesDir, conf)
+		require.NoError(t, err)
+		_, ok := c.EntryByName("other-002")
+		assert.True(t, ok)
+	})
+
+	t.Run("removed", func(t *testing.T) {
+		c, err := New(ctx, filepath.Join(dir, "indexes.yaml"), indexesDir)
+		require.NoError(t, err)
+		require.NoError(t, c.Remove("idx"))
+		_, ok := c.EntryByName("other-002")
+		assert.False(t, ok)
+		_, err = c.Write()
+		require.NoError(t, err)
+		assert.NoFileExists(t, filepath.Join(indexesDir, "idx.yaml"))
+		assert.NoFileExists(t, compactPath)
+	})
+}
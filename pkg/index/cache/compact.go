@@ -0,0 +1,230 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cache
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/falcosecurity/falcoctl/pkg/index/config"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+)
+
+const (
+	// CompactFileExt is the extension of the compact files written next to the YAML index files.
+	CompactFileExt = ".idx"
+	// compactFormat is the version of the format of the compact files. Files of other versions are rebuilt.
+	compactFormat = 2
+)
+
+// compactIndex is the pre-built representation of an index file, stored next to it. It holds what is needed
+// to look the entries up and search them, while the entries themselves are decoded only when requested.
+// The YAML index file remains the source of truth: the compact file records its sha256 and is rebuilt
+// when it does not match.
+type compactIndex struct {
+	Format int
+	// Source is the sha256 of the YAML index file the compact file was built from.
+	Source [sha256.Size]byte
+	// Names, Repositories and Keywords are the names, the "registry/repository" and the space separated
+	// keywords of the entries, in the order of the index file.
+	Names        []string
+	Repositories []string
+	Keywords     []string
+	// Advisories are the positions of the entries listing advisories.
+	Advisories []int32
+	// Sorted are the positions of the entries sorted by name, for prefix lookups.
+	Sorted []int32
+	// Trigrams maps the trigrams of the lowercased names, and of the keywords, to the positions of the
+	// entries containing them.
+	Trigrams map[string][]int32
+	// Offsets delimit the JSON encoding of each entry in Data: entry i is Data[Offsets[i]:Offsets[i+1]].
+	Offsets []int32
+	Data    []byte
+
+	byName       map[string]int
+	byRepository map[string][]int
+}
+
+// newCompactIndex builds the compact representation of the given entries.
+func newCompactIndex(entries []*index.Entry) (*compactIndex, error) {
+	c := &compactIndex{
+		Format:       compactFormat,
+		Names:        make([]string, len(entries)),
+		Repositories: make([]string, len(entries)),
+		Keywords:     make([]string, len(entries)),
+		Sorted:       make([]int32, len(entries)),
+		Trigrams:     map[string][]int32{},
+		Offsets:      make([]int32, 0, len(entries)+1),
+	}
+
+	var data bytes.Buffer
+	for i, e := range entries {
+		c.Names[i] = e.Name
+		c.Repositories[i] = e.Registry + "/" + e.Repository
+		c.Keywords[i] = strings.Join(e.Keywords, " ")
+		c.Sorted[i] = int32(i) //nolint:gosec // the number of entries fits in an int32
+		if len(e.Advisories) > 0 {
+			c.Advisories = append(c.Advisories, int32(i)) //nolint:gosec // the number of entries fits in an int32
+		}
+		for _, t := range trigramsOf(strings.ToLower(e.Name), strings.ToLower(c.Keywords[i])) {
+			c.Trigrams[t] = append(c.Trigrams[t], int32(i)) //nolint:gosec // the number of entries fits in an int32
+		}
+
+		c.Offsets = append(c.Offsets, int32(data.Len())) //nolint:gosec // index files are smaller than 2GiB
+		if err := json.NewEncoder(&data).Encode(e); err != nil {
+			return nil, fmt.Errorf("cannot encode entry %q: %w", e.Name, err)
+		}
+	}
+	c.Offsets = append(c.Offsets, int32(data.Len())) //nolint:gosec // index files are smaller than 2GiB
+	c.Data = data.Bytes()
+
+	sort.SliceStable(c.Sorted, func(i, j int) bool {
+		return c.Names[c.Sorted[i]] < c.Names[c.Sorted[j]]
+	})
+
+	c.init()
+	return c, nil
+}
+
+// readCompactIndex reads the compact file at path, and returns an error if it was not built from source.
+func readCompactIndex(path string, source []byte) (*compactIndex, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+
+	c := &compactIndex{}
+	if err := gob.NewDecoder(bytes.NewReader(data)).Decode(c); err != nil {
+		return nil, fmt.Errorf("cannot decode compact index %q: %w", path, err)
+	}
+	if c.Format != compactFormat {
+		return nil, fmt.Errorf("compact index %q has format %d, expected %d", path, c.Format, compactFormat)
+	}
+	if c.Source != sha256.Sum256(source) {
+		return nil, fmt.Errorf("compact index %q is out of date", path)
+	}
+	if len(c.Repositories) != len(c.Names) || len(c.Keywords) != len(c.Names) ||
+		len(c.Sorted) != len(c.Names) || len(c.Offsets) != len(c.Names)+1 {
+		return nil, fmt.Errorf("compact index %q is corrupted", path)
+	}
+
+	c.init()
+	return c, nil
+}
+
+// write writes the compact index to path, recording the sha256 of the YAML index file it describes.
+// The file is renamed into place so that concurrent readers never see a partial file.
+func (c *compactIndex) write(path string, source []byte) error {
+	c.Source = sha256.Sum256(source)
+
+	var data bytes.Buffer
+	if err := gob.NewEncoder(&data).Encode(c); err != nil {
+		return fmt.Errorf("cannot encode compact index: %w", err)
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data.Bytes()); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), config.DefaultFilePermissions); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (c *compactIndex) init() {
+	c.byName = make(map[string]int, len(c.Names))
+	c.byRepository = make(map[string][]int, len(c.Names))
+	for i, name := range c.Names {
+		c.byName[name] = i
+		c.byRepository[c.Repositories[i]] = append(c.byRepository[c.Repositories[i]], i)
+	}
+}
+
+// decode decodes the entry at position i.
+func (c *compactIndex) decode(i int) (*index.Entry, error) {
+	entry := &index.Entry{}
+	if err := json.Unmarshal(c.Data[c.Offsets[i]:c.Offsets[i+1]], entry); err != nil {
+		return nil, fmt.Errorf("cannot decode entry %q: %w", c.Names[i], err)
+	}
+	return entry, nil
+}
+
+// withPrefix returns the positions of the entries whose name starts with prefix, sorted by name.
+func (c *compactIndex) withPrefix(prefix string) []int {
+	start := sort.Search(len(c.Sorted), func(i int) bool {
+		return c.Names[c.Sorted[i]] >= prefix
+	})
+
+	var positions []int
+	for _, p := range c.Sorted[start:] {
+		if !strings.HasPrefix(c.Names[p], prefix) {
+			break
+		}
+		positions = append(positions, int(p))
+	}
+	return positions
+}
+
+// containing returns the positions of the entries whose lowercased name or keywords may contain the
+// given string, that must be at least three bytes long. Returns every entry having all its trigrams.
+func (c *compactIndex) containing(s string) map[int]struct{} {
+	var candidates map[int]struct{}
+	for _, t := range trigramsOf(s) {
+		next := map[int]struct{}{}
+		for _, p := range c.Trigrams[t] {
+			if _, ok := candidates[int(p)]; ok || candidates == nil {
+				next[int(p)] = struct{}{}
+			}
+		}
+		candidates = next
+		if len(candidates) == 0 {
+			break
+		}
+	}
+	return candidates
+}
+
+// trigramsOf returns the distinct trigrams of the given strings.
+func trigramsOf(strs ...string) []string {
+	seen := map[string]struct{}{}
+	var trigrams []string
+	for _, s := range strs {
+		for i := 0; i+3 <= len(s); i++ {
+			t := s[i : i+3]
+			if _, ok := seen[t]; !ok {
+				seen[t] = struct{}{}
+				trigrams = append(trigrams, t)
+			}
+		}
+	}
+	return trigrams
+}
@@ -21,12 +21,17 @@ import (
 	"net/url"
 	"strings"
 
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 	"github.com/falcosecurity/falcoctl/pkg/index/config"
 	"github.com/falcosecurity/falcoctl/pkg/index/fetch/file"
 	"github.com/falcosecurity/falcoctl/pkg/index/fetch/gcs"
 	"github.com/falcosecurity/falcoctl/pkg/index/fetch/http"
 	"github.com/falcosecurity/falcoctl/pkg/index/fetch/s3"
 	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
+	"github.com/falcosecurity/falcoctl/pkg/tracing"
 )
 
 // Func is a prototype for fetching indices for a specific index backend.
@@ -71,6 +76,26 @@ func (f *Fetcher) get(backend string) (Func, error) {
 
 // Fetch retrieves a remote index.
 func (f *Fetcher) Fetch(ctx context.Context, conf *config.Entry) (*index.Index, error) {
+	bytes, err := f.FetchBytes(ctx, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	i := index.New(conf.Name)
+	err = i.ReadBytes(bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	return i, nil
+}
+
+// FetchBytes retrieves the raw content of a remote source through the index backends,
+// e.g. an index or an advisory feed.
+func (f *Fetcher) FetchBytes(ctx context.Context, conf *config.Entry) (_ []byte, err error) {
+	ctx, span := tracing.Start(ctx, "fetch.FetchBytes", attribute.String("name", conf.Name), attribute.String("url", conf.URL))
+	defer func() { tracing.End(span, err) }()
+
 	// if we don't have an explicit backend
 	// we try to guess based on the URI scheme
 	if conf.Backend == "" {
@@ -87,17 +112,16 @@ func (f *Fetcher) Fetch(ctx context.Context, conf *config.Entry) (*index.Index,
 	if err != nil {
 		return nil, err
 	}
+	span.SetAttributes(attribute.String("backend", conf.Backend))
 
-	bytes, err := fetcher(ctx, conf)
-	if err != nil {
-		return nil, fmt.Errorf("unable to fetch index: %w", err)
+	// Only the file backend reads local sources.
+	if offline.Enabled() && !strings.EqualFold(conf.Backend, "file") {
+		return nil, offline.Errorf("index %q from %q", conf.Name, conf.URL)
 	}
 
-	i := index.New(conf.Name)
-	err = i.ReadBytes(bytes)
+	bytes, err := fetcher(ctx, conf)
 	if err != nil {
-		return nil, err
+		return nil, errdefs.Classify(fmt.Errorf("unable to fetch index: %w", err))
 	}
-
-	return i, nil
+	return bytes, nil
 }
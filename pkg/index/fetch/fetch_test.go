@@ -17,12 +17,17 @@ package fetch
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 	"github.com/falcosecurity/falcoctl/pkg/index/config"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
+	"github.com/falcosecurity/falcoctl/pkg/test"
 )
 
 func TestFetch(t *testing.T) {
@@ -54,3 +59,30 @@ func TestFetch(t *testing.T) {
 		t.Errorf("cannot fetch index")
 	}
 }
+
+func TestFetchOffline(t *testing.T) {
+	fetcher := NewFetcher()
+	ts := test.NewDialCountingServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer ts.Close()
+	offline.Enable(true)
+	t.Cleanup(func() { offline.Enable(false) })
+
+	_, err := fetcher.Fetch(context.Background(), &config.Entry{Name: "falcosecurity", URL: ts.URL})
+	if !errors.Is(err, errdefs.ErrOffline) {
+		t.Errorf("expected offline error, got %v", err)
+	}
+	if ts.Dials() != 0 {
+		t.Errorf("expected no dial, got %d", ts.Dials())
+	}
+
+	// Local sources are still available.
+	path, err := filepath.Abs("../testdata/index.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fetcher.Fetch(context.Background(), &config.Entry{Name: "local", URL: "file://" + path}); err != nil {
+		t.Errorf("cannot fetch local index: %v", err)
+	}
+}
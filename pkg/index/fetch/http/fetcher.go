@@ -21,6 +21,7 @@ import (
 	"io"
 	"net/http"
 
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 	"github.com/falcosecurity/falcoctl/pkg/index/config"
 )
 
@@ -34,11 +35,16 @@ func Fetch(ctx context.Context, conf *config.Entry) ([]byte, error) {
 	client := &http.Client{}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("cannot fetch index: %w", err)
+		return nil, errdefs.Wrap(errdefs.ErrNetwork, fmt.Errorf("cannot fetch index: %w", err))
 	}
 	defer resp.Body.Close() // #nosec G307 closing errors should not happen
 
-	if resp.StatusCode >= http.StatusBadRequest && resp.StatusCode <= http.StatusNetworkAuthenticationRequired {
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, errdefs.Errorf(errdefs.ErrNotFound, "cannot fetch index: %s", resp.Status)
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, errdefs.Errorf(errdefs.ErrUnauthorized, "cannot fetch index: %s", resp.Status)
+	case resp.StatusCode >= http.StatusBadRequest && resp.StatusCode <= http.StatusNetworkAuthenticationRequired:
 		return nil, fmt.Errorf("cannot fetch index: %s", resp.Status)
 	}
 
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/blang/semver/v4"
+)
+
+// Advisory severities, from the least to the most severe.
+const (
+	SeverityLow      = "low"
+	SeverityMedium   = "medium"
+	SeverityHigh     = "high"
+	SeverityCritical = "critical"
+)
+
+// Severities lists the advisory severities, from the least to the most severe.
+var Severities = []string{SeverityLow, SeverityMedium, SeverityHigh, SeverityCritical}
+
+// Advisory describes a known issue of some versions of an artifact, such as a vulnerability
+// of a plugin or a false negative of a rulesfile.
+type Advisory struct {
+	ID string `yaml:"id"`
+	// Artifact is the name of the affected artifact. It defaults to the name of the entry
+	// listing the advisory.
+	Artifact string `yaml:"artifact,omitempty"`
+	// Affected is the semver range of the affected versions, e.g. ">=0.9.0 <0.9.2".
+	Affected    string `yaml:"affected"`
+	Severity    string `yaml:"severity"`
+	Fixed       string `yaml:"fixed,omitempty"`
+	Description string `yaml:"description,omitempty"`
+	URL         string `yaml:"url,omitempty"`
+}
+
+// Validate checks that the advisory has an ID, a valid range of affected versions and a known severity.
+func (a *Advisory) Validate() error {
+	if a.ID == "" {
+		return fmt.Errorf("advisory without id")
+	}
+	if _, err := semver.ParseRange(a.Affected); err != nil {
+		return fmt.Errorf("invalid affected versions %q of advisory %q: %w", a.Affected, a.ID, err)
+	}
+	if SeverityRank(a.Severity) < 0 {
+		return fmt.Errorf("invalid severity %q of advisory %q, allowed values: %s", a.Severity, a.ID, strings.Join(Severities, ", "))
+	}
+	return nil
+}
+
+// Affects returns true if the given version is in the range of affected versions.
+// Versions that are not valid semvers, such as floating tags, are never affected.
+func (a *Advisory) Affects(version string) bool {
+	v, err := semver.Parse(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return false
+	}
+	affected, err := semver.ParseRange(a.Affected)
+	if err != nil {
+		return false
+	}
+	return affected(v)
+}
+
+// SeverityRank returns the position of a severity in Severities, ignoring case, or -1 if unknown.
+func SeverityRank(severity string) int {
+	return slices.Index(Severities, strings.ToLower(severity))
+}
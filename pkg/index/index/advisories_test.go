@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdvisoryValidate(t *testing.T) {
+	testCases := []struct {
+		name     string
+		advisory Advisory
+		err      string
+	}{
+		{name: "valid", advisory: Advisory{ID: "FALCO-2025-001", Affected: ">=0.9.0 <0.9.2", Severity: "High"}},
+		{name: "no id", advisory: Advisory{Affected: "<1.0.0", Severity: SeverityLow}, err: "advisory without id"},
+		{
+			name:     "invalid range",
+			advisory: Advisory{ID: "FALCO-2025-001", Affected: "0.9.x.y", Severity: SeverityLow},
+			err:      `invalid affected versions "0.9.x.y" of advisory "FALCO-2025-001"`,
+		},
+		{
+			name:     "invalid severity",
+			advisory: Advisory{ID: "FALCO-2025-001", Affected: "<1.0.0", Severity: "urgent"},
+			err:      `invalid severity "urgent" of advisory "FALCO-2025-001", allowed values: low, medium, high, critical`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.advisory.Validate()
+			if tc.err == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tc.err)
+			}
+		})
+	}
+}
+
+func TestAdvisoryAffects(t *testing.T) {
+	a := Advisory{ID: "FALCO-2025-001", Affected: ">=0.9.0 <0.9.2", Severity: SeverityHigh}
+
+	assert.True(t, a.Affects("0.9.0"))
+	assert.True(t, a.Affects("v0.9.1"))
+	assert.False(t, a.Affects("0.9.2"))
+	assert.False(t, a.Affects("0.8.5"))
+	assert.False(t, a.Affects("latest"))
+	assert.False(t, (&Advisory{Affected: "invalid"}).Affects("0.9.0"))
+}
+
+func TestSeverityRank(t *testing.T) {
+	assert.Equal(t, 0, SeverityRank("low"))
+	assert.Equal(t, 3, SeverityRank("CRITICAL"))
+	assert.Equal(t, -1, SeverityRank(""))
+}
@@ -25,9 +25,11 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/blang/semver"
 	"gopkg.in/yaml.v3"
 	"oras.land/oras-go/v2/registry"
 
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 	"github.com/falcosecurity/falcoctl/pkg/index/config"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
 )
@@ -47,6 +49,69 @@ type Entry struct {
 	License     string     `yaml:"license"`
 	Maintainers Maintainer `yaml:"maintainers"`
 	Sources     []string   `yaml:"sources"`
+	// Rules is the catalog of the rules contained in a rulesfile artifact, generated by "falcoctl index generate".
+	Rules []Rule `yaml:"rules,omitempty"`
+	// Versions lists the released versions of the artifact.
+	Versions []Version `yaml:"versions,omitempty"`
+	// Advisories lists the known issues of the versions of the artifact.
+	Advisories []Advisory `yaml:"advisories,omitempty"`
+}
+
+// Version describes a released version of an artifact. The version is the tag of the artifact and
+// the digest, when set, is the digest the tag must resolve to.
+type Version struct {
+	Version      string `yaml:"version"`
+	Digest       string `yaml:"digest,omitempty"`
+	ReleaseDate  string `yaml:"release-date,omitempty"`
+	Changelog    string `yaml:"changelog,omitempty"`
+	ChangelogURL string `yaml:"changelog-url,omitempty"`
+	Requirements string `yaml:"requirements,omitempty"`
+	Deprecated   bool   `yaml:"deprecated,omitempty"`
+}
+
+// Version returns the version of the entry with the given tag.
+func (e *Entry) Version(tag string) (*Version, bool) {
+	for i := range e.Versions {
+		if e.Versions[i].Version == tag {
+			return &e.Versions[i], true
+		}
+	}
+	return nil, false
+}
+
+// VersionByDigest returns the version of the entry with the given digest.
+func (e *Entry) VersionByDigest(digest string) (*Version, bool) {
+	for i := range e.Versions {
+		if e.Versions[i].Digest != "" && e.Versions[i].Digest == digest {
+			return &e.Versions[i], true
+		}
+	}
+	return nil, false
+}
+
+// NewerVersions returns the versions of the entry newer than the given tag, in the listed order.
+// It returns nil if the tag is not a full semver version, such as the floating tags "latest" or "0".
+func (e *Entry) NewerVersions(tag string) []Version {
+	current, err := semver.Parse(tag)
+	if err != nil {
+		return nil
+	}
+	var newer []Version
+	for _, v := range e.Versions {
+		if listed, err := semver.Parse(v.Version); err == nil && listed.GT(current) {
+			newer = append(newer, v)
+		}
+	}
+	return newer
+}
+
+// ChangelogSummary returns the changelog URL of the version or, if not set, the first line of its changelog.
+func (v *Version) ChangelogSummary() string {
+	if v.ChangelogURL != "" {
+		return v.ChangelogURL
+	}
+	summary, _, _ := strings.Cut(strings.TrimSpace(v.Changelog), "\n")
+	return summary
 }
 
 // Maintainer represents an index maintainer.
@@ -119,7 +184,7 @@ func (i *Index) Remove(entry *Entry) error {
 		}
 	}
 
-	return fmt.Errorf("cannot remove %s: not found", entry.Name)
+	return errdefs.Errorf(errdefs.ErrNotFound, "cannot remove %s: not found", entry.Name)
 }
 
 // EntryByName returns a Entry by passing its name.
@@ -169,12 +234,9 @@ func (i *Index) Write(path string) error {
 		}
 	}
 
-	if err := i.Normalize(); err != nil {
-		return err
-	}
-	indexBytes, err := yaml.Marshal(i.Entries)
+	indexBytes, err := i.Marshal()
 	if err != nil {
-		return fmt.Errorf("cannot marshal index: %w", err)
+		return err
 	}
 
 	if err = os.WriteFile(path, indexBytes, config.DefaultFilePermissions); err != nil {
@@ -184,6 +246,18 @@ func (i *Index) Write(path string) error {
 	return nil
 }
 
+// Marshal normalizes the index and returns its entries in the YAML format of the index files.
+func (i *Index) Marshal() ([]byte, error) {
+	if err := i.Normalize(); err != nil {
+		return nil, err
+	}
+	indexBytes, err := yaml.Marshal(i.Entries)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal index: %w", err)
+	}
+	return indexBytes, nil
+}
+
 // Read reads entries from a file.
 func (i *Index) Read(path string) error {
 	bytes, err := os.ReadFile(filepath.Clean(path))
@@ -234,41 +308,110 @@ func (m *MergedIndexes) Merge(indexes ...*Index) {
 
 // SearchByKeywords search for entries matching the given keywords in MergedIndexes.
 // minScore is the minimum score to consider a match between a name of an artifact and a keyword.
-// if minScore is not reached, we fallback to a simple partial matching on keywords.
+// if minScore is not reached, we fallback to a simple partial matching on name and keywords.
+// The entries are returned in the order of the index.
 func (i *Index) SearchByKeywords(minScore float64, keywords ...string) []*Entry {
-	matches := make(map[*Entry]struct{})
-
+	var result []*Entry
 	for _, entry := range i.Entries {
 		entryKeywords := strings.Join(entry.Keywords, " ")
 
 		for _, keyword := range keywords {
-			// Compute score between the keyword and entry name.
-			score := score(entry.Name, keyword)
-
-			if score >= minScore || strings.Contains(entryKeywords, keyword) {
-				matches[entry] = struct{}{}
+			if MatchKeyword(minScore, entry.Name, entryKeywords, keyword) {
+				result = append(result, entry)
 				break
 			}
 		}
 	}
 
-	result := make([]*Entry, 0, len(matches))
-	for k := range matches {
-		result = append(result, k)
-	}
-
 	return result
 }
 
+// MatchKeyword returns true if a keyword matches an entry with the given name and space separated keywords:
+// either the score between the name and the keyword reaches minScore, or the name or the keywords of the
+// entry contain it, ignoring case.
+func MatchKeyword(minScore float64, name, entryKeywords, keyword string) bool {
+	return score(name, keyword) >= minScore ||
+		strings.Contains(strings.ToLower(name), strings.ToLower(keyword)) ||
+		strings.Contains(strings.ToLower(entryKeywords), strings.ToLower(keyword))
+}
+
 // IndexByEntry is used to retrieve the original index from an entry in MergedIndexes.
 func (m *MergedIndexes) IndexByEntry(entry *Entry) *Index {
 	return m.indexByEntry[entry]
 }
 
+// EntryByRepository returns the entry of the given registry and repository.
+func (m *MergedIndexes) EntryByRepository(reg, repo string) (*Entry, bool) {
+	for _, entry := range m.Entries {
+		if entry.Registry == reg && entry.Repository == repo {
+			return entry, true
+		}
+	}
+	return nil, false
+}
+
+// Lookup finds entries among a set of merged indexes. It is implemented by MergedIndexes, and by the
+// index cache which looks the entries up without loading all of them.
+type Lookup interface {
+	EntryByName(name string) (*Entry, bool)
+	EntryByRepository(reg, repo string) (*Entry, bool)
+}
+
+// VersionForRef returns the version listed by the index for a fully qualified reference with a tag.
+// Returns false if the reference has no tag, or if its repository or tag is not listed.
+func (m *MergedIndexes) VersionForRef(ref string) (*Version, bool) {
+	return VersionForRef(m, ref)
+}
+
+// NewerVersionsForRef returns the versions listed by the index newer than the tag of a reference.
+func (m *MergedIndexes) NewerVersionsForRef(ref string) []Version {
+	return NewerVersionsForRef(m, ref)
+}
+
 // SignatureForIndexRef is a helper function that will identify signature data if available for the specified name
 // corresponding to an entry in the index.
 // Returns nil if not found or if the specified name is a full reference.
 func (m *MergedIndexes) SignatureForIndexRef(name string) *Signature {
+	return SignatureForIndexRef(m, name)
+}
+
+// ResolveReference resolves the name of an artifact to a reference, see the ResolveReference function.
+func (m *MergedIndexes) ResolveReference(name string) (string, error) {
+	return ResolveReference(m, name)
+}
+
+// VersionForRef returns the version listed by the indexes of l for a fully qualified reference with a tag.
+// Returns false if the reference has no tag, or if its repository or tag is not listed.
+func VersionForRef(l Lookup, ref string) (*Version, bool) {
+	parsedRef, err := registry.ParseReference(ref)
+	if err != nil || parsedRef.ValidateReferenceAsTag() != nil {
+		return nil, false
+	}
+	entry, ok := l.EntryByRepository(parsedRef.Registry, parsedRef.Repository)
+	if !ok {
+		return nil, false
+	}
+	return entry.Version(parsedRef.Reference)
+}
+
+// NewerVersionsForRef returns the versions listed by the indexes of l newer than the tag of a fully qualified
+// reference. Returns nil if the reference has no semver tag, or if its repository is not listed.
+func NewerVersionsForRef(l Lookup, ref string) []Version {
+	parsedRef, err := registry.ParseReference(ref)
+	if err != nil || parsedRef.ValidateReferenceAsTag() != nil {
+		return nil
+	}
+	entry, ok := l.EntryByRepository(parsedRef.Registry, parsedRef.Repository)
+	if !ok {
+		return nil
+	}
+	return entry.NewerVersions(parsedRef.Reference)
+}
+
+// SignatureForIndexRef returns the signature data, if available, of the entry of the indexes of l
+// with the specified name.
+// Returns nil if not found or if the specified name is a full reference.
+func SignatureForIndexRef(l Lookup, name string) *Signature {
 	_, err := registry.ParseReference(name)
 	// If we have a full reference we cannot determine the signature
 	if err == nil {
@@ -280,7 +423,7 @@ func (m *MergedIndexes) SignatureForIndexRef(name string) *Signature {
 		return nil
 	}
 
-	entry, ok := m.EntryByName(entryName)
+	entry, ok := l.EntryByName(entryName)
 	if !ok {
 		return nil
 	}
@@ -290,11 +433,11 @@ func (m *MergedIndexes) SignatureForIndexRef(name string) *Signature {
 
 // ResolveReference is a helper function that parse with the following logic:
 //
-//  1. if name is the name of an artifact, it will use the merged index to compute
+//  1. if name is the name of an artifact, it will use the indexes of l to compute
 //     its reference. The tag latest is always appended.
 //     e.g "cloudtrail" -> "ghcr.io/falcosecurity/plugins/cloudtrail:latest"
 //     if instead a tag or a digest is specified, the name will be used to look up
-//     into mergedIndexes, then the tag or digest will be appended.
+//     into the indexes, then the tag or digest will be appended.
 //     e.g "cloudtrail:0.5.1" -> "ghcr.io/falcosecurity/plugins/cloudtrail:0.5.1"
 //     e.g "cloudtrail@sha256:123abc..." -> "ghcr.io/falcosecurity/plugins/cloudtrail@sha256:123abc...
 //
@@ -302,7 +445,7 @@ func (m *MergedIndexes) SignatureForIndexRef(name string) *Signature {
 //     e.g. "ghcr.io/falcosecurity/plugins/cloudtrail" -> "ghcr.io/falcosecurity/plugins/cloudtrail:latest"
 //
 //  3. if name is a complete reference, it will be returned as is.
-func (m *MergedIndexes) ResolveReference(name string) (string, error) {
+func ResolveReference(l Lookup, name string) (string, error) {
 	parsedRef, err := registry.ParseReference(name)
 	var ref string
 
@@ -313,9 +456,9 @@ func (m *MergedIndexes) ResolveReference(name string) (string, error) {
 			return "", err
 		}
 
-		entry, ok := m.EntryByName(entryName)
+		entry, ok := l.EntryByName(entryName)
 		if !ok {
-			return "", fmt.Errorf("cannot find %s among the configured indexes, skipping", name)
+			return "", errdefs.Errorf(errdefs.ErrNotFound, "cannot find %s among the configured indexes, skipping", name)
 		}
 
 		ref = fmt.Sprintf("%s/%s", entry.Registry, entry.Repository)
@@ -18,6 +18,7 @@ package index
 import (
 	"crypto/sha256"
 	"fmt"
+	"strings"
 	"testing"
 
 	"gopkg.in/yaml.v3"
@@ -135,6 +136,106 @@ func TestMerge(t *testing.T) {
 	}
 }
 
+func TestVersionForRef(t *testing.T) {
+	i := New("index")
+	if err := i.ReadBytes([]byte(`- name: falco-rules
+  type: rulesfile
+  registry: ghcr.io
+  repository: falcosecurity/rules/falco-rules
+  versions:
+    - version: 3.0.0
+      digest: sha256:aaa
+      release-date: "2024-01-30"
+      changelog-url: https://github.com/falcosecurity/rules/releases/tag/falco-rules-3.0.0
+      requirements: engine_version_semver >= 0.26.0
+    - version: 2.0.0
+      digest: sha256:bbb
+      deprecated: true
+`)); err != nil {
+		t.Fatal(err)
+	}
+	merged := NewMergedIndexes()
+	merged.Merge(i)
+
+	tests := []struct {
+		ref    string
+		digest string
+		found  bool
+	}{
+		{ref: "ghcr.io/falcosecurity/rules/falco-rules:3.0.0", digest: "sha256:aaa", found: true},
+		{ref: "ghcr.io/falcosecurity/rules/falco-rules:2.0.0", digest: "sha256:bbb", found: true},
+		{ref: "ghcr.io/falcosecurity/rules/falco-rules:latest"},
+		{ref: "ghcr.io/falcosecurity/rules/falco-rules@sha256:9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"},
+		{ref: "ghcr.io/falcosecurity/rules/other:3.0.0"},
+		{ref: "falco-rules:3.0.0"},
+	}
+	for _, tt := range tests {
+		version, ok := merged.VersionForRef(tt.ref)
+		if ok != tt.found {
+			t.Errorf("%s: expected found %v, got %v", tt.ref, tt.found, ok)
+			continue
+		}
+		if ok && version.Digest != tt.digest {
+			t.Errorf("%s: expected digest %q, got %q", tt.ref, tt.digest, version.Digest)
+		}
+	}
+
+	entry, _ := merged.EntryByName("falco-rules")
+	if v, _ := entry.Version("2.0.0"); !v.Deprecated {
+		t.Errorf("expected version 2.0.0 to be deprecated")
+	}
+	if v, _ := entry.Version("3.0.0"); v.ReleaseDate != "2024-01-30" || v.Requirements != "engine_version_semver >= 0.26.0" {
+		t.Errorf("unexpected version 3.0.0: %+v", v)
+	}
+}
+
+func TestNewerVersions(t *testing.T) {
+	entry := &Entry{Registry: "ghcr.io", Repository: "falcosecurity/rules/falco-rules", Versions: []Version{
+		{Version: "3.1.0", Digest: "sha256:ccc", Changelog: "Add new rules.\nFix macros."},
+		{Version: "3.0.0", Digest: "sha256:aaa", ChangelogURL: "https://example.com/3.0.0"},
+		{Version: "2.0.0", Digest: "sha256:bbb"},
+		{Version: "nightly"},
+	}}
+	merged := NewMergedIndexes()
+	i := New("index")
+	i.Upsert(entry)
+	merged.Merge(i)
+
+	tests := []struct {
+		ref   string
+		newer []string
+	}{
+		{ref: "ghcr.io/falcosecurity/rules/falco-rules:2.0.0", newer: []string{"3.1.0", "3.0.0"}},
+		{ref: "ghcr.io/falcosecurity/rules/falco-rules:3.1.0"},
+		// Floating tags cannot be compared with the listed versions.
+		{ref: "ghcr.io/falcosecurity/rules/falco-rules:3"},
+		{ref: "ghcr.io/falcosecurity/rules/falco-rules:latest"},
+		{ref: "ghcr.io/falcosecurity/rules/other:2.0.0"},
+	}
+	for _, tt := range tests {
+		var newer []string
+		for _, v := range merged.NewerVersionsForRef(tt.ref) {
+			newer = append(newer, v.Version)
+		}
+		if strings.Join(newer, ",") != strings.Join(tt.newer, ",") {
+			t.Errorf("%s: expected newer versions %v, got %v", tt.ref, tt.newer, newer)
+		}
+	}
+
+	if v, ok := entry.VersionByDigest("sha256:aaa"); !ok || v.Version != "3.0.0" {
+		t.Errorf("expected version 3.0.0 for digest sha256:aaa, got %+v", v)
+	}
+	if _, ok := entry.VersionByDigest(""); ok {
+		t.Errorf("expected no version for an empty digest")
+	}
+	if summary := entry.Versions[0].ChangelogSummary(); summary != "Add new rules." {
+		t.Errorf("unexpected changelog summary %q", summary)
+	}
+	if summary := entry.Versions[1].ChangelogSummary(); summary != "https://example.com/3.0.0" {
+		t.Errorf("unexpected changelog summary %q", summary)
+	}
+}
+
 func TestSearchByKeywords(t *testing.T) {
 	i := New("name")
 
@@ -165,6 +266,22 @@ func TestSearchByKeywords(t *testing.T) {
 		t.Errorf("error in SearchByKeywords, expected to find a perfect match with keyword")
 	}
 
+	// Test match on keyword ignoring case.
+	i.Upsert(&Entry{
+		Name:     "k8saudit",
+		Keywords: []string{"Kubernetes", "audit"},
+	})
+	caseInsensitive := i.SearchByKeywords(1, "kubernetes")
+	if len(caseInsensitive) != 1 || caseInsensitive[0].Name != "k8saudit" {
+		t.Errorf("error in SearchByKeywords, expected to find a match with keyword ignoring case")
+	}
+
+	// Test that the matches keep the order of the index.
+	ordered := i.SearchByKeywords(1, "audit")
+	if len(ordered) != 2 || ordered[0].Name != "github" || ordered[1].Name != "k8saudit" {
+		t.Errorf("error in SearchByKeywords, expected the matches in the order of the index")
+	}
+
 	// Test partial match
 	partialKeywordMatch := i.SearchByKeywords(1, "web")
 	if len(partialKeywordMatch) != 1 {
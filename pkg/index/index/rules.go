@@ -0,0 +1,239 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultRuleSource is the source of the rules not declaring one.
+const DefaultRuleSource = "syscall"
+
+// priorities are the Falco rule priorities, from the most to the least severe.
+var priorities = []string{"emergency", "alert", "critical", "error", "warning", "notice", "informational", "debug"}
+
+// mitreTechnique matches the tags holding a MITRE ATT&CK technique or sub-technique, e.g. T1059 or T1059.004.
+var mitreTechnique = regexp.MustCompile(`^T\d{4}(\.\d{3})?$`)
+
+// Rule describes a Falco rule contained in a rulesfile artifact.
+type Rule struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"desc,omitempty"`
+	Priority    string   `yaml:"priority,omitempty"`
+	Source      string   `yaml:"source,omitempty"`
+	Tags        []string `yaml:"tags,omitempty"`
+	// Mitre holds the MITRE ATT&CK techniques found among the tags.
+	Mitre []string `yaml:"mitre,omitempty"`
+}
+
+// RuleQuery selects the rules of a catalog. Empty fields match any rule.
+type RuleQuery struct {
+	// Name matches the rules whose name contains it, ignoring case.
+	Name string
+	// Tags matches the rules having all of them, ignoring case.
+	Tags []string
+	// Mitre matches the rules having any of the techniques, or one of their sub-techniques.
+	Mitre []string
+	// Priority matches the rules at least as severe as it.
+	Priority string
+}
+
+// RuleMatch is a rule matching a RuleQuery, together with the entry it belongs to.
+type RuleMatch struct {
+	Entry *Entry
+	Rule  Rule
+}
+
+// ValidatePriority returns an error if the given priority is not a Falco rule priority.
+func ValidatePriority(priority string) error {
+	if priorityRank(priority) < 0 {
+		return fmt.Errorf("invalid priority %q, allowed values: %s", priority, strings.Join(priorities, ", "))
+	}
+	return nil
+}
+
+// priorityRank returns the position of the priority in priorities, or -1 if unknown.
+// Falco accepts "info" as a synonym of "informational".
+func priorityRank(priority string) int {
+	p := strings.ToLower(priority)
+	if p == "info" {
+		p = "informational"
+	}
+	for i, v := range priorities {
+		if v == p {
+			return i
+		}
+	}
+	return -1
+}
+
+// IsEmpty reports whether the query matches any rule.
+func (q *RuleQuery) IsEmpty() bool {
+	return q.Name == "" && len(q.Tags) == 0 && len(q.Mitre) == 0 && q.Priority == ""
+}
+
+// Matches reports whether the rule matches the query.
+func (q *RuleQuery) Matches(r *Rule) bool {
+	if q.Name != "" && !strings.Contains(strings.ToLower(r.Name), strings.ToLower(q.Name)) {
+		return false
+	}
+	for _, tag := range q.Tags {
+		if !containsFold(r.Tags, tag) {
+			return false
+		}
+	}
+	if len(q.Mitre) > 0 && !matchesMitre(r.Mitre, q.Mitre) {
+		return false
+	}
+	if q.Priority != "" {
+		rank := priorityRank(r.Priority)
+		if rank < 0 || rank > priorityRank(q.Priority) {
+			return false
+		}
+	}
+	return true
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchesMitre(techniques, wanted []string) bool {
+	for _, t := range techniques {
+		for _, w := range wanted {
+			w = strings.ToUpper(w)
+			if t == w || strings.HasPrefix(t, w+".") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SearchRules returns the rules of the catalogs of the entries matching the query,
+// sorted by entry and rule name.
+func (i *Index) SearchRules(q *RuleQuery) []RuleMatch {
+	var matches []RuleMatch
+	for _, entry := range i.Entries {
+		for k := range entry.Rules {
+			if q.Matches(&entry.Rules[k]) {
+				matches = append(matches, RuleMatch{Entry: entry, Rule: entry.Rules[k]})
+			}
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool {
+		if matches[a].Entry.Name != matches[b].Entry.Name {
+			return matches[a].Entry.Name < matches[b].Entry.Name
+		}
+		return matches[a].Rule.Name < matches[b].Rule.Name
+	})
+	return matches
+}
+
+// ruleItem is an item of a Falco rulesfile. Only the fields needed by the catalog are decoded.
+type ruleItem struct {
+	Rule     string      `yaml:"rule"`
+	Desc     string      `yaml:"desc"`
+	Priority string      `yaml:"priority"`
+	Source   string      `yaml:"source"`
+	Tags     []string    `yaml:"tags"`
+	Append   bool        `yaml:"append"`
+	Override interface{} `yaml:"override"`
+}
+
+// ParseRules returns the rules defined in the given Falco rulesfile. Lists, macros and the items
+// appending to or overriding rules defined elsewhere are skipped.
+func ParseRules(data []byte) ([]Rule, error) {
+	var items []ruleItem
+	if err := yaml.Unmarshal(data, &items); err != nil {
+		return nil, fmt.Errorf("cannot unmarshal rulesfile: %w", err)
+	}
+
+	var rules []Rule
+	for _, item := range items {
+		if item.Rule == "" || item.Append || item.Override != nil {
+			continue
+		}
+		rule := Rule{
+			Name:        item.Rule,
+			Description: strings.TrimSpace(item.Desc),
+			Priority:    strings.ToLower(item.Priority),
+			Source:      item.Source,
+			Tags:        item.Tags,
+		}
+		if rule.Source == "" {
+			rule.Source = DefaultRuleSource
+		}
+		for _, tag := range item.Tags {
+			if mitreTechnique.MatchString(tag) {
+				rule.Mitre = append(rule.Mitre, tag)
+			}
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// RulesFromArchive returns the rules defined in the YAML files of the given gzipped tarball,
+// i.e. the layer of a rulesfile artifact, sorted by name.
+func RulesFromArchive(r io.Reader) ([]Rule, error) {
+	gr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read rulesfile archive: %w", err)
+	}
+	defer gr.Close()
+
+	var rules []Rule
+	tr := tar.NewReader(gr)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read rulesfile archive: %w", err)
+		}
+		if ext := filepath.Ext(hdr.Name); !hdr.FileInfo().Mode().IsRegular() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("cannot read %q from rulesfile archive: %w", hdr.Name, err)
+		}
+		fileRules, err := ParseRules(data)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", hdr.Name, err)
+		}
+		rules = append(rules, fileRules...)
+	}
+	sort.SliceStable(rules, func(a, b int) bool { return rules[a].Name < rules[b].Name })
+	return rules, nil
+}
@@ -0,0 +1,142 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package index
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testRulesfile = `
+- required_engine_version: 0.26.0
+- list: shell_binaries
+  items: [bash, sh]
+- macro: spawned_process
+  condition: evt.type = execve
+- rule: Terminal shell in container
+  desc: >
+    A shell was used as the entrypoint/exec point into a container.
+  condition: spawned_process and container
+  output: A shell was spawned in a container
+  priority: NOTICE
+  tags: [maturity_stable, container, shell, mitre_execution, T1059]
+- rule: Read sensitive file untrusted
+  desc: An attempt to read sensitive files.
+  condition: open_read
+  output: Sensitive file opened
+  priority: WARNING
+  tags: [host, container, filesystem, mitre_credential_access, T1555]
+- rule: Contact K8S API Server From Container
+  desc: Detect attempts to contact the K8S API Server from a container.
+  condition: outbound
+  output: Unexpected connection to K8s API Server
+  priority: NOTICE
+  tags: [network, k8s, container, mitre_discovery, T1565.001]
+- rule: Github Webhook Connected
+  desc: Detect a webhook connection.
+  condition: github.type=ping
+  output: A webhook was connected
+  priority: DEBUG
+  source: github
+- rule: Terminal shell in container
+  append: true
+  condition: and not user_known_shell
+- rule: Read sensitive file untrusted
+  override:
+    condition: append
+  condition: and not trusted
+`
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules([]byte(testRulesfile))
+	require.NoError(t, err)
+	require.Len(t, rules, 4)
+
+	assert.Equal(t, Rule{
+		Name:        "Terminal shell in container",
+		Description: "A shell was used as the entrypoint/exec point into a container.",
+		Priority:    "notice",
+		Source:      DefaultRuleSource,
+		Tags:        []string{"maturity_stable", "container", "shell", "mitre_execution", "T1059"},
+		Mitre:       []string{"T1059"},
+	}, rules[0])
+	assert.Equal(t, []string{"T1565.001"}, rules[2].Mitre)
+	assert.Equal(t, "github", rules[3].Source)
+
+	_, err = ParseRules([]byte("rule: not a list"))
+	assert.Error(t, err)
+}
+
+func TestRulesFromArchive(t *testing.T) {
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for name, content := range map[string]string{"falco_rules.yaml": testRulesfile, "README.md": "- rule: ignored"} {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gw.Close())
+
+	rules, err := RulesFromArchive(&buf)
+	require.NoError(t, err)
+	names := make([]string, 0, len(rules))
+	for _, r := range rules {
+		names = append(names, r.Name)
+	}
+	assert.Equal(t, []string{"Contact K8S API Server From Container", "Github Webhook Connected",
+		"Read sensitive file untrusted", "Terminal shell in container"}, names)
+}
+
+func TestSearchRules(t *testing.T) {
+	rules, err := ParseRules([]byte(testRulesfile))
+	require.NoError(t, err)
+	i := New("test")
+	i.Upsert(&Entry{Name: "falco-rules", Type: "rulesfile", Rules: rules})
+	i.Upsert(&Entry{Name: "k8saudit", Type: "plugin"})
+
+	names := func(q *RuleQuery) []string {
+		var res []string
+		for _, m := range i.SearchRules(q) {
+			assert.Equal(t, "falco-rules", m.Entry.Name)
+			res = append(res, m.Rule.Name)
+		}
+		return res
+	}
+
+	assert.Equal(t, []string{"Terminal shell in container"}, names(&RuleQuery{Mitre: []string{"t1059"}}))
+	assert.Equal(t, []string{"Contact K8S API Server From Container"}, names(&RuleQuery{Mitre: []string{"T1565"}}))
+	assert.Empty(t, names(&RuleQuery{Mitre: []string{"T1565.002"}}))
+	// Priorities at least as severe as notice include warning.
+	assert.Equal(t, []string{"Contact K8S API Server From Container", "Read sensitive file untrusted", "Terminal shell in container"},
+		names(&RuleQuery{Tags: []string{"Container"}, Priority: "notice"}))
+	assert.Equal(t, []string{"Terminal shell in container"}, names(&RuleQuery{Tags: []string{"container", "shell"}}))
+	assert.Equal(t, []string{"Read sensitive file untrusted"}, names(&RuleQuery{Priority: "warning"}))
+	assert.Equal(t, []string{"Github Webhook Connected"}, names(&RuleQuery{Name: "webhook"}))
+	assert.Len(t, names(&RuleQuery{}), 4)
+}
+
+func TestValidatePriority(t *testing.T) {
+	assert.NoError(t, ValidatePriority("Warning"))
+	assert.NoError(t, ValidatePriority("info"))
+	assert.Error(t, ValidatePriority("severe"))
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+)
+
+const (
+	// IndexPath is the path the index is served at.
+	IndexPath = "/index.yaml"
+	// SignaturePath is the path the detached signature of the index is served at.
+	SignaturePath = IndexPath + ".sig"
+)
+
+// Signer returns the detached signature of data.
+type Signer func(data []byte) ([]byte, error)
+
+// Server serves an index, and its detached signature if any, over HTTP. The served content is
+// replaced by Set, e.g. after a refresh of the upstream indexes, while the server is running.
+type Server struct {
+	signer    Signer
+	mu        sync.RWMutex
+	index     []byte
+	etag      string
+	modTime   time.Time
+	signature []byte
+}
+
+// New returns a Server serving an empty index until Set is called. If signer is not nil, each index
+// set is signed with it, and the signature is served along with it.
+func New(signer Signer) *Server {
+	s := &Server{signer: signer}
+	s.setBytes([]byte("[]\n"), nil)
+	return s
+}
+
+// Set serializes idx as it is written to the index files and serves it, along with its signature
+// if the server has a signer. The served signature always covers the served bytes: if idx cannot
+// be signed, the previous index and signature keep being served.
+func (s *Server) Set(idx *index.Index) error {
+	indexBytes, err := idx.Marshal()
+	if err != nil {
+		return err
+	}
+	var signature []byte
+	if s.signer != nil {
+		if signature, err = s.signer(indexBytes); err != nil {
+			return fmt.Errorf("unable to sign the served index: %w", err)
+		}
+	}
+	s.setBytes(indexBytes, signature)
+	return nil
+}
+
+func (s *Server) setBytes(indexBytes, signature []byte) {
+	etag := fmt.Sprintf("%q", fmt.Sprintf("sha256:%x", sha256.Sum256(indexBytes)))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// The modification time only changes along with the content, so that clients revalidating
+	// with If-Modified-Since keep getting 304 across refreshes yielding the same index.
+	if etag != s.etag {
+		s.modTime = time.Now().UTC()
+	}
+	s.index, s.etag, s.signature = indexBytes, etag, signature
+}
+
+// ETag returns the entity tag of the served index.
+func (s *Server) ETag() string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.etag
+}
+
+// ServeHTTP serves the index at IndexPath and its signature at SignaturePath. Conditional requests
+// are answered with 304 Not Modified when If-None-Match matches the ETag of the index.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.mu.RLock()
+	indexBytes, etag, modTime, signature := s.index, s.etag, s.modTime, s.signature
+	s.mu.RUnlock()
+
+	switch r.URL.Path {
+	case IndexPath:
+		w.Header().Set("Content-Type", "application/yaml")
+		w.Header().Set("ETag", etag)
+		http.ServeContent(w, r, IndexPath, modTime, bytes.NewReader(indexBytes))
+	case SignaturePath:
+		if signature == nil {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/octet-stream")
+		http.ServeContent(w, r, SignaturePath, modTime, bytes.NewReader(signature))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// Select returns the index made of the entries of merged coming from the indexes in names, or all the
+// entries if names is empty. The registries of the entries found in mirrors are replaced by the
+// mirror they are mapped to, leaving the entries of merged untouched.
+func Select(merged *index.MergedIndexes, name string, names []string, mirrors map[string]string) *index.Index {
+	selected := make(map[string]bool, len(names))
+	for _, n := range names {
+		selected[n] = true
+	}
+
+	idx := index.New(name)
+	for _, entry := range merged.Entries {
+		if len(selected) > 0 {
+			if from := merged.IndexByEntry(entry); from == nil || !selected[from.Name] {
+				continue
+			}
+		}
+		if mirror, ok := mirrors[entry.Registry]; ok {
+			mirrored := *entry
+			mirrored.Registry = mirror
+			entry = &mirrored
+		}
+		idx.Upsert(entry)
+	}
+	return idx
+}
@@ -0,0 +1,187 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"bytes"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+)
+
+func newMerged() *index.MergedIndexes {
+	upstream := index.New("falcosecurity")
+	upstream.Upsert(&index.Entry{Name: "falco-rules", Type: "rulesfile", Registry: "ghcr.io", Repository: "falcosecurity/rules/falco-rules"})
+	upstream.Upsert(&index.Entry{Name: "k8saudit", Type: "plugin", Registry: "ghcr.io", Repository: "falcosecurity/plugins/k8saudit"})
+	custom := index.New("custom")
+	custom.Upsert(&index.Entry{Name: "my-rules", Type: "rulesfile", Registry: "registry.example.com", Repository: "rules/my-rules"})
+
+	merged := index.NewMergedIndexes()
+	merged.Merge(upstream, custom)
+	return merged
+}
+
+func get(t *testing.T, url string, header map[string]string) (*http.Response, string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodGet, url, http.NoBody)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp, string(body)
+}
+
+func TestSelect(t *testing.T) {
+	merged := newMerged()
+
+	all := Select(merged, "served", nil, nil)
+	if len(all.Entries) != 3 {
+		t.Fatalf("expected 3 entries, got %d", len(all.Entries))
+	}
+
+	subset := Select(merged, "served", []string{"custom"}, nil)
+	if len(subset.Entries) != 1 || subset.Entries[0].Name != "my-rules" {
+		t.Fatalf("expected only the entries of the custom index, got %v", subset.Entries)
+	}
+
+	mirrored := Select(merged, "served", nil, map[string]string{"ghcr.io": "mirror.local:5000"})
+	entry, ok := mirrored.EntryByName("falco-rules")
+	if !ok || entry.Registry != "mirror.local:5000" {
+		t.Fatalf("expected falco-rules to point to the mirror, got %v", entry)
+	}
+	if entry, _ = mirrored.EntryByName("my-rules"); entry.Registry != "registry.example.com" {
+		t.Errorf("expected my-rules not to be mirrored, got %q", entry.Registry)
+	}
+	// The merged entries must not be modified.
+	if entry, _ = merged.EntryByName("falco-rules"); entry.Registry != "ghcr.io" {
+		t.Errorf("expected the merged entry to be left untouched, got %q", entry.Registry)
+	}
+}
+
+func TestServer(t *testing.T) {
+	server := New(nil)
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	idx := Select(newMerged(), "served", nil, nil)
+	if err := server.Set(idx); err != nil {
+		t.Fatal(err)
+	}
+
+	resp, body := get(t, ts.URL+IndexPath, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	etag := resp.Header.Get("ETag")
+	if etag == "" || etag != server.ETag() {
+		t.Fatalf("expected ETag %q, got %q", server.ETag(), etag)
+	}
+	// The body is the index as consumed by the http backend.
+	served := index.New("served")
+	if err := served.ReadBytes([]byte(body)); err != nil {
+		t.Fatal(err)
+	}
+	if len(served.Entries) != 3 {
+		t.Errorf("expected 3 served entries, got %d", len(served.Entries))
+	}
+
+	if resp, _ = get(t, ts.URL+IndexPath, map[string]string{"If-None-Match": etag}); resp.StatusCode != http.StatusNotModified {
+		t.Errorf("expected status 304 for a matching ETag, got %d", resp.StatusCode)
+	}
+
+	if resp, _ = get(t, ts.URL+SignaturePath, nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status 404 without signature, got %d", resp.StatusCode)
+	}
+
+	// Changing the index changes the ETag.
+	idx = Select(newMerged(), "served", []string{"custom"}, nil)
+	if err := server.Set(idx); err != nil {
+		t.Fatal(err)
+	}
+	if resp, _ = get(t, ts.URL+IndexPath, map[string]string{"If-None-Match": etag}); resp.StatusCode != http.StatusOK {
+		t.Errorf("expected status 200 for a stale ETag, got %d", resp.StatusCode)
+	}
+
+	if resp, _ = get(t, ts.URL+"/other.yaml", nil); resp.StatusCode != http.StatusNotFound {
+		t.Errorf("expected status 404 for an unknown path, got %d", resp.StatusCode)
+	}
+}
+
+func TestServerSignature(t *testing.T) {
+	password := []byte("password")
+	keys, err := cosign.GenerateKeyPair(func(bool) ([]byte, error) { return password, nil })
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyPath := filepath.Join(t.TempDir(), "cosign.key")
+	if err := os.WriteFile(keyPath, keys.PrivateBytes, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewKeySigner(keyPath, []byte("wrong")); err == nil {
+		t.Fatal("expected an error loading the key with a wrong password")
+	}
+	signer, err := NewKeySigner(keyPath, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := cosign.LoadPrivateKey(keys.PrivateBytes, password)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := New(signer)
+	ts := httptest.NewServer(server)
+	defer ts.Close()
+
+	// The signature is computed again each time the served index changes, e.g. on refreshes.
+	for _, names := range [][]string{nil, {"custom"}} {
+		if err := server.Set(Select(newMerged(), "served", names, map[string]string{"ghcr.io": "mirror.example.com"})); err != nil {
+			t.Fatal(err)
+		}
+		_, body := get(t, ts.URL+IndexPath, nil)
+		resp, sigBody := get(t, ts.URL+SignaturePath, nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("expected status 200 for the signature, got %d", resp.StatusCode)
+		}
+		sig, err := base64.StdEncoding.DecodeString(sigBody)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := verifier.VerifySignature(bytes.NewReader(sig), strings.NewReader(body)); err != nil {
+			t.Errorf("expected the signature to cover the served index: %v", err)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package serve
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+)
+
+// NewKeySigner returns a Signer signing with the cosign private key at keyPath, decrypted with password.
+// The signatures are base64 encoded like the ones of "cosign sign-blob", and can be verified with:
+//
+//	cosign verify-blob --key cosign.pub --signature index.yaml.sig --insecure-ignore-tlog index.yaml
+func NewKeySigner(keyPath string, password []byte) (Signer, error) {
+	key, err := os.ReadFile(filepath.Clean(keyPath))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read signing key %q: %w", keyPath, err)
+	}
+	sv, err := cosign.LoadPrivateKey(key, password)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load signing key %q: %w", keyPath, err)
+	}
+	return func(data []byte) ([]byte, error) {
+		sig, err := sv.SignMessage(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return []byte(base64.StdEncoding.EncodeToString(sig)), nil
+	}, nil
+}
@@ -0,0 +1,301 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installed
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+)
+
+// Policies applied to the files modified locally, i.e. the existing files whose content is not the one
+// falcoctl recorded when writing them, or that falcoctl did not write at all.
+const (
+	// ConflictKeep keeps the local file and writes the new content next to it, with the NewFileSuffix suffix.
+	ConflictKeep = "keep"
+	// ConflictBackup moves the local file next to its location, with the BackupFileSuffix suffix, and
+	// writes the new content in place.
+	ConflictBackup = "backup"
+	// ConflictFail refuses to install the artifact, leaving all its files untouched.
+	ConflictFail = "fail"
+
+	// NewFileSuffix is appended to the path of a local file kept with ConflictKeep to write the new content.
+	NewFileSuffix = ".falcoctl-new"
+	// BackupFileSuffix is appended to the path of a local file to back it up with ConflictBackup.
+	BackupFileSuffix = ".falcoctl-old"
+
+	// stashFileSuffix is appended to the path of a file replaced while installing an artifact, until all its
+	// files are installed.
+	stashFileSuffix = ".falcoctl-tmp"
+)
+
+// ConflictPolicies lists the policies applied to the files modified locally. The first one is the default.
+var ConflictPolicies = []string{ConflictBackup, ConflictKeep, ConflictFail}
+
+// ValidateConflictPolicy checks the policy applied to the files modified locally. An empty policy selects the default.
+func ValidateConflictPolicy(policy string) error {
+	if policy != "" && !slices.Contains(ConflictPolicies, policy) {
+		return fmt.Errorf("invalid conflict policy %q, allowed values: %s", policy, strings.Join(ConflictPolicies, ", "))
+	}
+	return nil
+}
+
+// Conflict is a file owned by another artifact, or modified locally, found while installing an artifact.
+type Conflict struct {
+	// Path is the path of the local file.
+	Path string
+	// Owner is the reference of the other artifact owning the file, if any.
+	Owner string
+	// Policy is the policy applied to the file, if modified locally.
+	Policy string
+	// Saved is where the new content, with ConflictKeep, or the local file, with ConflictBackup, has been written.
+	Saved string
+}
+
+// Move is a file to be installed: the file at Src, e.g. extracted in a temporary directory, is moved to Dst.
+type Move struct {
+	Src string
+	Dst string
+}
+
+// Installer moves the files of the artifacts to their destination, refusing to overwrite the files owned by other
+// artifacts and applying a policy to the files modified locally.
+type Installer struct {
+	policy         string
+	allowOverwrite bool
+	// recorded maps the paths of the files written by falcoctl to their recorded digest.
+	recorded map[string]string
+	// owners maps the paths of the files written by falcoctl to the reference of the artifact owning them.
+	owners map[string]string
+}
+
+// NewInstaller returns an installer applying the given policy, the default one if empty, to the files modified
+// since they were recorded in the state file. With an empty state file, every existing file whose content
+// differs is handled as modified locally. The files owned by other artifacts are overwritten only if
+// allowOverwrite is true.
+func NewInstaller(stateFile, policy string, allowOverwrite bool) (*Installer, error) {
+	state := &State{}
+	if stateFile != "" {
+		var err error
+		if state, err = Load(stateFile); err != nil {
+			return nil, err
+		}
+	}
+	return newInstaller(state, policy, allowOverwrite)
+}
+
+// newInstaller returns an installer checking the files recorded in the given state.
+func newInstaller(state *State, policy string, allowOverwrite bool) (*Installer, error) {
+	if err := ValidateConflictPolicy(policy); err != nil {
+		return nil, err
+	}
+	if policy == "" {
+		policy = ConflictPolicies[0]
+	}
+
+	i := &Installer{policy: policy, allowOverwrite: allowOverwrite, recorded: map[string]string{}, owners: map[string]string{}}
+	for _, a := range state.Artifacts {
+		for _, f := range a.Files {
+			i.recorded[f.Path] = f.Digest
+			i.owners[f.Path] = a.Ref
+		}
+	}
+	return i, nil
+}
+
+// Transaction installs an artifact while holding the lock of the state file, so that concurrent installations,
+// in the process or in other processes, cannot overwrite the same files without a conflict being raised: fn
+// installs the files with an installer built from the current state, and returns the artifact to record in it.
+// Without a state file, fn is run with an installer handling every existing file that differs as modified
+// locally, and nothing is recorded.
+func Transaction(stateFile, policy string, allowOverwrite bool, fn func(*Installer) (Artifact, error)) error {
+	if stateFile == "" {
+		i, err := NewInstaller("", policy, allowOverwrite)
+		if err != nil {
+			return err
+		}
+		_, err = fn(i)
+		return err
+	}
+	return Update(stateFile, func(s *State) error {
+		i, err := newInstaller(s, policy, allowOverwrite)
+		if err != nil {
+			return err
+		}
+		artifact, err := fn(i)
+		if err != nil {
+			return err
+		}
+		s.Set(artifact)
+		return nil
+	})
+}
+
+// Install moves the files of the artifact with the given reference to their destination. It returns the installed
+// files with the digest of their new content, to be recorded in the state file even when the local file has been
+// kept, so that it is still handled as modified locally the next time, and the conflicts found. When a file is
+// owned by another artifact and overwriting is not allowed, or is modified locally with ConflictFail, it returns an
+// error of class errdefs.ErrFileConflict, along with the conflicts, before moving any file. If a file cannot be
+// installed, the files already installed are rolled back, restoring the ones they replaced.
+func (i *Installer) Install(ref string, moves []Move) ([]File, []Conflict, error) {
+	files := make([]File, 0, len(moves))
+	found := make([]Conflict, len(moves))
+	var blocked []Conflict
+	for k, m := range moves {
+		digest, err := Digest(m.Src)
+		if err != nil {
+			return nil, nil, err
+		}
+		files = append(files, File{Path: m.Dst, Digest: digest})
+
+		found[k].Path = m.Dst
+		if owner, ok := i.owners[m.Dst]; ok && repository(owner) != repository(ref) {
+			found[k].Owner = owner
+		}
+		modified, err := i.modified(m.Dst, digest)
+		if err != nil {
+			return nil, nil, err
+		}
+		if modified {
+			found[k].Policy = i.policy
+		}
+		if (found[k].Owner != "" && !i.allowOverwrite) || found[k].Policy == ConflictFail {
+			blocked = append(blocked, found[k])
+		}
+	}
+	if len(blocked) > 0 {
+		reasons := make([]string, 0, len(blocked))
+		for _, c := range blocked {
+			if c.Owner != "" {
+				reasons = append(reasons, fmt.Sprintf("%s (owned by %s)", c.Path, c.Owner))
+			} else {
+				reasons = append(reasons, fmt.Sprintf("%s (modified locally)", c.Path))
+			}
+		}
+		return nil, blocked, errdefs.Errorf(errdefs.ErrFileConflict,
+			"installing %q would overwrite %s", ref, strings.Join(reasons, ", "))
+	}
+
+	var (
+		conflicts []Conflict
+		done      []undo
+	)
+	for k, m := range moves {
+		u, c, err := i.install(m, found[k])
+		if c.Owner != "" || c.Policy != "" {
+			conflicts = append(conflicts, c)
+		}
+		if err != nil {
+			rollback(done)
+			return nil, conflicts, err
+		}
+		done = append(done, u)
+	}
+	for _, u := range done {
+		if u.temporary {
+			// The replaced file is not needed anymore.
+			_ = os.Remove(u.stash)
+		}
+	}
+
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, conflicts, nil
+}
+
+// undo records how to revert the installation of a file: dst is removed, and the file replaced by it,
+// moved to stash, is moved back to restore. A temporary stash is removed once all the files are installed.
+type undo struct {
+	dst       string
+	stash     string
+	restore   string
+	temporary bool
+}
+
+// install moves a file to its destination, applying the policy to the conflict found for it, if any. The file
+// replaced, if any, is stashed so that the installation can be rolled back until all the files are installed.
+func (i *Installer) install(m Move, c Conflict) (undo, Conflict, error) {
+	u := undo{dst: m.Dst}
+	if err := os.MkdirAll(filepath.Dir(m.Dst), 0o750); err != nil {
+		return u, c, err
+	}
+
+	switch c.Policy {
+	case ConflictKeep:
+		u.dst = m.Dst + NewFileSuffix
+		c.Saved = u.dst
+	case ConflictBackup:
+		c.Saved = m.Dst + BackupFileSuffix
+		if err := os.Rename(m.Dst, c.Saved); err != nil {
+			return u, c, fmt.Errorf("unable to back up %q: %w", m.Dst, err)
+		}
+		u.stash, u.restore = c.Saved, m.Dst
+	}
+
+	if u.stash == "" {
+		if _, err := os.Lstat(u.dst); err == nil {
+			u.stash, u.restore, u.temporary = u.dst+stashFileSuffix, u.dst, true
+			if err := os.Rename(u.dst, u.stash); err != nil {
+				return u, c, fmt.Errorf("unable to replace %q: %w", u.dst, err)
+			}
+		}
+	}
+
+	if err := utils.Move(m.Src, u.dst); err != nil {
+		rollback([]undo{u})
+		return u, c, fmt.Errorf("unable to install %q: %w", u.dst, err)
+	}
+	return u, c, nil
+}
+
+// rollback reverts the installation of the given files, in reverse order.
+func rollback(done []undo) {
+	for k := len(done) - 1; k >= 0; k-- {
+		u := done[k]
+		_ = os.Remove(u.dst)
+		if u.stash != "" {
+			_ = os.Rename(u.stash, u.restore)
+		}
+	}
+}
+
+// modified returns true if the file at path exists with a content that is neither the new one nor the recorded one.
+func (i *Installer) modified(path, digest string) (bool, error) {
+	// Symbolic links are not followed, as their digest is the one of their target path.
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil || info.IsDir() {
+		return false, err
+	}
+	current, err := Digest(path)
+	if err != nil {
+		return false, err
+	}
+	if current == digest {
+		return false, nil
+	}
+	recorded, ok := i.recorded[path]
+	return !ok || recorded != current, nil
+}
@@ -0,0 +1,283 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installed
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+)
+
+const testRef = "ghcr.io/falcosecurity/rules/falco-rules:3.1.0"
+
+// setupConflict writes the local file at dst, records the given content for it in a new state file if not
+// empty, and returns the state file along with the file to be installed at dst.
+func setupConflict(t *testing.T, local, recorded string) (stateFile string, m Move) {
+	t.Helper()
+	return setupOwnedConflict(t, "ghcr.io/falcosecurity/rules/falco-rules:3.0.0", local, recorded)
+}
+
+// setupOwnedConflict is like setupConflict, recording the file as owned by the artifact with the given reference.
+func setupOwnedConflict(t *testing.T, owner, local, recorded string) (stateFile string, m Move) {
+	t.Helper()
+	dir := t.TempDir()
+	m = Move{Src: filepath.Join(dir, "tmp", "rules.yaml"), Dst: filepath.Join(dir, "rules", "rules.yaml")}
+	require.NoError(t, os.MkdirAll(filepath.Dir(m.Src), 0o750))
+	require.NoError(t, os.WriteFile(m.Src, []byte("new"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Dir(m.Dst), 0o750))
+	require.NoError(t, os.WriteFile(m.Dst, []byte(local), 0o600))
+
+	stateFile = filepath.Join(dir, "installed.yaml")
+	if recorded == "" {
+		return stateFile, m
+	}
+	recordedFile := filepath.Join(dir, "recorded")
+	require.NoError(t, os.WriteFile(recordedFile, []byte(recorded), 0o600))
+	digest, err := Digest(recordedFile)
+	require.NoError(t, err)
+	require.NoError(t, Update(stateFile, func(s *State) error {
+		s.Set(Artifact{Ref: owner, Files: []File{{Path: m.Dst, Digest: digest}}})
+		return nil
+	}))
+	return stateFile, m
+}
+
+func readFile(t *testing.T, path string) string {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return string(data)
+}
+
+func TestInstallNew(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "rules.yaml")
+	require.NoError(t, os.WriteFile(src, []byte("new"), 0o600))
+	dst := filepath.Join(dir, "rules", "nested", "rules.yaml")
+
+	i, err := NewInstaller("", ConflictFail, false)
+	require.NoError(t, err)
+	files, conflicts, err := i.Install(testRef, []Move{{Src: src, Dst: dst}})
+	require.NoError(t, err)
+	assert.Empty(t, conflicts)
+	require.Len(t, files, 1)
+	assert.Equal(t, dst, files[0].Path)
+	assert.Equal(t, "new", readFile(t, dst))
+
+	digest, err := Digest(dst)
+	require.NoError(t, err)
+	assert.Equal(t, digest, files[0].Digest)
+}
+
+func TestInstallUnmodified(t *testing.T) {
+	t.Run("recorded", func(t *testing.T) {
+		stateFile, m := setupConflict(t, "old", "old")
+		i, err := NewInstaller(stateFile, ConflictFail, false)
+		require.NoError(t, err)
+		_, conflicts, err := i.Install(testRef, []Move{m})
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+		assert.Equal(t, "new", readFile(t, m.Dst))
+	})
+
+	t.Run("identical content", func(t *testing.T) {
+		stateFile, m := setupConflict(t, "new", "")
+		i, err := NewInstaller(stateFile, ConflictFail, false)
+		require.NoError(t, err)
+		_, conflicts, err := i.Install(testRef, []Move{m})
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+		assert.Equal(t, "new", readFile(t, m.Dst))
+	})
+}
+
+func TestInstallModified(t *testing.T) {
+	t.Run("backup", func(t *testing.T) {
+		stateFile, m := setupConflict(t, "local", "old")
+		i, err := NewInstaller(stateFile, "", false)
+		require.NoError(t, err)
+		files, conflicts, err := i.Install(testRef, []Move{m})
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Equal(t, []Conflict{{Path: m.Dst, Policy: ConflictBackup, Saved: m.Dst + BackupFileSuffix}}, conflicts)
+		assert.Equal(t, "new", readFile(t, m.Dst))
+		assert.Equal(t, "local", readFile(t, m.Dst+BackupFileSuffix))
+	})
+
+	t.Run("keep", func(t *testing.T) {
+		stateFile, m := setupConflict(t, "local", "")
+		i, err := NewInstaller(stateFile, ConflictKeep, false)
+		require.NoError(t, err)
+		files, conflicts, err := i.Install(testRef, []Move{m})
+		require.NoError(t, err)
+		assert.Equal(t, []Conflict{{Path: m.Dst, Policy: ConflictKeep, Saved: m.Dst + NewFileSuffix}}, conflicts)
+		assert.Equal(t, "local", readFile(t, m.Dst))
+		assert.Equal(t, "new", readFile(t, m.Dst+NewFileSuffix))
+
+		// The digest of the new content is recorded, so the local file is still modified at the next install.
+		digest, err := Digest(m.Dst + NewFileSuffix)
+		require.NoError(t, err)
+		require.Len(t, files, 1)
+		assert.Equal(t, File{Path: m.Dst, Digest: digest}, files[0])
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		stateFile, m := setupConflict(t, "local", "old")
+		i, err := NewInstaller(stateFile, ConflictFail, false)
+		require.NoError(t, err)
+		_, conflicts, err := i.Install(testRef, []Move{m})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errdefs.ErrFileConflict)
+		assert.Equal(t, []Conflict{{Path: m.Dst, Policy: ConflictFail}}, conflicts)
+		assert.Equal(t, "local", readFile(t, m.Dst))
+		assert.Equal(t, "new", readFile(t, m.Src))
+	})
+}
+
+func TestInstallOwned(t *testing.T) {
+	const owner = "ghcr.io/falcosecurity/plugins/ruleset/k8saudit:0.7.0"
+
+	t.Run("refused", func(t *testing.T) {
+		stateFile, m := setupOwnedConflict(t, owner, "old", "old")
+		i, err := NewInstaller(stateFile, "", false)
+		require.NoError(t, err)
+		_, conflicts, err := i.Install(testRef, []Move{m})
+		require.Error(t, err)
+		assert.ErrorIs(t, err, errdefs.ErrFileConflict)
+		assert.Contains(t, err.Error(), owner)
+		assert.Equal(t, []Conflict{{Path: m.Dst, Owner: owner}}, conflicts)
+		assert.Equal(t, "old", readFile(t, m.Dst))
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		stateFile, m := setupOwnedConflict(t, owner, "old", "old")
+		i, err := NewInstaller(stateFile, ConflictFail, true)
+		require.NoError(t, err)
+		_, conflicts, err := i.Install(testRef, []Move{m})
+		require.NoError(t, err)
+		assert.Equal(t, []Conflict{{Path: m.Dst, Owner: owner}}, conflicts)
+		assert.Equal(t, "new", readFile(t, m.Dst))
+	})
+
+	t.Run("allowed and modified locally", func(t *testing.T) {
+		stateFile, m := setupOwnedConflict(t, owner, "local", "old")
+		i, err := NewInstaller(stateFile, ConflictBackup, true)
+		require.NoError(t, err)
+		_, conflicts, err := i.Install(testRef, []Move{m})
+		require.NoError(t, err)
+		assert.Equal(t, []Conflict{{Path: m.Dst, Owner: owner, Policy: ConflictBackup, Saved: m.Dst + BackupFileSuffix}}, conflicts)
+		assert.Equal(t, "local", readFile(t, m.Dst+BackupFileSuffix))
+	})
+}
+
+func TestInstallSymlink(t *testing.T) {
+	dir := t.TempDir()
+	src := filepath.Join(dir, "tmp", "latest.yaml")
+	require.NoError(t, os.MkdirAll(filepath.Dir(src), 0o750))
+	require.NoError(t, os.Symlink("rules.yaml", src))
+	dst := filepath.Join(dir, "rules", "latest.yaml")
+
+	i, err := NewInstaller("", "", false)
+	require.NoError(t, err)
+	files, _, err := i.Install(testRef, []Move{{Src: src, Dst: dst}})
+	require.NoError(t, err)
+	target, err := os.Readlink(dst)
+	require.NoError(t, err)
+	assert.Equal(t, "rules.yaml", target)
+
+	// The digest of a link is the one of its target path, even if it does not exist.
+	digest, err := Digest(dst)
+	require.NoError(t, err)
+	assert.Equal(t, []File{{Path: dst, Digest: digest}}, files)
+}
+
+func TestInstallRollback(t *testing.T) {
+	stateFile, m := setupConflict(t, "local", "old")
+	dir := filepath.Dir(m.Dst)
+	other := Move{Src: m.Src, Dst: filepath.Join(dir, "installed.yaml")}
+	require.NoError(t, os.WriteFile(other.Dst, []byte("installed"), 0o600))
+	// The last file cannot be installed, since the file it replaces cannot be moved aside.
+	broken := Move{Src: filepath.Join(filepath.Dir(m.Src), "broken.yaml"), Dst: filepath.Join(dir, "broken.yaml")}
+	require.NoError(t, os.WriteFile(broken.Src, []byte("new"), 0o600))
+	require.NoError(t, os.Mkdir(broken.Dst, 0o750))
+	require.NoError(t, os.MkdirAll(filepath.Join(broken.Dst+stashFileSuffix, "dir"), 0o750))
+	m.Src = filepath.Join(filepath.Dir(m.Src), "rules.yaml.new")
+	require.NoError(t, os.WriteFile(m.Src, []byte("new"), 0o600))
+
+	i, err := NewInstaller(stateFile, ConflictBackup, false)
+	require.NoError(t, err)
+	_, _, err = i.Install(testRef, []Move{m, other, broken})
+	require.Error(t, err)
+
+	// The files already installed are rolled back.
+	assert.Equal(t, "local", readFile(t, m.Dst))
+	assert.Equal(t, "installed", readFile(t, other.Dst))
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Name())
+	}
+	assert.ElementsMatch(t, []string{"rules.yaml", "installed.yaml", "broken.yaml", "broken.yaml" + stashFileSuffix}, names)
+}
+
+func TestTransaction(t *testing.T) {
+	const owner = "ghcr.io/falcosecurity/plugins/ruleset/k8saudit:0.7.0"
+
+	t.Run("recorded", func(t *testing.T) {
+		stateFile, m := setupConflict(t, "old", "old")
+		err := Transaction(stateFile, "", false, func(i *Installer) (Artifact, error) {
+			files, _, err := i.Install(testRef, []Move{m})
+			return Artifact{Ref: testRef, Files: files}, err
+		})
+		require.NoError(t, err)
+		state, err := Load(stateFile)
+		require.NoError(t, err)
+		a, ok := state.Owner(m.Dst)
+		require.True(t, ok)
+		assert.Equal(t, testRef, a.Ref)
+		assert.Equal(t, "new", readFile(t, m.Dst))
+	})
+
+	t.Run("not recorded on conflict", func(t *testing.T) {
+		stateFile, m := setupOwnedConflict(t, owner, "old", "old")
+		err := Transaction(stateFile, "", false, func(i *Installer) (Artifact, error) {
+			files, _, err := i.Install(testRef, []Move{m})
+			return Artifact{Ref: testRef, Files: files}, err
+		})
+		assert.ErrorIs(t, err, errdefs.ErrFileConflict)
+		state, err := Load(stateFile)
+		require.NoError(t, err)
+		a, ok := state.Owner(m.Dst)
+		require.True(t, ok)
+		assert.Equal(t, owner, a.Ref)
+	})
+}
+
+func TestValidateConflictPolicy(t *testing.T) {
+	for _, policy := range append([]string{""}, ConflictPolicies...) {
+		assert.NoError(t, ValidateConflictPolicy(policy))
+	}
+	assert.Error(t, ValidateConflictPolicy("overwrite"))
+
+	_, err := NewInstaller("", "overwrite", false)
+	assert.Error(t, err)
+}
@@ -0,0 +1,240 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installed
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+)
+
+// Artifact is an artifact installed by falcoctl.
+type Artifact struct {
+	// Ref is the reference the artifact was installed from.
+	Ref          string                   `yaml:"ref"`
+	Name         string                   `yaml:"name"`
+	Version      string                   `yaml:"version,omitempty"`
+	Type         string                   `yaml:"type"`
+	Digest       string                   `yaml:"digest"`
+	Dependencies []oci.ArtifactDependency `yaml:"dependencies,omitempty"`
+	InstalledAt  time.Time                `yaml:"installedAt"`
+	Files        []File                   `yaml:"files,omitempty"`
+}
+
+// File is a file written by falcoctl while installing an artifact.
+type File struct {
+	Path   string `yaml:"path"`
+	Digest string `yaml:"digest"`
+}
+
+// State is the content of the state file.
+type State struct {
+	Artifacts []Artifact `yaml:"artifacts"`
+}
+
+// mu serializes the updates of the state files within the process, e.g. the ones of concurrent followers.
+var mu sync.Mutex
+
+// lockFileSuffix is appended to the path of a state file to lock it across processes.
+const lockFileSuffix = ".lock"
+
+// lock serializes the updates of the state file at path, within the process and across processes. It returns
+// the function releasing the lock.
+func lock(path string) (func(), error) {
+	mu.Lock()
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		mu.Unlock()
+		return nil, fmt.Errorf("unable to create directory of state file %q: %w", path, err)
+	}
+	f, err := os.OpenFile(filepath.Clean(path+lockFileSuffix), os.O_CREATE|os.O_RDWR, 0o600)
+	if err != nil {
+		mu.Unlock()
+		return nil, fmt.Errorf("unable to lock state file %q: %w", path, err)
+	}
+	if err := lockFile(f); err != nil {
+		_ = f.Close()
+		mu.Unlock()
+		return nil, fmt.Errorf("unable to lock state file %q: %w", path, err)
+	}
+	return func() {
+		_ = unlockFile(f)
+		_ = f.Close()
+		mu.Unlock()
+	}, nil
+}
+
+// Load reads the state file. A missing file yields an empty state.
+func Load(path string) (*State, error) {
+	state := &State{}
+	data, err := os.ReadFile(filepath.Clean(path))
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read state file %q: %w", path, err)
+	}
+	if err := yaml.Unmarshal(data, state); err != nil {
+		return nil, fmt.Errorf("unable to parse state file %q: %w", path, err)
+	}
+	return state, nil
+}
+
+// Write writes the state file, atomically replacing the previous one.
+func (s *State) Write(path string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("unable to marshal state: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("unable to create directory of state file %q: %w", path, err)
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return fmt.Errorf("unable to write state file %q: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return fmt.Errorf("unable to write state file %q: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("unable to write state file %q: %w", path, err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("unable to write state file %q: %w", path, err)
+	}
+	return nil
+}
+
+// Update loads the state file, applies fn and writes the result.
+func Update(path string, fn func(*State) error) error {
+	unlock, err := lock(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	state, err := Load(path)
+	if err != nil {
+		return err
+	}
+	if err := fn(state); err != nil {
+		return err
+	}
+	return state.Write(path)
+}
+
+// Set records an installed artifact, replacing the one installed from the same repository, if any.
+// The artifact becomes the owner of its files, which are dropped from the other artifacts.
+func (s *State) Set(artifact Artifact) {
+	paths := make(map[string]bool, len(artifact.Files))
+	for _, f := range artifact.Files {
+		paths[f.Path] = true
+	}
+	repo := repository(artifact.Ref)
+	replaced := false
+	for i := range s.Artifacts {
+		a := &s.Artifacts[i]
+		if repository(a.Ref) == repo {
+			*a = artifact
+			replaced = true
+			continue
+		}
+		a.Files = slices.DeleteFunc(a.Files, func(f File) bool { return paths[f.Path] })
+	}
+	if replaced {
+		return
+	}
+	s.Artifacts = append(s.Artifacts, artifact)
+	sort.Slice(s.Artifacts, func(i, j int) bool { return s.Artifacts[i].Ref < s.Artifacts[j].Ref })
+}
+
+// Owner returns the installed artifact owning the file at path, i.e. the last one that wrote it.
+func (s *State) Owner(path string) (*Artifact, bool) {
+	for i := range s.Artifacts {
+		for _, f := range s.Artifacts[i].Files {
+			if f.Path == path {
+				return &s.Artifacts[i], true
+			}
+		}
+	}
+	return nil, false
+}
+
+// ByName returns the installed artifact with the given name.
+func (s *State) ByName(name string) (*Artifact, bool) {
+	for i := range s.Artifacts {
+		if s.Artifacts[i].Name == name {
+			return &s.Artifacts[i], true
+		}
+	}
+	return nil, false
+}
+
+// repository returns the repository of a reference, or the reference itself if it cannot be parsed.
+func repository(ref string) string {
+	if repo, err := utils.RepositoryFromRef(ref); err == nil {
+		return repo
+	}
+	return ref
+}
+
+// NewFiles hashes the given installed files.
+func NewFiles(paths []string) ([]File, error) {
+	files := make([]File, 0, len(paths))
+	for _, path := range paths {
+		digest, err := Digest(path)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, File{Path: path, Digest: digest})
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].Path < files[j].Path })
+	return files, nil
+}
+
+// Digest returns the sha256 digest of a file, in the "sha256:<hex>" format. The digest of a symbolic link
+// is the one of its target path, so that links are not followed.
+func Digest(path string) (string, error) {
+	if target, err := os.Readlink(path); err == nil {
+		sum := sha256.Sum256([]byte(target))
+		return "sha256:" + hex.EncodeToString(sum[:]), nil
+	}
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return "", fmt.Errorf("unable to open %q: %w", path, err)
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", fmt.Errorf("unable to read %q: %w", path, err)
+	}
+	return "sha256:" + hex.EncodeToString(h.Sum(nil)), nil
+}
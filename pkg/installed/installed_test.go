@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package installed
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+)
+
+func TestLoadMissing(t *testing.T) {
+	state, err := Load(filepath.Join(t.TempDir(), "installed.yaml"))
+	require.NoError(t, err)
+	assert.Empty(t, state.Artifacts)
+}
+
+func TestSet(t *testing.T) {
+	state := &State{}
+	state.Set(Artifact{Ref: "ghcr.io/falcosecurity/rules/k8saudit-rules:0.7.0", Name: "k8saudit-rules"})
+	state.Set(Artifact{Ref: "ghcr.io/falcosecurity/rules/falco-rules:3.0.0", Name: "falco-rules", Version: "3.0.0"})
+	state.Set(Artifact{Ref: "ghcr.io/falcosecurity/rules/falco-rules:3.1.0", Name: "falco-rules", Version: "3.1.0"})
+
+	require.Len(t, state.Artifacts, 2)
+	assert.Equal(t, "falco-rules", state.Artifacts[0].Name)
+	assert.Equal(t, "3.1.0", state.Artifacts[0].Version)
+	assert.Equal(t, "k8saudit-rules", state.Artifacts[1].Name)
+
+	a, ok := state.ByName("falco-rules")
+	require.True(t, ok)
+	assert.Equal(t, "ghcr.io/falcosecurity/rules/falco-rules:3.1.0", a.Ref)
+	_, ok = state.ByName("missing")
+	assert.False(t, ok)
+}
+
+func TestOwner(t *testing.T) {
+	state := &State{}
+	state.Set(Artifact{Ref: "ghcr.io/falcosecurity/rules/falco-rules:3.0.0", Name: "falco-rules",
+		Files: []File{{Path: "/etc/falco/falco_rules.yaml"}, {Path: "/etc/falco/macros.yaml"}}})
+	state.Set(Artifact{Ref: "ghcr.io/example/rules/my-rules:1.0.0", Name: "my-rules",
+		Files: []File{{Path: "/etc/falco/macros.yaml"}, {Path: "/etc/falco/my_rules.yaml"}}})
+
+	// The artifact recorded last owns the shared file.
+	a, ok := state.Owner("/etc/falco/macros.yaml")
+	require.True(t, ok)
+	assert.Equal(t, "my-rules", a.Name)
+	a, ok = state.Owner("/etc/falco/falco_rules.yaml")
+	require.True(t, ok)
+	assert.Equal(t, "falco-rules", a.Name)
+	assert.Equal(t, []File{{Path: "/etc/falco/falco_rules.yaml"}}, a.Files)
+	_, ok = state.Owner("/etc/falco/missing.yaml")
+	assert.False(t, ok)
+
+	// Upgrading an artifact keeps the ownership of its files.
+	state.Set(Artifact{Ref: "ghcr.io/example/rules/my-rules:1.1.0", Name: "my-rules",
+		Files: []File{{Path: "/etc/falco/macros.yaml"}}})
+	a, ok = state.Owner("/etc/falco/macros.yaml")
+	require.True(t, ok)
+	assert.Equal(t, "ghcr.io/example/rules/my-rules:1.1.0", a.Ref)
+	_, ok = state.Owner("/etc/falco/my_rules.yaml")
+	assert.False(t, ok)
+}
+
+func TestUpdate(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "falcoctl", "installed.yaml")
+	artifact := Artifact{
+		Ref:          "ghcr.io/falcosecurity/plugins/plugin/cloudtrail:0.9.0",
+		Name:         "cloudtrail",
+		Version:      "0.9.0",
+		Type:         "plugin",
+		Digest:       "sha256:0123",
+		Dependencies: []oci.ArtifactDependency{{Name: "json", Version: "0.7.0"}},
+		Files:        []File{{Path: "/usr/share/falco/plugins/libcloudtrail.so", Digest: "sha256:4567"}},
+	}
+
+	require.NoError(t, Update(path, func(s *State) error {
+		s.Set(artifact)
+		return nil
+	}))
+	assert.Error(t, Update(path, func(s *State) error {
+		return assert.AnError
+	}))
+
+	state, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, state.Artifacts, 1)
+	assert.Equal(t, artifact.Name, state.Artifacts[0].Name)
+	require.Len(t, state.Artifacts[0].Dependencies, 1)
+	assert.Equal(t, "json", state.Artifacts[0].Dependencies[0].Name)
+	assert.Equal(t, "0.7.0", state.Artifacts[0].Dependencies[0].Version)
+	assert.Equal(t, artifact.Files, state.Artifacts[0].Files)
+}
+
+func TestNewFiles(t *testing.T) {
+	dir := t.TempDir()
+	b := filepath.Join(dir, "b.yaml")
+	a := filepath.Join(dir, "a.yaml")
+	require.NoError(t, os.WriteFile(b, []byte("b"), 0o600))
+	require.NoError(t, os.WriteFile(a, []byte(""), 0o600))
+
+	files, err := NewFiles([]string{b, a})
+	require.NoError(t, err)
+	assert.Equal(t, []File{
+		{Path: a, Digest: "sha256:e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"},
+		{Path: b, Digest: "sha256:3e23e8160039594a33894f6564e1b1348bbd7a0088d42c4acb73eeaed59c009d"},
+	}, files)
+
+	_, err = NewFiles([]string{filepath.Join(dir, "missing")})
+	assert.Error(t, err)
+}
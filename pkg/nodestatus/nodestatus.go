@@ -0,0 +1,333 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodestatus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/util/retry"
+
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const (
+	// LabelDriverType is the node label holding the type of the loaded driver.
+	LabelDriverType = "falco.org/driver-type"
+	// LabelDriverVersion is the node label holding the version of the loaded driver.
+	LabelDriverVersion = "falco.org/driver-version"
+	// AnnotationDriver is the node annotation holding the JSON driver status.
+	AnnotationDriver = "falco.org/driver"
+	// AnnotationArtifacts is the node annotation holding the JSON installed artifacts, by reference.
+	AnnotationArtifacts = "falco.org/artifacts"
+	// AnnotationFollower is the node annotation holding the JSON follower status, by followed reference.
+	AnnotationFollower = "falco.org/follower"
+	// AnnotationDesired is the node annotation holding the JSON artifacts the node-local falcoctl
+	// instances must install, as digest references by FalcoArtifactSet ("namespace/name").
+	AnnotationDesired = "falco.org/desired-artifacts"
+
+	// DefaultMinInterval is the default minimum interval between two updates of the node.
+	DefaultMinInterval = time.Minute
+)
+
+// Health values.
+const (
+	Healthy   = "healthy"
+	Unhealthy = "unhealthy"
+)
+
+// invalidLabelChars matches the characters not allowed in label values.
+var invalidLabelChars = regexp.MustCompile(`[^A-Za-z0-9_.-]`)
+
+// Driver is the status of the driver loaded on the node.
+type Driver struct {
+	Type    string `json:"type"`
+	Version string `json:"version"`
+	Health  string `json:"health"`
+	Error   string `json:"error,omitempty"`
+}
+
+// Artifact is an artifact installed on the node.
+type Artifact struct {
+	Ref     string `json:"ref"`
+	Type    string `json:"type"`
+	Version string `json:"version,omitempty"`
+	Digest  string `json:"digest"`
+	// RootDigest is the digest of the root descriptor of the artifact, the one of its image index if any,
+	// as resolved from a tag and found in the digest references.
+	RootDigest  string    `json:"rootDigest,omitempty"`
+	InstalledAt time.Time `json:"installedAt"`
+}
+
+// Follower is the status of the follower of an artifact running on the node.
+type Follower struct {
+	LastSync time.Time `json:"lastSync"`
+	Health   string    `json:"health"`
+	Error    string    `json:"error,omitempty"`
+}
+
+// Publisher publishes the status of a node. Updates are rate limited: an update arriving
+// less than the minimum interval after the previous one is published once the interval has elapsed.
+// A nil Publisher is valid and publishes nothing.
+type Publisher struct {
+	client      kubernetes.Interface
+	nodeName    string
+	printer     *output.Printer
+	minInterval time.Duration
+
+	mu        sync.Mutex
+	driver    *Driver
+	artifacts map[string]Artifact
+	followers map[string]Follower
+	dirty     bool
+	last      time.Time
+	timer     *time.Timer
+}
+
+// NewPublisher returns a new Publisher updating the given node.
+func NewPublisher(client kubernetes.Interface, nodeName string, printer *output.Printer, minInterval time.Duration) *Publisher {
+	return &Publisher{
+		client:      client,
+		nodeName:    nodeName,
+		printer:     printer,
+		minInterval: minInterval,
+		artifacts:   make(map[string]Artifact),
+		followers:   make(map[string]Follower),
+	}
+}
+
+// NewClient returns a Kubernetes client using the given kubeconfig, or the in-cluster config if empty.
+func NewClient(kubeconfig string) (kubernetes.Interface, error) {
+	cfg, err := RestConfig(kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+	return kubernetes.NewForConfig(cfg)
+}
+
+// RestConfig loads the given kubeconfig, or the in-cluster config if empty.
+func RestConfig(kubeconfig string) (*rest.Config, error) {
+	var cfg *rest.Config
+	var err error
+	if kubeconfig != "" {
+		cfg, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
+	} else {
+		cfg, err = rest.InClusterConfig()
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to load Kubernetes config: %w", err)
+	}
+	return cfg, nil
+}
+
+// Desired returns the content of the desired artifacts annotation of a node, by FalcoArtifactSet.
+func Desired(node *corev1.Node) map[string][]string {
+	entries := make(map[string][]string)
+	if value, ok := node.Annotations[AnnotationDesired]; ok {
+		// Ignore malformed content, it is going to be overwritten.
+		_ = json.Unmarshal([]byte(value), &entries)
+	}
+	return entries
+}
+
+// DesiredRefs returns the sorted digest references the node-local falcoctl instances must install on the given node.
+func DesiredRefs(ctx context.Context, client kubernetes.Interface, nodeName string) ([]string, error) {
+	node, err := client.CoreV1().Nodes().Get(ctx, nodeName, metav1.GetOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to get node %q: %w", nodeName, err)
+	}
+	var refs []string
+	for _, setRefs := range Desired(node) {
+		for _, ref := range setRefs {
+			if !slices.Contains(refs, ref) {
+				refs = append(refs, ref)
+			}
+		}
+	}
+	slices.Sort(refs)
+	return refs, nil
+}
+
+// SetDriver records the status of the loaded driver.
+func (p *Publisher) SetDriver(ctx context.Context, driver Driver) {
+	if p == nil {
+		return
+	}
+	p.update(ctx, func() { p.driver = &driver })
+}
+
+// SetArtifact records an installed artifact.
+func (p *Publisher) SetArtifact(ctx context.Context, artifact Artifact) {
+	if p == nil {
+		return
+	}
+	p.update(ctx, func() { p.artifacts[artifact.Ref] = artifact })
+}
+
+// SetFollower records the status of the follower of the given reference.
+func (p *Publisher) SetFollower(ctx context.Context, ref string, follower Follower) {
+	if p == nil {
+		return
+	}
+	p.update(ctx, func() { p.followers[ref] = follower })
+}
+
+// Flush publishes the pending updates, if any, without waiting for the minimum interval.
+func (p *Publisher) Flush(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.timer != nil {
+		p.timer.Stop()
+		p.timer = nil
+	}
+	return p.publish(ctx)
+}
+
+func (p *Publisher) update(ctx context.Context, set func()) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	set()
+	p.dirty = true
+
+	if wait := p.minInterval - time.Since(p.last); wait > 0 {
+		if p.timer == nil {
+			p.timer = time.AfterFunc(wait, func() {
+				p.mu.Lock()
+				defer p.mu.Unlock()
+				p.timer = nil
+				p.logErr(p.publish(context.WithoutCancel(ctx)))
+			})
+		}
+		return
+	}
+	p.logErr(p.publish(ctx))
+}
+
+func (p *Publisher) logErr(err error) {
+	if err != nil && p.printer != nil {
+		p.printer.Logger.Warn("Unable to publish node status", p.printer.Logger.Args("node", p.nodeName, "reason", err.Error()))
+	}
+}
+
+// publish patches the labels and annotations of the node with the recorded status. It must be called
+// with the lock held. The annotations holding maps are read and merged on each attempt, and the patch is
+// conditional on the read resourceVersion, so that concurrent updates, ie: from other falcoctl instances
+// running on the same node, are retained.
+func (p *Publisher) publish(ctx context.Context) error {
+	if !p.dirty {
+		return nil
+	}
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		node, err := p.client.CoreV1().Nodes().Get(ctx, p.nodeName, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		labels := make(map[string]string)
+		annotations := make(map[string]string)
+		if p.driver != nil {
+			labels[LabelDriverType] = labelValue(p.driver.Type)
+			labels[LabelDriverVersion] = labelValue(p.driver.Version)
+			if err := setJSON(annotations, AnnotationDriver, p.driver); err != nil {
+				return err
+			}
+		}
+		if err := mergeJSON(node.Annotations, annotations, AnnotationArtifacts, p.artifacts); err != nil {
+			return err
+		}
+		if err := mergeJSON(node.Annotations, annotations, AnnotationFollower, p.followers); err != nil {
+			return err
+		}
+
+		patch, err := json.Marshal(map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"resourceVersion": node.ResourceVersion,
+				"labels":          labels,
+				"annotations":     annotations,
+			},
+		})
+		if err != nil {
+			return fmt.Errorf("unable to marshal node patch: %w", err)
+		}
+		_, err = p.client.CoreV1().Nodes().Patch(ctx, p.nodeName, types.MergePatchType, patch, metav1.PatchOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("unable to patch node %q: %w", p.nodeName, err)
+	}
+	p.dirty = false
+	p.last = time.Now()
+	return nil
+}
+
+// mergeJSON merges the given entries into the JSON object held by the current annotation,
+// and sets the result in annotations.
+func mergeJSON[T any](current, annotations map[string]string, key string, entries map[string]T) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	merged := make(map[string]T)
+	if current, ok := current[key]; ok {
+		// Ignore malformed content, it is going to be overwritten.
+		_ = json.Unmarshal([]byte(current), &merged)
+	}
+	for k, v := range entries {
+		merged[k] = v
+	}
+	return setJSON(annotations, key, merged)
+}
+
+func setJSON(annotations map[string]string, key string, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("unable to marshal %q annotation: %w", key, err)
+	}
+	annotations[key] = string(data)
+	return nil
+}
+
+// labelValue turns the given string into a valid label value.
+func labelValue(s string) string {
+	s = invalidLabelChars.ReplaceAllString(s, "_")
+	if len(s) > 63 {
+		s = s[:63]
+	}
+	for len(s) > 0 && !isAlphanumeric(s[0]) {
+		s = s[1:]
+	}
+	for len(s) > 0 && !isAlphanumeric(s[len(s)-1]) {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+func isAlphanumeric(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
@@ -0,0 +1,147 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package nodestatus
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+const nodeName = "node-1"
+
+func getNode(t *testing.T, client *fake.Clientset) *corev1.Node {
+	node, err := client.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+	require.NoError(t, err)
+	return node
+}
+
+func TestPublisher(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:   nodeName,
+			Labels: map[string]string{"kubernetes.io/os": "linux"},
+			// Artifacts published by another falcoctl instance are retained.
+			Annotations: map[string]string{AnnotationArtifacts: `{"ghcr.io/falcosecurity/plugins/k8saudit:0.7":` +
+				`{"ref":"ghcr.io/falcosecurity/plugins/k8saudit:0.7","type":"plugin","digest":"sha256:aaa","installedAt":"2025-01-01T00:00:00Z"}}`},
+		},
+	})
+	p := NewPublisher(client, nodeName, nil, 0)
+
+	p.SetDriver(ctx, Driver{Type: "kmod", Version: "7.0.0+driver", Health: Healthy})
+	node := getNode(t, client)
+	assert.Equal(t, "kmod", node.Labels[LabelDriverType])
+	assert.Equal(t, "7.0.0_driver", node.Labels[LabelDriverVersion])
+	var driver Driver
+	require.NoError(t, json.Unmarshal([]byte(node.Annotations[AnnotationDriver]), &driver))
+	assert.Equal(t, "7.0.0+driver", driver.Version)
+
+	ref := "ghcr.io/falcosecurity/rules/falco-rules:3"
+	p.SetArtifact(ctx, Artifact{Ref: ref, Type: "rulesfile", Version: "3.0.0", Digest: "sha256:bbb"})
+	p.SetFollower(ctx, ref, Follower{Health: Unhealthy, Error: "unable to pull"})
+	node = getNode(t, client)
+	var artifacts map[string]Artifact
+	require.NoError(t, json.Unmarshal([]byte(node.Annotations[AnnotationArtifacts]), &artifacts))
+	assert.Len(t, artifacts, 2)
+	assert.Equal(t, "3.0.0", artifacts[ref].Version)
+	var followers map[string]Follower
+	require.NoError(t, json.Unmarshal([]byte(node.Annotations[AnnotationFollower]), &followers))
+	assert.Equal(t, Unhealthy, followers[ref].Health)
+
+	// The node is only patched, retaining the labels not owned by the publisher.
+	assert.Equal(t, "linux", node.Labels["kubernetes.io/os"])
+	for _, action := range client.Actions() {
+		assert.NotEqual(t, "update", action.GetVerb())
+	}
+}
+
+func TestPublisherRateLimit(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})
+	p := NewPublisher(client, nodeName, nil, time.Hour)
+
+	// The first update is published right away, the following ones wait for the interval.
+	p.SetDriver(ctx, Driver{Type: "kmod", Version: "7.0.0+driver", Health: Healthy})
+	p.SetDriver(ctx, Driver{Type: "ebpf", Version: "7.0.0+driver", Health: Healthy})
+	assert.Equal(t, "kmod", getNode(t, client).Labels[LabelDriverType])
+
+	require.NoError(t, p.Flush(ctx))
+	assert.Equal(t, "ebpf", getNode(t, client).Labels[LabelDriverType])
+}
+
+func TestPublisherConflict(t *testing.T) {
+	ctx := context.Background()
+	client := fake.NewSimpleClientset(&corev1.Node{ObjectMeta: metav1.ObjectMeta{Name: nodeName}})
+	conflicts := 1
+	client.PrependReactor("patch", "nodes", func(k8stesting.Action) (bool, runtime.Object, error) {
+		if conflicts > 0 {
+			conflicts--
+			return true, nil, apierrors.NewConflict(schema.GroupResource{Resource: "nodes"}, nodeName, nil)
+		}
+		return false, nil, nil
+	})
+	p := NewPublisher(client, nodeName, nil, 0)
+
+	p.SetDriver(ctx, Driver{Type: "kmod", Version: "7.0.0+driver", Health: Healthy})
+	assert.Equal(t, "kmod", getNode(t, client).Labels[LabelDriverType])
+	assert.Zero(t, conflicts)
+}
+
+func TestNilPublisher(t *testing.T) {
+	var p *Publisher
+	p.SetDriver(context.Background(), Driver{})
+	assert.NoError(t, p.Flush(context.Background()))
+}
+
+func TestDesiredRefs(t *testing.T) {
+	const (
+		rules  = "ghcr.io/falcosecurity/rules/falco-rules@sha256:aaa"
+		plugin = "ghcr.io/falcosecurity/plugins/k8saudit@sha256:bbb"
+	)
+	client := fake.NewSimpleClientset(&corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: nodeName,
+			Annotations: map[string]string{AnnotationDesired: `{"falco/rules":["` + rules + `"],` +
+				`"falco/all":["` + rules + `","` + plugin + `"]}`},
+		},
+	})
+
+	refs, err := DesiredRefs(context.Background(), client, nodeName)
+	require.NoError(t, err)
+	assert.Equal(t, []string{plugin, rules}, refs)
+
+	_, err = DesiredRefs(context.Background(), client, "unknown")
+	assert.Error(t, err)
+}
+
+func TestLabelValue(t *testing.T) {
+	assert.Equal(t, "7.0.0_driver", labelValue("7.0.0+driver"))
+	assert.Equal(t, "abc", labelValue("-abc_"))
+	assert.Len(t, labelValue(strings.Repeat("a", 70)), 63)
+}
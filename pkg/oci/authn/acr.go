@@ -0,0 +1,136 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/policy"
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/golang-jwt/jwt"
+	"golang.org/x/exp/slices"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/pkg/tracing"
+)
+
+const (
+	// ACRUsername is the username to authenticate to ACR with a refresh token.
+	// See https://learn.microsoft.com/en-us/azure/container-registry/container-registry-authentication#az-acr-login-with---expose-token
+	ACRUsername = "00000000-0000-0000-0000-000000000000"
+
+	// acrScope is the scope of the Microsoft Entra ID tokens exchanged for ACR refresh tokens.
+	acrScope = "https://containerregistry.azure.net/.default"
+)
+
+var (
+	// acrTokens caches the refresh tokens for all registries using acr credentials.
+	acrTokens = newCloudTokenCache(ACRToken)
+
+	// acrHTTPClient is the client used to exchange the Microsoft Entra ID tokens with the registries.
+	acrHTTPClient = &http.Client{
+		Transport: tracing.Transport(http.DefaultTransport),
+		Timeout:   30 * time.Second,
+	}
+)
+
+// ACRToken retrieves a refresh token for the given ACR registry, along with its expiry, exchanging a
+// Microsoft Entra ID token obtained with the Azure default credentials chain: environment variables,
+// workload identity, managed identity and Azure CLI.
+func ACRToken(ctx context.Context, reg string) (auth.Credential, time.Time, error) {
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return auth.EmptyCredential, time.Time{}, fmt.Errorf("unable to find Azure credentials: %w", err)
+	}
+
+	aadToken, err := cred.GetToken(ctx, policy.TokenRequestOptions{Scopes: []string{acrScope}})
+	if err != nil {
+		return auth.EmptyCredential, time.Time{}, fmt.Errorf("unable to get Microsoft Entra ID token: %w", err)
+	}
+
+	refreshToken, err := exchangeACRToken(ctx, reg, aadToken.Token)
+	if err != nil {
+		return auth.EmptyCredential, time.Time{}, err
+	}
+
+	// The refresh token is a JWT: its expiry is read from its claims, falling back to the expiry of
+	// the exchanged token.
+	expiry := aadToken.ExpiresOn
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(refreshToken, claims); err == nil {
+		if exp, ok := claims["exp"].(float64); ok {
+			expiry = time.Unix(int64(exp), 0)
+		}
+	}
+
+	return auth.Credential{Username: ACRUsername, Password: refreshToken}, expiry, nil
+}
+
+// exchangeACRToken exchanges a Microsoft Entra ID token for a refresh token of the registry.
+func exchangeACRToken(ctx context.Context, reg, aadToken string) (string, error) {
+	form := url.Values{
+		"grant_type":   {"access_token"},
+		"service":      {reg},
+		"access_token": {aadToken},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://"+reg+"/oauth2/exchange", strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := acrHTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("unable to exchange Microsoft Entra ID token with registry %q: %w", reg, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("unable to exchange Microsoft Entra ID token with registry %q: %s", reg, resp.Status)
+	}
+
+	var body struct {
+		RefreshToken string `json:"refresh_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("unable to decode the token exchange response of registry %q: %w", reg, err)
+	}
+	if body.RefreshToken == "" {
+		return "", fmt.Errorf("no refresh token returned by registry %q", reg)
+	}
+	return body.RefreshToken, nil
+}
+
+// ACRCredential retrieves a valid refresh token from the Azure credentials to perform registry authentication.
+func ACRCredential(ctx context.Context, reg string) (auth.Credential, error) {
+	acrAuths, err := config.Acrs()
+	if err != nil {
+		return auth.EmptyCredential, fmt.Errorf("unable to retrieve acr authentication config %w", err)
+	}
+
+	// acr auth not set for this registry
+	if !slices.ContainsFunc(acrAuths, func(c config.AcrAuth) bool { return c.Registry == reg }) {
+		return auth.EmptyCredential, nil
+	}
+
+	return acrTokens.credential(ctx, reg)
+}
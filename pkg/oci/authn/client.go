@@ -22,8 +22,11 @@ import (
 	"net/http"
 	"time"
 
+	"go.opentelemetry.io/otel/attribute"
 	"oras.land/oras-go/v2/registry/remote/auth"
 	"oras.land/oras-go/v2/registry/remote/credentials"
+
+	"github.com/falcosecurity/falcoctl/pkg/tracing"
 )
 
 const (
@@ -72,10 +75,13 @@ func NewClient(options ...func(*Options)) *auth.Client {
 
 	authClient := auth.Client{
 		Client: &http.Client{
-			Transport: transport,
+			Transport: tracing.Transport(transport),
 		},
 		Cache: opt.ClientTokenCache,
-		Credential: func(ctx context.Context, reg string) (auth.Credential, error) {
+		Credential: func(ctx context.Context, reg string) (_ auth.Credential, err error) {
+			ctx, span := tracing.Start(ctx, "authn.Credential", attribute.String("registry", reg))
+			defer func() { tracing.End(span, err) }()
+
 			// try cred func from cache first
 			credFunc, exists := opt.CredentialsFuncsCache[reg]
 			if exists {
@@ -139,6 +145,20 @@ func WithGcpCredentials() func(c *Options) {
 	}
 }
 
+// WithEcrCredentials adds the Amazon ECR source to the client.
+func WithEcrCredentials() func(c *Options) {
+	return func(c *Options) {
+		c.CredentialsFuncs = append(c.CredentialsFuncs, ECRCredential)
+	}
+}
+
+// WithAcrCredentials adds the Azure ACR source to the client.
+func WithAcrCredentials() func(c *Options) {
+	return func(c *Options) {
+		c.CredentialsFuncs = append(c.CredentialsFuncs, ACRCredential)
+	}
+}
+
 // WithCredentials adds a static credential function to the client.
 func WithCredentials(cred *auth.Credential) func(c *Options) {
 	return func(c *Options) {
@@ -0,0 +1,70 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+// cloudTokenRefreshWindow is how long before its expiry a registry token obtained from a cloud provider
+// is refreshed, so that long-running followers never present an expired token.
+const cloudTokenRefreshWindow = 5 * time.Minute
+
+// cloudTokenFunc obtains a registry credential, and its expiry, from the ambient cloud identity.
+type cloudTokenFunc func(ctx context.Context, reg string) (auth.Credential, time.Time, error)
+
+type cloudToken struct {
+	credential auth.Credential
+	expiry     time.Time
+}
+
+// cloudTokenCache caches the registry credentials obtained from a cloud provider, per registry,
+// refreshing them shortly before they expire.
+type cloudTokenCache struct {
+	mu     sync.Mutex
+	tokens map[string]cloudToken
+	fetch  cloudTokenFunc
+	now    func() time.Time
+}
+
+func newCloudTokenCache(fetch cloudTokenFunc) *cloudTokenCache {
+	return &cloudTokenCache{
+		tokens: make(map[string]cloudToken),
+		fetch:  fetch,
+		now:    time.Now,
+	}
+}
+
+// credential returns the cached credential for reg, obtaining a new one if it is missing or about to expire.
+func (c *cloudTokenCache) credential(ctx context.Context, reg string) (auth.Credential, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if token, ok := c.tokens[reg]; ok && token.expiry.Sub(c.now()) > cloudTokenRefreshWindow {
+		return token.credential, nil
+	}
+
+	cred, expiry, err := c.fetch(ctx, reg)
+	if err != nil {
+		return auth.EmptyCredential, err
+	}
+	c.tokens[reg] = cloudToken{credential: cred, expiry: expiry}
+	return cred, nil
+}
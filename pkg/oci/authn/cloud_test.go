@@ -0,0 +1,174 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/registry/remote/auth"
+)
+
+func TestCloudTokenCache(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	fetches := 0
+	cache := newCloudTokenCache(func(_ context.Context, reg string) (auth.Credential, time.Time, error) {
+		fetches++
+		if reg == "broken" {
+			return auth.EmptyCredential, time.Time{}, errors.New("no identity")
+		}
+		return auth.Credential{Username: reg, Password: fmt.Sprint(fetches)}, now.Add(time.Hour), nil
+	})
+	cache.now = func() time.Time { return now }
+
+	cred, err := cache.credential(ctx, "reg")
+	require.NoError(t, err)
+	assert.Equal(t, "1", cred.Password)
+
+	// The token is reused while it is far from its expiry.
+	now = now.Add(50 * time.Minute)
+	cred, err = cache.credential(ctx, "reg")
+	require.NoError(t, err)
+	assert.Equal(t, "1", cred.Password)
+
+	// The token is refreshed shortly before it expires.
+	now = now.Add(6 * time.Minute)
+	cred, err = cache.credential(ctx, "reg")
+	require.NoError(t, err)
+	assert.Equal(t, "2", cred.Password)
+
+	// Tokens are cached per registry.
+	cred, err = cache.credential(ctx, "other")
+	require.NoError(t, err)
+	assert.Equal(t, "3", cred.Password)
+
+	_, err = cache.credential(ctx, "broken")
+	require.Error(t, err)
+}
+
+func TestECRRegion(t *testing.T) {
+	region, err := ECRRegion("123456789012.dkr.ecr.eu-west-1.amazonaws.com")
+	require.NoError(t, err)
+	assert.Equal(t, "eu-west-1", region)
+
+	region, err = ECRRegion("123456789012.dkr.ecr.cn-north-1.amazonaws.com.cn")
+	require.NoError(t, err)
+	assert.Equal(t, "cn-north-1", region)
+
+	_, err = ECRRegion("public.ecr.aws")
+	require.Error(t, err)
+}
+
+func TestECRToken(t *testing.T) {
+	expiresAt := time.Now().Add(12 * time.Hour).Truncate(time.Second)
+	ecrServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Amz-Target") != "AmazonEC2ContainerRegistry_V20150921.GetAuthorizationToken" ||
+			!strings.Contains(r.Header.Get("Authorization"), "AKIDEXAMPLE/") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/us-east-1/ecr/") {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"authorizationData": []map[string]interface{}{{
+				"authorizationToken": base64.StdEncoding.EncodeToString([]byte("AWS:ecr-password")),
+				"expiresAt":          expiresAt.Unix(),
+				"proxyEndpoint":      "https://123456789012.dkr.ecr.us-east-1.amazonaws.com",
+			}},
+		})
+	}))
+	defer ecrServer.Close()
+
+	dir := t.TempDir()
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(dir, "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(dir, "credentials"))
+	t.Setenv("AWS_EC2_METADATA_DISABLED", "true")
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDEXAMPLE")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_ENDPOINT_URL_ECR", ecrServer.URL)
+
+	cred, expiry, err := ECRToken(context.Background(), "123456789012.dkr.ecr.us-east-1.amazonaws.com")
+	require.NoError(t, err)
+	assert.Equal(t, auth.Credential{Username: "AWS", Password: "ecr-password"}, cred)
+	assert.True(t, expiresAt.Equal(expiry))
+
+	_, _, err = ECRToken(context.Background(), "ghcr.io")
+	require.Error(t, err)
+}
+
+func TestACRToken(t *testing.T) {
+	expiresAt := time.Now().Add(3 * time.Hour).Truncate(time.Second)
+
+	// Stand-in for the managed identity endpoint of App Service and Azure Functions.
+	identityServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Identity-Header") != "identity-secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "aad-token",
+			"expires_on":   fmt.Sprint(time.Now().Add(time.Hour).Unix()),
+			"resource":     r.URL.Query().Get("resource"),
+			"token_type":   "Bearer",
+		})
+	}))
+	defer identityServer.Close()
+
+	refreshToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"exp": expiresAt.Unix()}).SignedString([]byte("key"))
+	require.NoError(t, err)
+	var exchanged url.Values
+	registryServer := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth2/exchange" || r.ParseForm() != nil || r.PostForm.Get("access_token") != "aad-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		exchanged = r.PostForm
+		_ = json.NewEncoder(w).Encode(map[string]string{"refresh_token": refreshToken})
+	}))
+	defer registryServer.Close()
+	reg := strings.TrimPrefix(registryServer.URL, "https://")
+
+	defaultClient := acrHTTPClient
+	acrHTTPClient = registryServer.Client()
+	defer func() { acrHTTPClient = defaultClient }()
+
+	for _, env := range []string{"AZURE_CLIENT_ID", "AZURE_CLIENT_SECRET", "AZURE_TENANT_ID", "AZURE_FEDERATED_TOKEN_FILE", "MSI_ENDPOINT"} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("IDENTITY_ENDPOINT", identityServer.URL)
+	t.Setenv("IDENTITY_HEADER", "identity-secret")
+
+	cred, expiry, err := ACRToken(context.Background(), reg)
+	require.NoError(t, err)
+	assert.Equal(t, auth.Credential{Username: ACRUsername, Password: refreshToken}, cred)
+	assert.True(t, expiresAt.Equal(expiry))
+	assert.Equal(t, "access_token", exchanged.Get("grant_type"))
+	assert.Equal(t, reg, exchanged.Get("service"))
+}
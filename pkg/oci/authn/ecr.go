@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package authn
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ecr"
+	"golang.org/x/exp/slices"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+)
+
+// ecrRegistryRegexp matches the private ECR registries, capturing their region,
+// e.g. 123456789012.dkr.ecr.us-east-1.amazonaws.com.
+var ecrRegistryRegexp = regexp.MustCompile(`^\d{12}\.dkr\.ecr(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?$`)
+
+// ecrTokens caches the authorization tokens for all registries using ecr credentials.
+var ecrTokens = newCloudTokenCache(ECRToken)
+
+// ECRRegion returns the AWS region hosting the given ECR registry.
+func ECRRegion(reg string) (string, error) {
+	matches := ecrRegistryRegexp.FindStringSubmatch(reg)
+	if matches == nil {
+		return "", fmt.Errorf("%q is not an Amazon ECR registry, expected <account>.dkr.ecr.<region>.amazonaws.com", reg)
+	}
+	return matches[1], nil
+}
+
+// ECRToken retrieves an authorization token for the given ECR registry, along with its expiry, using the
+// AWS default credentials chain: environment variables, shared configuration, web identity (e.g. EKS pod
+// identity or IRSA) and instance metadata.
+func ECRToken(ctx context.Context, reg string) (auth.Credential, time.Time, error) {
+	region, err := ECRRegion(reg)
+	if err != nil {
+		return auth.EmptyCredential, time.Time{}, err
+	}
+
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithRegion(region))
+	if err != nil {
+		return auth.EmptyCredential, time.Time{}, fmt.Errorf("unable to load AWS configuration: %w", err)
+	}
+
+	out, err := ecr.NewFromConfig(cfg).GetAuthorizationToken(ctx, &ecr.GetAuthorizationTokenInput{})
+	if err != nil {
+		return auth.EmptyCredential, time.Time{}, fmt.Errorf("unable to get ECR authorization token: %w", err)
+	}
+	if len(out.AuthorizationData) == 0 {
+		return auth.EmptyCredential, time.Time{}, fmt.Errorf("no ECR authorization token returned for registry %q", reg)
+	}
+
+	data := out.AuthorizationData[0]
+	decoded, err := base64.StdEncoding.DecodeString(aws.ToString(data.AuthorizationToken))
+	if err != nil {
+		return auth.EmptyCredential, time.Time{}, fmt.Errorf("unable to decode ECR authorization token: %w", err)
+	}
+	username, password, ok := strings.Cut(string(decoded), ":")
+	if !ok {
+		return auth.EmptyCredential, time.Time{}, fmt.Errorf("malformed ECR authorization token")
+	}
+
+	return auth.Credential{Username: username, Password: password}, aws.ToTime(data.ExpiresAt), nil
+}
+
+// ECRCredential retrieves a valid authorization token from the AWS credentials to perform registry authentication.
+func ECRCredential(ctx context.Context, reg string) (auth.Credential, error) {
+	ecrAuths, err := config.Ecrs()
+	if err != nil {
+		return auth.EmptyCredential, fmt.Errorf("unable to retrieve ecr authentication config %w", err)
+	}
+
+	// ecr auth not set for this registry
+	if !slices.ContainsFunc(ecrAuths, func(c config.EcrAuth) bool { return c.Registry == reg }) {
+		return auth.EmptyCredential, nil
+	}
+
+	return ecrTokens.credential(ctx, reg)
+}
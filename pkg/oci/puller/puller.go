@@ -22,13 +22,16 @@ import (
 	"io"
 
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.opentelemetry.io/otel/attribute"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content/file"
 	"oras.land/oras-go/v2/registry/remote"
 
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
 	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
 	"github.com/falcosecurity/falcoctl/pkg/output"
+	"github.com/falcosecurity/falcoctl/pkg/tracing"
 )
 
 // Puller implements pull operations.
@@ -50,7 +53,10 @@ func NewPuller(client remote.Client, plainHTTP bool, tracker output.Tracker) *Pu
 
 // Pull an artifact from a remote registry.
 // Ref format follows: REGISTRY/REPO[:TAG|@DIGEST]. Ex. localhost:5000/hello:latest.
-func (p *Puller) Pull(ctx context.Context, ref, destDir, os, arch string) (*oci.RegistryResult, error) {
+func (p *Puller) Pull(ctx context.Context, ref, destDir, os, arch string) (_ *oci.RegistryResult, err error) {
+	ctx, span := tracing.Start(ctx, "puller.Pull", attribute.String("ref", ref), attribute.String("platform", os+"/"+arch))
+	defer func() { tracing.End(span, err) }()
+
 	fileStore, err := file.New(destDir)
 	if err != nil {
 		return nil, err
@@ -71,7 +77,7 @@ func (p *Puller) Pull(ctx context.Context, ref, destDir, os, arch string) (*oci.
 
 	refDesc, _, err := repo.FetchReference(ctx, ref)
 	if err != nil {
-		return nil, err
+		return nil, errdefs.Classify(err)
 	}
 
 	copyOpts := oras.CopyOptions{}
@@ -92,8 +98,8 @@ func (p *Puller) Pull(ctx context.Context, ref, destDir, os, arch string) (*oci.
 	desc, err := oras.Copy(ctx, repo, ref, localTarget, ref, copyOpts)
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to pull artifact %s with tag %s from repo %s: %w",
-			repo.Reference.Repository, repo.Reference.Reference, repo.Reference.Repository, err)
+		return nil, errdefs.Classify(fmt.Errorf("unable to pull artifact %s with tag %s from repo %s: %w",
+			repo.Reference.Repository, repo.Reference.Reference, repo.Reference.Repository, err))
 	}
 
 	manifest, err := manifestFromDesc(ctx, localTarget, &desc)
@@ -114,6 +120,7 @@ func (p *Puller) Pull(ctx context.Context, ref, destDir, os, arch string) (*oci.
 	}
 
 	filename := manifest.Layers[0].Annotations[v1.AnnotationTitle]
+	span.SetAttributes(attribute.String("digest", string(refDesc.Digest)), attribute.String("type", artifactType.String()))
 
 	return &oci.RegistryResult{
 		RootDigest: string(refDesc.Digest),
@@ -124,7 +131,10 @@ func (p *Puller) Pull(ctx context.Context, ref, destDir, os, arch string) (*oci.
 }
 
 // Descriptor retrieves the descriptor of an artifact from a remote repository.
-func (p *Puller) Descriptor(ctx context.Context, ref string) (*v1.Descriptor, error) {
+func (p *Puller) Descriptor(ctx context.Context, ref string) (_ *v1.Descriptor, err error) {
+	ctx, span := tracing.Start(ctx, "puller.Descriptor", attribute.String("ref", ref))
+	defer func() { tracing.End(span, err) }()
+
 	repo, err := repository.NewRepository(ref, repository.WithClient(p.Client), repository.WithPlainHTTP(p.plainHTTP))
 	if err != nil {
 		return nil, err
@@ -132,11 +142,50 @@ func (p *Puller) Descriptor(ctx context.Context, ref string) (*v1.Descriptor, er
 
 	desc, _, err := repo.FetchReference(ctx, ref)
 	if err != nil {
-		return nil, err
+		return nil, errdefs.Classify(err)
 	}
 	return &desc, nil
 }
 
+// Platforms returns the platforms of the manifests listed by the image index a reference points to,
+// in the order of the index. It returns nil if the reference points to a single manifest.
+func (p *Puller) Platforms(ctx context.Context, ref string) (_ []v1.Platform, err error) {
+	ctx, span := tracing.Start(ctx, "puller.Platforms", attribute.String("ref", ref))
+	defer func() { tracing.End(span, err) }()
+
+	repo, err := repository.NewRepository(ref, repository.WithClient(p.Client), repository.WithPlainHTTP(p.plainHTTP))
+	if err != nil {
+		return nil, err
+	}
+
+	desc, reader, err := repo.FetchReference(ctx, ref)
+	if err != nil {
+		return nil, errdefs.Classify(err)
+	}
+	defer reader.Close()
+
+	if desc.MediaType != v1.MediaTypeImageIndex {
+		return nil, nil
+	}
+
+	indexBytes, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read index: %w", err)
+	}
+	var index v1.Index
+	if err = json.Unmarshal(indexBytes, &index); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal index: %w", err)
+	}
+
+	platforms := make([]v1.Platform, 0, len(index.Manifests))
+	for _, manifest := range index.Manifests {
+		if manifest.Platform != nil {
+			platforms = append(platforms, *manifest.Platform)
+		}
+	}
+	return platforms, nil
+}
+
 func manifestFromDesc(ctx context.Context, target oras.Target, desc *v1.Descriptor) (*v1.Manifest, error) {
 	var manifest v1.Manifest
 
@@ -183,7 +232,10 @@ func (p *Puller) manifest(ctx context.Context, ref, os, arch string) (*v1.Manife
 // RawManifest fetches the manifest layer from a given reference.
 // If the artifact has a v1.MediaTypeImageIndex descriptor then it fetches the manifest for the
 // specified platform.
-func (p *Puller) RawManifest(ctx context.Context, ref, os, arch string) ([]byte, error) {
+func (p *Puller) RawManifest(ctx context.Context, ref, os, arch string) (_ []byte, err error) {
+	ctx, span := tracing.Start(ctx, "puller.RawManifest", attribute.String("ref", ref), attribute.String("platform", os+"/"+arch))
+	defer func() { tracing.End(span, err) }()
+
 	repo, err := repository.NewRepository(ref, repository.WithClient(p.Client), repository.WithPlainHTTP(p.plainHTTP))
 	if err != nil {
 		return nil, err
@@ -191,7 +243,7 @@ func (p *Puller) RawManifest(ctx context.Context, ref, os, arch string) ([]byte,
 
 	desc, manifestReader, err := repo.FetchReference(ctx, ref)
 	if err != nil {
-		return nil, fmt.Errorf("unable to fetch reference %q: %w", ref, err)
+		return nil, errdefs.Classify(fmt.Errorf("unable to fetch reference %q: %w", ref, err))
 	}
 	defer manifestReader.Close()
 
@@ -220,12 +272,12 @@ func (p *Puller) RawManifest(ctx context.Context, ref, os, arch string) ([]byte,
 		}
 
 		if !found {
-			return nil, fmt.Errorf("unable to find a manifest matching the given platform: %s/%s", os, arch)
+			return nil, errdefs.Errorf(errdefs.ErrNotFound, "unable to find a manifest matching the given platform: %s/%s", os, arch)
 		}
 
 		manifestReader, err = repo.Fetch(ctx, desc)
 		if err != nil {
-			return nil, fmt.Errorf("unable to fetch manifest desc with digest %s: %w", desc.Digest.String(), err)
+			return nil, errdefs.Classify(fmt.Errorf("unable to fetch manifest desc with digest %s: %w", desc.Digest.String(), err))
 		}
 	}
 
@@ -257,7 +309,10 @@ func (p *Puller) ArtifactConfig(ctx context.Context, ref, os, arch string) (*oci
 // RawConfigLayer fetches only the config layer from a given ref.
 // If the artifact has a v1.MediaTypeImageIndex descriptor then it fetches the config layer for the
 // specified platform.
-func (p *Puller) RawConfigLayer(ctx context.Context, ref, os, arch string) ([]byte, error) {
+func (p *Puller) RawConfigLayer(ctx context.Context, ref, os, arch string) (_ []byte, err error) {
+	ctx, span := tracing.Start(ctx, "puller.RawConfigLayer", attribute.String("ref", ref), attribute.String("platform", os+"/"+arch))
+	defer func() { tracing.End(span, err) }()
+
 	repo, err := repository.NewRepository(ref, repository.WithClient(p.Client), repository.WithPlainHTTP(p.plainHTTP))
 	if err != nil {
 		return nil, err
@@ -272,12 +327,12 @@ func (p *Puller) RawConfigLayer(ctx context.Context, ref, os, arch string) ([]by
 
 	descriptor, err := repo.Blobs().Resolve(ctx, configRef)
 	if err != nil {
-		return nil, err
+		return nil, errdefs.Classify(err)
 	}
 
 	rc, err := repo.Fetch(ctx, descriptor)
 	if err != nil {
-		return nil, err
+		return nil, errdefs.Classify(err)
 	}
 
 	configBytes, err := io.ReadAll(rc)
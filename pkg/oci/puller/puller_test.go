@@ -293,6 +293,53 @@ var _ = Describe("Puller", func() {
 		})
 	})
 
+	Context("Platforms func", func() {
+		var (
+			ref       string
+			platforms []v1.Platform
+			err       error
+		)
+		JustBeforeEach(func() {
+			puller = ocipuller.NewPuller(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), plainHTTP, tracker)
+			platforms, err = puller.Platforms(ctx, ref)
+		})
+
+		When("Artifact does not exist", func() {
+			BeforeEach(func() {
+				ref = nonExistingArtifact
+			})
+
+			It("should error", func() {
+				Expect(err).Should(HaveOccurred())
+			})
+		})
+
+		When("Artifact is a multi-platform plugin", func() {
+			BeforeEach(func() {
+				ref = pluginMultiPlatformRef
+			})
+
+			It("should list the platforms of the index", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(platforms).Should(HaveLen(3))
+				for i, platform := range []string{testPluginPlatform1, testPluginPlatform2, testPluginPlatform3} {
+					Expect(platforms[i].OS + "/" + platforms[i].Architecture).Should(Equal(platform))
+				}
+			})
+		})
+
+		When("Artifact is a rulesfile", func() {
+			BeforeEach(func() {
+				ref = rulesRef
+			})
+
+			It("should return no platforms", func() {
+				Expect(err).ShouldNot(HaveOccurred())
+				Expect(platforms).Should(BeNil())
+			})
+		})
+	})
+
 	Context("CheckAllowedType func", func() {
 		var (
 			ref          string
@@ -18,6 +18,8 @@ package pusher
 import (
 	"fmt"
 
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
 	"github.com/falcosecurity/falcoctl/pkg/oci"
 )
 
@@ -27,6 +29,7 @@ type opts struct {
 	ArtifactConfig   *oci.ArtifactConfig
 	Tags             []string
 	AnnotationSource string
+	Annotations      map[string]string
 }
 
 // Option is a functional option for pusher.
@@ -97,3 +100,27 @@ func WithAnnotationSource(annotationSource string) Option {
 		return nil
 	}
 }
+
+// WithAnnotations sets additional annotations for the manifests and the index of the artifact.
+func WithAnnotations(annotations map[string]string) Option {
+	return func(o *opts) error {
+		o.Annotations = annotations
+		return nil
+	}
+}
+
+// annotations returns the annotations to be set on manifests and indexes, nil if there are none.
+func (o *opts) annotations() map[string]string {
+	if o.AnnotationSource == "" && len(o.Annotations) == 0 {
+		return nil
+	}
+
+	annotations := make(map[string]string, len(o.Annotations)+1)
+	for k, v := range o.Annotations {
+		annotations[k] = v
+	}
+	if o.AnnotationSource != "" {
+		annotations[v1.AnnotationSource] = o.AnnotationSource
+	}
+	return annotations
+}
@@ -26,13 +26,17 @@ import (
 
 	"github.com/opencontainers/image-spec/specs-go"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"go.opentelemetry.io/otel/attribute"
 	"oras.land/oras-go/v2"
 	"oras.land/oras-go/v2/content/file"
+	"oras.land/oras-go/v2/errdef"
 	"oras.land/oras-go/v2/registry/remote"
 
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 	"github.com/falcosecurity/falcoctl/pkg/oci"
 	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
 	"github.com/falcosecurity/falcoctl/pkg/output"
+	"github.com/falcosecurity/falcoctl/pkg/tracing"
 )
 
 const (
@@ -80,22 +84,15 @@ func NewPusher(client remote.Client, plainHTTP bool, tracker output.Tracker) *Pu
 // artifactPath path of the artifact blob on the disk.
 // ref format follows: REGISTRY/REPO[:TAG|@DIGEST]. Ex. localhost:5000/hello:latest.
 func (p *Pusher) Push(ctx context.Context, artifactType oci.ArtifactType,
-	ref string, options ...Option) (*oci.RegistryResult, error) {
-	var dataDesc, configDesc, rootDesc *v1.Descriptor
-	var err error
+	ref string, options ...Option) (_ *oci.RegistryResult, err error) {
+	ctx, span := tracing.Start(ctx, "pusher.Push", attribute.String("ref", ref), attribute.String("type", artifactType.String()))
+	defer func() { tracing.End(span, err) }()
 
 	o := &opts{}
 	if err := Options(options).apply(o); err != nil {
 		return nil, err
 	}
 
-	// First thing check that we do not have multiple rulesfiles or multiple assets.
-	if artifactType == oci.Rulesfile && len(o.Filepaths) != 1 {
-		return nil, fmt.Errorf("expecting 1 rulesfile object, received %d: %w", len(o.Filepaths), ErrInvalidNumberRulesfiles)
-	} else if artifactType == oci.Asset && len(o.Filepaths) != 1 {
-		return nil, fmt.Errorf("expecting 1 asset object, received %d: %w", len(o.Filepaths), ErrInvalidNumberAssets)
-	}
-
 	repo, err := repository.NewRepository(ref,
 		repository.WithClient(p.Client),
 		repository.WithPlainHTTP(p.plainHTTP))
@@ -121,21 +118,114 @@ func (p *Pusher) Push(ctx context.Context, artifactType oci.ArtifactType,
 		remoteTarget = p.tracker(repo)
 	}
 
+	rootDesc, rootStore, err := p.pack(ctx, artifactType, remoteTarget, o)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(p.workingDir)
+
+	rootReader, err := rootStore.Fetch(ctx, *rootDesc)
+	if err != nil {
+		return nil, err
+	}
+	defer rootReader.Close()
+
+	// Tag the root descriptor remotely.
+	err = repo.PushReference(ctx, *rootDesc, rootReader, repo.Reference.Reference)
+	if err != nil {
+		return nil, errdefs.Classify(err)
+	}
+
+	if len(tags) > 0 {
+		tagNOptions := oras.DefaultTagNOptions
+		tagNOptions.Concurrency = 1
+		if _, err = oras.TagN(ctx, remoteTarget, repo.Reference.Reference, o.Tags, tagNOptions); err != nil {
+			return nil, errdefs.Classify(err)
+		}
+	}
+
+	return &oci.RegistryResult{
+		RootDigest: string(rootDesc.Digest),
+		Type:       artifactType,
+	}, nil
+}
+
+// PushToTarget packs an artifact and stores it in the given target, for example a local OCI layout,
+// tagging it with reference and with the tags passed as options.
+func (p *Pusher) PushToTarget(ctx context.Context, artifactType oci.ArtifactType,
+	target oras.Target, reference string, options ...Option) (_ *oci.RegistryResult, err error) {
+	ctx, span := tracing.Start(ctx, "pusher.PushToTarget", attribute.String("reference", reference), attribute.String("type", artifactType.String()))
+	defer func() { tracing.End(span, err) }()
+
+	o := &opts{}
+	if err := Options(options).apply(o); err != nil {
+		return nil, err
+	}
+
+	if reference == "" {
+		reference = oci.DefaultTag
+	}
+
+	rootDesc, rootStore, err := p.pack(ctx, artifactType, target, o)
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(p.workingDir)
+
+	rootReader, err := rootStore.Fetch(ctx, *rootDesc)
+	if err != nil {
+		return nil, err
+	}
+	defer rootReader.Close()
+
+	if err = target.Push(ctx, *rootDesc, rootReader); err != nil && !errors.Is(err, errdef.ErrAlreadyExists) {
+		return nil, err
+	}
+
+	for _, tag := range append([]string{reference}, o.Tags...) {
+		if err = target.Tag(ctx, *rootDesc, tag); err != nil {
+			return nil, fmt.Errorf("unable to tag %s with %q: %w", rootDesc.Digest, tag, err)
+		}
+	}
+
+	return &oci.RegistryResult{
+		RootDigest: string(rootDesc.Digest),
+		Type:       artifactType,
+	}, nil
+}
+
+// pack stores the layers, the config layers and the manifests of the artifact in the target.
+// It returns the root descriptor of the artifact, that has not been pushed yet, and the store
+// from where it can be fetched. On success, the caller is responsible for removing the working directory.
+func (p *Pusher) pack(ctx context.Context, artifactType oci.ArtifactType,
+	target oras.Target, o *opts) (rootDesc *v1.Descriptor, fileStore *file.Store, err error) {
+	var dataDesc, configDesc *v1.Descriptor
+
+	// First thing check that we do not have multiple rulesfiles or multiple assets.
+	if artifactType == oci.Rulesfile && len(o.Filepaths) != 1 {
+		return nil, nil, fmt.Errorf("expecting 1 rulesfile object, received %d: %w", len(o.Filepaths), ErrInvalidNumberRulesfiles)
+	} else if artifactType == oci.Asset && len(o.Filepaths) != 1 {
+		return nil, nil, fmt.Errorf("expecting 1 asset object, received %d: %w", len(o.Filepaths), ErrInvalidNumberAssets)
+	}
+
 	defaultCopyOptions := oras.DefaultCopyGraphOptions
 	defaultCopyOptions.Concurrency = 1
 
 	// Initialize the file store for this artifact.
 	p.workingDir, err = os.MkdirTemp("", "falcoctl")
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	defer os.RemoveAll(p.workingDir)
+	defer func() {
+		if err != nil {
+			os.RemoveAll(p.workingDir)
+		}
+	}()
 
 	manifestDescs := make([]*v1.Descriptor, len(o.Filepaths))
-	var fileStore *file.Store
 	for i, artifactPath := range o.Filepaths {
 		if fileStore, err = file.New(p.workingDir); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		platform := ""
@@ -146,25 +236,25 @@ func (p *Pusher) Push(ctx context.Context, artifactType oci.ArtifactType,
 		// Prepare data layer.
 		absolutePath, err := filepath.Abs(artifactPath)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if dataDesc, err = p.storeMainLayer(ctx, fileStore, artifactType, absolutePath); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// Prepare configuration layer.
 		if configDesc, err = p.storeConfigLayer(ctx, fileStore, artifactType, o.ArtifactConfig); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		// Now we can create manifest, using the Config descriptor and principal Layer descriptor.
 		if manifestDescs[i], err = p.packManifest(ctx, fileStore, configDesc,
-			dataDesc, platform, o.AnnotationSource); err != nil {
-			return nil, err
+			dataDesc, platform, o.annotations()); err != nil {
+			return nil, nil, err
 		}
 
-		if err = oras.CopyGraph(ctx, fileStore, remoteTarget, *manifestDescs[i], defaultCopyOptions); err != nil {
-			return nil, err
+		if err = oras.CopyGraph(ctx, fileStore, target, *manifestDescs[i], defaultCopyOptions); err != nil {
+			return nil, nil, errdefs.Classify(err)
 		}
 	}
 
@@ -175,37 +265,14 @@ func (p *Pusher) Push(ctx context.Context, artifactType oci.ArtifactType,
 		// Here we are in the case when we are dealing with a plugin.
 		// Assuming this filestore to be memory only (size of the index should be less than 4MiB)
 		if fileStore, err = file.New(""); err != nil {
-			return nil, err
+			return nil, nil, err
 		}
-		if rootDesc, err = p.storeArtifactsIndex(ctx, fileStore, manifestDescs, o.AnnotationSource); err != nil {
-			return nil, err
+		if rootDesc, err = p.storeArtifactsIndex(ctx, fileStore, manifestDescs, o.annotations()); err != nil {
+			return nil, nil, err
 		}
 	}
 
-	rootReader, err := fileStore.Fetch(ctx, *rootDesc)
-	if err != nil {
-		return nil, err
-	}
-	defer rootReader.Close()
-
-	// Tag the root descriptor remotely.
-	err = repo.PushReference(ctx, *rootDesc, rootReader, repo.Reference.Reference)
-	if err != nil {
-		return nil, err
-	}
-
-	if len(tags) > 0 {
-		tagNOptions := oras.DefaultTagNOptions
-		tagNOptions.Concurrency = 1
-		if _, err = oras.TagN(ctx, remoteTarget, repo.Reference.Reference, o.Tags, tagNOptions); err != nil {
-			return nil, err
-		}
-	}
-
-	return &oci.RegistryResult{
-		RootDigest: string(rootDesc.Digest),
-		Type:       artifactType,
-	}, nil
+	return rootDesc, fileStore, nil
 }
 
 func (p *Pusher) storeMainLayer(ctx context.Context, fileStore *file.Store,
@@ -265,16 +332,12 @@ func (p *Pusher) storeConfigLayer(ctx context.Context, fileStore *file.Store,
 }
 
 func (p *Pusher) storeArtifactsIndex(ctx context.Context, fileStore *file.Store,
-	manifestDescs []*v1.Descriptor, annotationSource string) (*v1.Descriptor, error) {
+	manifestDescs []*v1.Descriptor, annotations map[string]string) (*v1.Descriptor, error) {
 	// fat manifest
 	index := &v1.Index{
-		Versioned: specs.Versioned{SchemaVersion: 2},
-		MediaType: v1.MediaTypeImageIndex,
-	}
-
-	if annotationSource != "" {
-		index.Annotations = make(map[string]string)
-		index.Annotations[v1.AnnotationSource] = annotationSource
+		Versioned:   specs.Versioned{SchemaVersion: 2},
+		MediaType:   v1.MediaTypeImageIndex,
+		Annotations: annotations,
 	}
 
 	// copy manifests
@@ -316,22 +379,12 @@ func (p *Pusher) toFileStore(ctx context.Context, fileStore *file.Store, mediaTy
 }
 
 func (p *Pusher) packManifest(ctx context.Context, fileStore *file.Store,
-	configDesc, dataDesc *v1.Descriptor, platform, annotationSource string) (*v1.Descriptor, error) {
+	configDesc, dataDesc *v1.Descriptor, platform string, annotations map[string]string) (*v1.Descriptor, error) {
 	// Now we can create manifest, using the Config descriptor and principal Layer descriptor.
-	// In case annotation source is passed, we put it in the ManifestAnnotations.
-	var packOptions oras.PackOptions
-
+	// In case annotations are passed, we put them in the ManifestAnnotations.
 	// Currently, Manifests are not pushed as OCI Artifact Manifest.
 	// Always pushed as OCI Image Manifest.
-	packImageManifest := true
-
-	if annotationSource != "" {
-		annotations := make(map[string]string)
-		annotations[v1.AnnotationSource] = annotationSource
-		packOptions = oras.PackOptions{ConfigDescriptor: configDesc, ManifestAnnotations: annotations, PackImageManifest: packImageManifest}
-	} else {
-		packOptions = oras.PackOptions{ConfigDescriptor: configDesc, PackImageManifest: packImageManifest}
-	}
+	packOptions := oras.PackOptions{ConfigDescriptor: configDesc, ManifestAnnotations: annotations, PackImageManifest: true}
 
 	desc, err := oras.Pack(ctx, fileStore, "", []v1.Descriptor{*dataDesc}, packOptions)
 	if err != nil {
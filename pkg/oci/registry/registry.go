@@ -23,6 +23,8 @@ import (
 
 	"oras.land/oras-go/v2/registry/remote"
 	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/falcosecurity/falcoctl/pkg/offline"
 )
 
 // Registry is an HTTP client to interact with a remote registry.
@@ -65,6 +67,9 @@ func WithPlainHTTP(plainHTTP bool) func(r *Registry) {
 
 // CheckConnection checks whether the underlying HTTP client can correctly interact with the remote registry.
 func (r *Registry) CheckConnection(ctx context.Context) error {
+	if offline.Enabled() {
+		return offline.Errorf("registry %q", r.RepositoryOptions.Reference.Registry)
+	}
 	if authClient, ok := r.Client.(*auth.Client); ok {
 		cred, err := authClient.Credential(ctx, r.RepositoryOptions.Reference.Registry)
 		if err != nil {
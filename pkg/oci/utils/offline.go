@@ -0,0 +1,215 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2/content"
+	ocilayout "oras.land/oras-go/v2/content/oci"
+	"oras.land/oras-go/v2/errdef"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
+)
+
+// offlineClient is the remote.Client used in offline mode. The repositories having a local OCI layout, such as the
+// ones written by "artifact build", are served from it through the read-only part of the registry API, while every
+// other request fails without reaching the registry.
+type offlineClient struct {
+	// layouts maps the repositories, as "<registry>/<repository>", to the path of their OCI layout.
+	layouts map[string]string
+
+	mu     sync.Mutex
+	stores map[string]*ocilayout.ReadOnlyStore
+}
+
+func newOfflineClient(layouts []config.Layout) *offlineClient {
+	c := &offlineClient{layouts: make(map[string]string, len(layouts)), stores: make(map[string]*ocilayout.ReadOnlyStore)}
+	for _, l := range layouts {
+		c.layouts[strings.TrimSuffix(l.Repository, "/")] = l.Path
+	}
+	return c
+}
+
+// Do implements the remote.Client interface.
+func (c *offlineClient) Do(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet && req.Method != http.MethodHead {
+		return nil, offline.Errorf("registry %q", req.URL.Host)
+	}
+	if req.URL.Path == "/v2/" && c.hasRegistry(req.URL.Host) {
+		return response(req, http.StatusOK, "", "", nil), nil
+	}
+	repo, kind, ref, ok := parseRegistryPath(req.URL.Path)
+	if !ok {
+		return nil, offline.Errorf("registry %q", req.URL.Host)
+	}
+	path, ok := c.layouts[req.URL.Host+"/"+repo]
+	if !ok {
+		return nil, offline.Errorf("repository %q", req.URL.Host+"/"+repo)
+	}
+	store, err := c.store(req.Context(), path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case "tags":
+		var tags []string
+		if err := store.Tags(req.Context(), req.URL.Query().Get("last"), func(page []string) error {
+			tags = append(tags, page...)
+			return nil
+		}); err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(map[string]interface{}{"name": repo, "tags": tags})
+		if err != nil {
+			return nil, err
+		}
+		return response(req, http.StatusOK, "application/json", "", data), nil
+	case "manifests":
+		return fetch(req, store, ref, "MANIFEST_UNKNOWN")
+	default:
+		return fetch(req, store, ref, "BLOB_UNKNOWN")
+	}
+}
+
+// hasRegistry reports whether a local OCI layout serves a repository of the registry.
+func (c *offlineClient) hasRegistry(registry string) bool {
+	for repo := range c.layouts {
+		if strings.HasPrefix(repo, registry+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// store opens the OCI layout at path, a directory or a tar archive, once.
+func (c *offlineClient) store(ctx context.Context, path string) (*ocilayout.ReadOnlyStore, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if store, ok := c.stores[path]; ok {
+		return store, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to open OCI layout %q: %w", path, err)
+	}
+	var store *ocilayout.ReadOnlyStore
+	if info.IsDir() {
+		store, err = ocilayout.NewFromFS(ctx, os.DirFS(path))
+	} else {
+		store, err = ocilayout.NewFromTar(ctx, path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to open OCI layout %q: %w", path, err)
+	}
+	c.stores[path] = store
+	return store, nil
+}
+
+// parseRegistryPath splits the path of a request of the registry API into the repository, the kind of
+// resource ("manifests", "blobs" or "tags") and its reference.
+func parseRegistryPath(path string) (repo, kind, ref string, ok bool) {
+	path, ok = strings.CutPrefix(path, "/v2/")
+	if !ok {
+		return "", "", "", false
+	}
+	if repo, ok = strings.CutSuffix(path, "/tags/list"); ok {
+		return repo, "tags", "", repo != ""
+	}
+	for _, kind := range []string{"manifests", "blobs"} {
+		if i := strings.LastIndex(path, "/"+kind+"/"); i > 0 {
+			return path[:i], kind, path[i+len(kind)+2:], true
+		}
+	}
+	return "", "", "", false
+}
+
+// fetch serves the manifest or blob with the given reference, a tag or a digest, from the OCI layout.
+func fetch(req *http.Request, store *ocilayout.ReadOnlyStore, ref, notFoundCode string) (*http.Response, error) {
+	desc, err := store.Resolve(req.Context(), ref)
+	if errors.Is(err, errdef.ErrNotFound) {
+		data, err := json.Marshal(map[string]interface{}{
+			"errors": []map[string]string{{"code": notFoundCode, "message": fmt.Sprintf("%q not found in the OCI layout", ref)}},
+		})
+		if err != nil {
+			return nil, err
+		}
+		return response(req, http.StatusNotFound, "application/json", "", data), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	data, err := content.FetchAll(req.Context(), store, desc)
+	if err != nil {
+		return nil, err
+	}
+
+	// Manifests resolved by digest only have a plain descriptor: their media type is the one they declare.
+	mediaType := desc.MediaType
+	if mediaType == "" || mediaType == "application/octet-stream" {
+		var manifest struct {
+			MediaType string `json:"mediaType"`
+		}
+		if json.Unmarshal(data, &manifest) == nil && manifest.MediaType != "" {
+			mediaType = manifest.MediaType
+		}
+	}
+	if mediaType == "" {
+		mediaType = v1.MediaTypeImageManifest
+	}
+	return response(req, http.StatusOK, mediaType, desc.Digest.String(), data), nil
+}
+
+// response returns the response to a request of the registry API.
+func response(req *http.Request, status int, contentType, digest string, body []byte) *http.Response {
+	header := make(http.Header)
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	if digest != "" {
+		header.Set("Docker-Content-Digest", digest)
+	}
+	size := int64(len(body))
+	header.Set("Content-Length", fmt.Sprint(size))
+	// The responses to HEAD requests have no body, but the length of the one of GET requests.
+	if req.Method == http.MethodHead {
+		body = nil
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", status, http.StatusText(status)),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: size,
+		Request:       req,
+	}
+}
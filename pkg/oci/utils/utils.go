@@ -28,6 +28,7 @@ import (
 	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
 	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
 	"github.com/falcosecurity/falcoctl/pkg/oci/registry"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
 	"github.com/falcosecurity/falcoctl/pkg/output"
 )
 
@@ -52,7 +53,17 @@ func Pusher(plainHTTP bool, printer *output.Printer) (*ocipusher.Pusher, error)
 
 // Client returns a new auth.Client.
 // It authenticates the client if credentials are found in the system.
+// In offline mode, it returns a client serving the repositories from their local OCI layouts, if configured,
+// and failing every other request without reaching the registry.
 func Client(enableClientTokenCache bool) (remote.Client, error) {
+	if offline.Enabled() {
+		layouts, err := config.OfflineLayouts()
+		if err != nil {
+			return nil, err
+		}
+		return newOfflineClient(layouts), nil
+	}
+
 	credentialStore, err := credentials.NewStore(config.RegistryCredentialConfPath(), credentials.StoreOptions{
 		AllowPlaintextPut: true,
 	})
@@ -65,11 +76,14 @@ func Client(enableClientTokenCache bool) (remote.Client, error) {
 	// 2. checks basic auth credential store
 	// 3. checks oauth2 clientcredentials
 	// 4. checks gcp credentials if enabled
+	// 5. checks ecr and acr credentials if enabled
 	ops := []func(*authn.Options){
 		authn.WithAutoLogin(authn.NewAutoLoginHandler(credentialStore)),
 		authn.WithStore(credentialStore),
 		authn.WithOAuthCredentials(),
 		authn.WithGcpCredentials(),
+		authn.WithEcrCredentials(),
+		authn.WithAcrCredentials(),
 	}
 	if enableClientTokenCache {
 		ops = append(ops, authn.WithClientTokenCache(auth.NewCache()))
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package utils
+
+import (
+	"archive/tar"
+	"context"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	ocilayout "oras.land/oras-go/v2/content/oci"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
+	"github.com/falcosecurity/falcoctl/pkg/offline"
+	"github.com/falcosecurity/falcoctl/pkg/test"
+)
+
+func TestOffline(t *testing.T) {
+	server := test.NewDialCountingServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	t.Setenv("HOME", t.TempDir())
+	offline.Enable(true)
+	t.Cleanup(func() { offline.Enable(false) })
+	ctx := context.Background()
+	reg := strings.TrimPrefix(server.URL, "http://")
+
+	client, err := Client(true)
+	require.NoError(t, err)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/v2/", http.NoBody)
+	require.NoError(t, err)
+	_, err = client.Do(req) //nolint:bodyclose // no response is returned
+	assert.ErrorIs(t, err, errdefs.ErrOffline)
+	assert.ErrorContains(t, err, "offline: registry \""+reg+"\" not available locally")
+
+	assert.ErrorIs(t, CheckConnectionForRegistry(ctx, client, true, reg), errdefs.ErrOffline)
+
+	puller, err := Puller(true, nil)
+	require.NoError(t, err)
+	_, err = puller.Descriptor(ctx, reg+"/rules:latest")
+	assert.ErrorIs(t, err, errdefs.ErrOffline)
+
+	assert.Zero(t, server.Dials())
+}
+
+func TestOfflineLayouts(t *testing.T) {
+	server := test.NewDialCountingServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+	ctx := context.Background()
+	reg := strings.TrimPrefix(server.URL, "http://")
+
+	// Build a layout like "artifact build" does, and a tar archive of it.
+	layoutDir := filepath.Join(t.TempDir(), "layout")
+	store, err := ocilayout.New(layoutDir)
+	require.NoError(t, err)
+	built, err := ocipusher.NewPusher(nil, false, nil).PushToTarget(ctx, oci.Rulesfile, store, "0.1.0",
+		ocipusher.WithFilepaths([]string{"../../test/data/rules.tar.gz"}),
+		ocipusher.WithArtifactConfig(oci.ArtifactConfig{Name: "rules", Version: "0.1.0"}))
+	require.NoError(t, err)
+	layoutTar := filepath.Join(t.TempDir(), "layout.tar")
+	writeTar(t, layoutDir, layoutTar)
+
+	client := newOfflineClient([]config.Layout{
+		{Repository: reg + "/rules", Path: layoutDir},
+		{Repository: reg + "/bundled/rules", Path: layoutTar},
+	})
+	puller := ocipuller.NewPuller(client, true, nil)
+
+	for _, repo := range []string{reg + "/rules", reg + "/bundled/rules"} {
+		t.Run(repo, func(t *testing.T) {
+			desc, err := puller.Descriptor(ctx, repo+":0.1.0")
+			require.NoError(t, err)
+			assert.Equal(t, built.RootDigest, desc.Digest.String())
+
+			artifactConfig, err := puller.ArtifactConfig(ctx, repo+":0.1.0", "linux", "amd64")
+			require.NoError(t, err)
+			assert.Equal(t, "0.1.0", artifactConfig.Version)
+
+			dir := t.TempDir()
+			res, err := puller.Pull(ctx, repo+"@"+built.RootDigest, dir, "linux", "amd64")
+			require.NoError(t, err)
+			assert.Equal(t, oci.Rulesfile, res.Type)
+			assert.FileExists(t, filepath.Join(dir, res.Filename))
+
+			_, err = puller.Descriptor(ctx, repo+":9.9.9")
+			assert.Error(t, err)
+			assert.NotErrorIs(t, err, errdefs.ErrOffline)
+		})
+	}
+
+	// The other repositories are not available locally.
+	_, err = puller.Descriptor(ctx, reg+"/other:latest")
+	assert.ErrorIs(t, err, errdefs.ErrOffline)
+	assert.Zero(t, server.Dials())
+}
+
+// writeTar writes a tar archive of the files of dir at path.
+func writeTar(t *testing.T, dir, path string) {
+	t.Helper()
+	f, err := os.Create(path)
+	require.NoError(t, err)
+	defer f.Close()
+	tw := tar.NewWriter(f)
+	require.NoError(t, filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		if hdr.Name, err = filepath.Rel(dir, p); err != nil {
+			return err
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		src, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		_, err = io.Copy(tw, src)
+		return err
+	}))
+	require.NoError(t, tw.Close())
+}
@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package offline
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+)
+
+var enabled atomic.Bool
+
+// Enable turns the offline mode on or off for the whole process. Components reaching the network
+// check Enabled before doing so, and fail with an error built by Errorf when it is on.
+func Enable(on bool) {
+	enabled.Store(on)
+}
+
+// Enabled reports whether the offline mode is on.
+func Enabled() bool {
+	return enabled.Load()
+}
+
+// Errorf returns an error of class errdefs.ErrOffline reporting that the described resource
+// is not available locally, e.g. Errorf("index %q", name).
+func Errorf(format string, a ...any) error {
+	return errdefs.Errorf(errdefs.ErrOffline, "offline: %s not available locally", fmt.Sprintf(format, a...))
+}
@@ -20,6 +20,7 @@ import (
 
 	"github.com/pterm/pterm"
 	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
 
 	"github.com/falcosecurity/falcoctl/internal/config"
 	"github.com/falcosecurity/falcoctl/pkg/index/cache"
@@ -113,4 +114,7 @@ func (o *Common) AddFlags(flags *pflag.FlagSet) {
 	flags.StringVar(&o.ConfigFile, "config", config.ConfigPath, "config file to be used for falcoctl")
 	flags.Var(o.logFormat, "log-format", "Set formatting for logs "+o.logFormat.Allowed())
 	flags.Var(o.logLevel, "log-level", "Set level for logs "+o.logLevel.Allowed())
+	flags.Bool("offline", false, "Use only local sources and fail immediately on anything that would need the network")
+	// The flag is read through the config key, so that config.Load enables the offline mode once, whatever its source.
+	_ = viper.BindPFlag(config.OfflineKey, flags.Lookup("offline"))
 }
@@ -21,13 +21,16 @@ import (
 	"net/url"
 	"path/filepath"
 	"sort"
+	"strings"
 
 	"github.com/falcosecurity/driverkit/pkg/kernelrelease"
+	"github.com/spf13/cobra"
 
 	"github.com/falcosecurity/falcoctl/internal/config"
 	driverdistro "github.com/falcosecurity/falcoctl/pkg/driver/distro"
 	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
 	"github.com/falcosecurity/falcoctl/pkg/enum"
+	"github.com/falcosecurity/falcoctl/pkg/output"
 )
 
 // DriverTypes data structure for driver types.
@@ -48,23 +51,87 @@ func NewDriverTypes() *DriverTypes {
 
 // Driver defines options that are common while interacting with driver commands.
 type Driver struct {
-	Type     drivertype.DriverType
-	Name     string
-	Repos    []string
-	Version  string
-	HostRoot string
-	Distro   driverdistro.Distro
-	Kr       kernelrelease.KernelRelease
+	Type                drivertype.DriverType
+	Name                string
+	Repos               []string
+	Version             string
+	HostRoot            string
+	ModuleParams        []string
+	PersistModuleParams bool
+	LoadOnBoot          bool
+	Requirements        DriverRequirements
+	Distro              driverdistro.Distro
+	Kr                  kernelrelease.KernelRelease
+}
+
+// DriverRequirements defines where to find the driver API and schema versions required by Falco.
+type DriverRequirements struct {
+	FalcoBinary      string
+	FalcoVersionsURL string
+	APIVersion       string
+	SchemaVersion    string
+}
+
+// IsSet returns whether any source for the requirements has been configured.
+func (r *DriverRequirements) IsSet() bool {
+	return r.FalcoBinary != "" || r.FalcoVersionsURL != "" || r.APIVersion != "" || r.SchemaVersion != ""
+}
+
+// AddRequirementsFlags registers the flags used to find the driver versions required by Falco.
+func (d *Driver) AddRequirementsFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&d.Requirements.FalcoBinary, "falco-binary", "",
+		"Falco binary whose required driver API and schema versions are used to choose and validate the driver version")
+	cmd.Flags().StringVar(&d.Requirements.FalcoVersionsURL, "falco-versions", "",
+		"Falco webserver versions endpoint (e.g. http://localhost:8765/versions) "+
+			"whose required driver API and schema versions are used to choose and validate the driver version")
+	cmd.Flags().StringVar(&d.Requirements.APIVersion, "driver-api-version", "",
+		"Driver API version required by Falco, used to choose and validate the driver version")
+	cmd.Flags().StringVar(&d.Requirements.SchemaVersion, "driver-schema-version", "",
+		"Driver schema version required by Falco, used to choose and validate the driver version")
+}
+
+// AddModuleFlags registers the flags used to load the driver and persist its configuration.
+func (d *Driver) AddModuleFlags(cmd *cobra.Command) {
+	// Kernel array parameters are comma separated (e.g. foo=1,2,3): do not split values on commas.
+	cmd.Flags().StringArrayVar(&d.ModuleParams, "module-param", nil,
+		"Driver module parameter in the key=value form (e.g. --module-param=g_buffer_bytes_dim=16777216). Can be repeated multiple times")
+	cmd.Flags().BoolVar(&d.PersistModuleParams, "persist-module-params", false,
+		"Whether to write the module parameters to a falcoctl-managed modprobe.d file under the host root")
+	cmd.Flags().BoolVar(&d.LoadOnBoot, "load-on-boot", false,
+		"Whether to install the kernel module under the host root and configure it to be loaded at boot")
+}
+
+// Persist stores the driver configuration on the host so that it survives reboots,
+// if requested and supported by the driver type.
+func (d *Driver) Persist(printer *output.Printer, src string) error {
+	if !d.PersistModuleParams && !d.LoadOnBoot {
+		return nil
+	}
+	persister, ok := d.Type.(drivertype.Persister)
+	if !ok {
+		printer.Logger.Info("Nothing to persist for the selected driver.")
+		return nil
+	}
+	return persister.Persist(printer, src, d.Name, drivertype.PersistOptions{
+		HostRoot:      d.HostRoot,
+		KernelRelease: d.Kr.String(),
+		Params:        d.ModuleParams,
+		PersistParams: d.PersistModuleParams,
+		LoadOnBoot:    d.LoadOnBoot,
+	})
 }
 
 // ToDriverConfig maps a Driver options to Driver config struct.
 func (d *Driver) ToDriverConfig() *config.Driver {
 	return &config.Driver{
-		Type:     []string{d.Type.String()},
-		Name:     d.Name,
-		Repos:    d.Repos,
-		Version:  d.Version,
-		HostRoot: d.HostRoot,
+		Type:                []string{d.Type.String()},
+		Name:                d.Name,
+		Repos:               d.Repos,
+		Version:             d.Version,
+		HostRoot:            d.HostRoot,
+		ModuleParams:        d.ModuleParams,
+		PersistModuleParams: d.PersistModuleParams,
+		LoadOnBoot:          d.LoadOnBoot,
 	}
 }
 
@@ -78,6 +145,12 @@ func (d *Driver) Validate() error {
 		return errors.New("version is mandatory and cannot be empty")
 	}
 
+	for _, param := range d.ModuleParams {
+		if key, _, found := strings.Cut(param, "="); !found || key == "" {
+			return fmt.Errorf("module-param must be in the key=value form (%s)", param)
+		}
+	}
+
 	for _, repo := range d.Repos {
 		_, err := url.ParseRequestURI(repo)
 		if err != nil {
@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/spf13/cobra"
+
+	drivertype "github.com/falcosecurity/falcoctl/pkg/driver/type"
+)
+
+var _ = Describe("Driver", func() {
+	var (
+		d   *Driver
+		cmd *cobra.Command
+	)
+
+	BeforeEach(func() {
+		kmod, err := drivertype.Parse(drivertype.TypeKmod)
+		Expect(err).ToNot(HaveOccurred())
+		d = &Driver{Type: kmod, Name: "falco", Version: "7.0.0+driver", HostRoot: "/"}
+		cmd = &cobra.Command{}
+		d.AddModuleFlags(cmd)
+	})
+
+	Context("module parameters", func() {
+		It("keeps array values together", func() {
+			Expect(cmd.Flags().Parse([]string{
+				"--module-param=g_buffer_bytes_dim=16777216",
+				"--module-param=foo=1,2,3",
+			})).To(Succeed())
+			Expect(d.ModuleParams).To(Equal([]string{"g_buffer_bytes_dim=16777216", "foo=1,2,3"}))
+			Expect(d.Validate()).To(Succeed())
+		})
+
+		It("rejects parameters without a key", func() {
+			Expect(cmd.Flags().Parse([]string{"--module-param=2"})).To(Succeed())
+			Expect(d.Validate()).To(MatchError(ContainSubstring("key=value")))
+		})
+	})
+
+	It("maps the module settings to the driver config", func() {
+		Expect(cmd.Flags().Parse([]string{"--module-param=foo=1,2", "--persist-module-params", "--load-on-boot"})).To(Succeed())
+		cfg := d.ToDriverConfig()
+		Expect(cfg.ModuleParams).To(Equal([]string{"foo=1,2"}))
+		Expect(cfg.PersistModuleParams).To(BeTrue())
+		Expect(cfg.LoadOnBoot).To(BeTrue())
+	})
+})
@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package options
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/pkg/nodestatus"
+	"github.com/falcosecurity/falcoctl/pkg/output"
+)
+
+const (
+	// FlagPublishNodeStatus is the name of the flag enabling the node status publication.
+	FlagPublishNodeStatus = "publish-node-status"
+	// FlagNodeName is the name of the flag to specify the node whose status is published.
+	FlagNodeName = "node-name"
+	// FlagNodeStatusInterval is the name of the flag to specify the minimum interval between node updates.
+	FlagNodeStatusInterval = "node-status-interval"
+
+	// nodeNameEnv is the environment variable usually filled with the node name through the downward API.
+	nodeNameEnv = "NODE_NAME"
+)
+
+// NodeStatus options for publishing the Falco status of the node to Kubernetes.
+type NodeStatus struct {
+	// Publish enables the node status publication.
+	Publish bool
+	// NodeName is the name of the node to be updated.
+	NodeName string
+	// MinInterval is the minimum interval between two updates of the node.
+	MinInterval time.Duration
+}
+
+// AddFlags registers the node status flags.
+func (n *NodeStatus) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(&n.Publish, FlagPublishNodeStatus, false,
+		"Whether to publish the Falco status of the node as labels and annotations of the Kubernetes node")
+	cmd.Flags().StringVar(&n.NodeName, FlagNodeName, os.Getenv(nodeNameEnv),
+		fmt.Sprintf("Name of the Kubernetes node whose status is published (defaults to the %s environment variable, "+
+			"usually set through the downward API)", nodeNameEnv))
+	cmd.Flags().DurationVar(&n.MinInterval, FlagNodeStatusInterval, nodestatus.DefaultMinInterval,
+		"Minimum interval between two updates of the Kubernetes node")
+}
+
+// OverrideFromConfig overrides the node status flags with the viper config, if not set by the user.
+func (n *NodeStatus) OverrideFromConfig(cmd *cobra.Command) error {
+	for flagName, key := range map[string]string{
+		FlagPublishNodeStatus:  config.NodeStatusPublishKey,
+		FlagNodeName:           config.NodeStatusNodeNameKey,
+		FlagNodeStatusInterval: config.NodeStatusMinIntervalKey,
+	} {
+		f := cmd.Flags().Lookup(flagName)
+		if f == nil {
+			// should never happen
+			return fmt.Errorf("unable to retrieve flag %q", flagName)
+		} else if !f.Changed && viper.IsSet(key) {
+			if err := cmd.Flags().Set(f.Name, viper.GetString(key)); err != nil {
+				return fmt.Errorf("unable to overwrite %q flag: %w", flagName, err)
+			}
+		}
+	}
+	return nil
+}
+
+// Publisher returns the publisher of the node status, using the in-cluster Kubernetes config.
+// It returns nil if the publication is not enabled.
+func (n *NodeStatus) Publisher(printer *output.Printer) (*nodestatus.Publisher, error) {
+	if !n.Publish {
+		return nil, nil
+	}
+	if n.NodeName == "" {
+		return nil, errors.New("node name is mandatory to publish the node status")
+	}
+	client, err := nodestatus.NewClient("")
+	if err != nil {
+		return nil, err
+	}
+	return nodestatus.NewPublisher(client, n.NodeName, printer, n.MinInterval), nil
+}
@@ -25,6 +25,8 @@ import (
 
 	isatty "github.com/mattn/go-isatty"
 	"github.com/pterm/pterm"
+
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 )
 
 // TableHeader is used to print out the correct header for a command.
@@ -37,6 +39,24 @@ const (
 	IndexList
 	// ArtifactInfo identifies the header for artifact info.
 	ArtifactInfo
+	// DriverAvailability identifies the header for driver availability nodes.
+	DriverAvailability
+	// DriverCoverage identifies the header for driver availability pools.
+	DriverCoverage
+	// DriverSearch identifies the header for driver search.
+	DriverSearch
+	// Doctor identifies the header for doctor checks.
+	Doctor
+	// RuleSearch identifies the header for artifact search by rules.
+	RuleSearch
+	// ArtifactRules identifies the header for the rules listed by artifact info.
+	ArtifactRules
+	// ArtifactVersions identifies the header for the versions listed by artifact info.
+	ArtifactVersions
+	// ArtifactAudit identifies the header for artifact audit.
+	ArtifactAudit
+	// ArtifactOwner identifies the header for artifact owner.
+	ArtifactOwner
 )
 
 var spinnerCharset = []string{"⠈⠁", "⠈⠑", "⠈⠱", "⠈⡱", "⢀⡱", "⢄⡱", "⢄⡱", "⢆⡱", "⢎⡱", "⢎⡰", "⢎⡠", "⢎⡀", "⢎⠁", "⠎⠁", "⠊⠁"}
@@ -112,39 +132,46 @@ func NewPrinter(logLevel pterm.LogLevel, logFormatter pterm.LogFormatter, writer
 
 // CheckErr prints a user-friendly error based on the active printer.
 func (p *Printer) CheckErr(err error) {
-	var handlerFunc func(msg string)
+	var handlerFunc func(msg string, args ...[]pterm.LoggerArgument)
 	switch {
 	case err == nil:
 		return
 
 	// Stop the spinner, if active.
 	case p != nil && p.Spinner.IsActive:
-		handlerFunc = func(msg string) {
+		handlerFunc = func(msg string, args ...[]pterm.LoggerArgument) {
 			_ = p.Spinner.Stop()
-			p.Logger.Error(msg)
+			p.Logger.Error(msg, args...)
 		}
 		// Stop the progress bar, if active.
 	case p != nil && p.ProgressBar != nil && p.ProgressBar.IsActive:
 
-		handlerFunc = func(msg string) {
+		handlerFunc = func(msg string, args ...[]pterm.LoggerArgument) {
 			_, _ = p.ProgressBar.Stop()
-			p.Logger.Error(msg)
+			p.Logger.Error(msg, args...)
 		}
 
 	// If the printer is initialized then print the error through it.
 	case p != nil:
-		handlerFunc = func(msg string) {
-			p.Logger.Error(msg)
+		handlerFunc = func(msg string, args ...[]pterm.LoggerArgument) {
+			p.Logger.Error(msg, args...)
 		}
 
 	// Otherwise, restore the default behavior.
 	// It should never happen.
 	default:
-		handlerFunc = func(msg string) {
+		handlerFunc = func(msg string, _ ...[]pterm.LoggerArgument) {
 			fmt.Printf("%s (it seems that the printer has not been initialized, that's why you are seeing this message", msg)
 		}
 	}
 
+	// When logging in JSON format, attach the machine-readable error so that
+	// scripts do not need to parse the message.
+	if p != nil && p.Logger.Formatter == pterm.LogFormatterJSON {
+		handlerFunc(err.Error(), p.Logger.Args("error", errdefs.ToJSON(err)))
+		return
+	}
+
 	handlerFunc(err.Error())
 }
 
@@ -159,6 +186,24 @@ func (p *Printer) PrintTable(header TableHeader, data [][]string) error {
 		table = [][]string{{"NAME", "URL", "ADDED", "UPDATED"}}
 	case ArtifactInfo:
 		table = [][]string{{"REF", "TAGS"}}
+	case DriverAvailability:
+		table = [][]string{{"NODE", "POOL", "DISTRO", "KERNEL RELEASE", "ARCH", "AVAILABLE", "NEEDS COMPILE"}}
+	case DriverCoverage:
+		table = [][]string{{"POOL", "NODES", "COVERED", "COVERAGE", "UNKNOWN"}}
+	case DriverSearch:
+		table = [][]string{{"VERSION", "TYPE", "MATCH", "FILENAME", "REPO"}}
+	case Doctor:
+		table = [][]string{{"CHECK", "STATUS", "MESSAGE", "HINT"}}
+	case RuleSearch:
+		table = [][]string{{"INDEX", "ARTIFACT", "RULE", "PRIORITY", "MITRE"}}
+	case ArtifactRules:
+		table = [][]string{{"ARTIFACT", "RULE", "PRIORITY", "SOURCE", "TAGS"}}
+	case ArtifactVersions:
+		table = [][]string{{"ARTIFACT", "VERSION", "DIGEST", "RELEASED", "REQUIREMENTS", "CHANGELOG"}}
+	case ArtifactAudit:
+		table = [][]string{{"ARTIFACT", "VERSION", "ADVISORY", "SEVERITY", "FIXED", "DESCRIPTION"}}
+	case ArtifactOwner:
+		table = [][]string{{"PATH", "ARTIFACT", "VERSION", "TYPE", "REF"}}
 	default:
 		return fmt.Errorf("unsupported output table")
 	}
@@ -183,7 +228,7 @@ func (p Printer) WithWriter(writer io.Writer) *Printer {
 func ExitOnErr(p *Printer, err error) {
 	if err != nil {
 		p.CheckErr(err)
-		os.Exit(1)
+		os.Exit(errdefs.ExitCode(err))
 	}
 }
 
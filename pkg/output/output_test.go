@@ -26,6 +26,8 @@ import (
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/gbytes"
 	"github.com/pterm/pterm"
+
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
 )
 
 var _ = Describe("NewPrinter func", func() {
@@ -233,6 +235,18 @@ var _ = Describe("CheckErr func", func() {
 		})
 	})
 
+	Context("log format is json", func() {
+		BeforeEach(func() {
+			buf = gbytes.NewBuffer()
+			printer = NewPrinter(pterm.LogLevelInfo, pterm.LogFormatterJSON, buf)
+			err = errdefs.Errorf(errdefs.ErrNotFound, "unable to find a prebuilt driver")
+		})
+
+		It("should print the error object", func() {
+			Expect(buf).Should(gbytes.Say(`"error":{"class":"not_found","code":2,"message":"unable to find a prebuilt driver"}`))
+		})
+	})
+
 })
 
 var _ = Describe("PrintTable func", func() {
@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// cdxBOM is a CycloneDX 1.5 document.
+type cdxBOM struct {
+	BOMFormat    string          `json:"bomFormat"`
+	SpecVersion  string          `json:"specVersion"`
+	SerialNumber string          `json:"serialNumber,omitempty"`
+	Version      int             `json:"version"`
+	Metadata     cdxMetadata     `json:"metadata"`
+	Components   []cdxComponent  `json:"components"`
+	Dependencies []cdxDependency `json:"dependencies,omitempty"`
+}
+
+type cdxMetadata struct {
+	Timestamp string       `json:"timestamp"`
+	Tools     cdxTools     `json:"tools"`
+	Component cdxComponent `json:"component"`
+}
+
+type cdxTools struct {
+	Components []cdxComponent `json:"components"`
+}
+
+type cdxComponent struct {
+	BOMRef     string         `json:"bom-ref,omitempty"`
+	Type       string         `json:"type"`
+	Name       string         `json:"name"`
+	Version    string         `json:"version,omitempty"`
+	Supplier   *cdxSupplier   `json:"supplier,omitempty"`
+	Licenses   []cdxLicense   `json:"licenses,omitempty"`
+	Purl       string         `json:"purl,omitempty"`
+	Hashes     []cdxHash      `json:"hashes,omitempty"`
+	Properties []cdxProperty  `json:"properties,omitempty"`
+	Components []cdxComponent `json:"components,omitempty"`
+}
+
+type cdxSupplier struct {
+	Name string `json:"name"`
+}
+
+type cdxLicense struct {
+	Expression string `json:"expression"`
+}
+
+type cdxHash struct {
+	Alg     string `json:"alg"`
+	Content string `json:"content"`
+}
+
+type cdxProperty struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+type cdxDependency struct {
+	Ref       string   `json:"ref"`
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// cdxPropertyPrefix is the namespace of the falcoctl properties.
+const cdxPropertyPrefix = "falcosecurity:falcoctl:"
+
+// cdxTypes maps the artifact types to the CycloneDX component types.
+var cdxTypes = map[string]string{
+	"plugin":    "library",
+	"rulesfile": "data",
+	"asset":     "data",
+}
+
+func encodeCycloneDX(w io.Writer, doc *Document) error {
+	bom := cdxBOM{
+		BOMFormat:   "CycloneDX",
+		SpecVersion: "1.5",
+		Version:     1,
+		Metadata: cdxMetadata{
+			Timestamp: doc.Timestamp.UTC().Format(time.RFC3339),
+			Tools: cdxTools{Components: []cdxComponent{
+				{Type: "application", Name: "falcoctl", Version: doc.ToolVersion},
+			}},
+			Component: cdxComponent{Type: "device", Name: doc.Name},
+		},
+		Components: []cdxComponent{},
+	}
+
+	byName := make(map[string]string, len(doc.Components))
+	for i := range doc.Components {
+		byName[doc.Components[i].Name] = doc.Components[i].bomRef()
+	}
+
+	for i := range doc.Components {
+		c := &doc.Components[i]
+		comp := cdxComponent{
+			BOMRef:  c.bomRef(),
+			Type:    cdxTypes[c.Type],
+			Name:    c.Name,
+			Version: c.Version,
+			Purl:    c.purl(),
+			Properties: []cdxProperty{
+				{Name: cdxPropertyPrefix + "type", Value: c.Type},
+				{Name: cdxPropertyPrefix + "ref", Value: c.Ref},
+			},
+		}
+		if comp.Type == "" {
+			comp.Type = "data"
+		}
+		if c.Supplier != "" {
+			comp.Supplier = &cdxSupplier{Name: c.Supplier}
+		}
+		if c.License != "" {
+			comp.Licenses = []cdxLicense{{Expression: c.License}}
+		}
+		if h := digestHex(c.Digest); h != "" {
+			comp.Hashes = []cdxHash{{Alg: "SHA-256", Content: h}}
+		}
+		for j, f := range c.Files {
+			comp.Components = append(comp.Components, cdxComponent{
+				BOMRef: fmt.Sprintf("%s#file-%d", comp.BOMRef, j),
+				Type:   "file",
+				Name:   f.Path,
+				Hashes: []cdxHash{{Alg: "SHA-1", Content: f.SHA1}, {Alg: "SHA-256", Content: f.SHA256}},
+			})
+		}
+		bom.Components = append(bom.Components, comp)
+
+		dep := cdxDependency{Ref: comp.BOMRef}
+		for _, name := range c.DependsOn {
+			if ref, ok := byName[name]; ok {
+				dep.DependsOn = append(dep.DependsOn, ref)
+			}
+		}
+		bom.Dependencies = append(bom.Dependencies, dep)
+	}
+
+	if d := doc.Driver; d != nil {
+		bom.Components = append(bom.Components, cdxComponent{
+			BOMRef:  fmt.Sprintf("%s-%s@%s", TypeDriver, d.Name, d.Version),
+			Type:    "device-driver",
+			Name:    d.Name,
+			Version: d.Version,
+			Properties: []cdxProperty{
+				{Name: cdxPropertyPrefix + "type", Value: TypeDriver},
+				{Name: cdxPropertyPrefix + "driver:type", Value: d.Type},
+				{Name: cdxPropertyPrefix + "driver:kernelRelease", Value: d.KernelRelease},
+				{Name: cdxPropertyPrefix + "driver:arch", Value: d.Arch},
+				{Name: cdxPropertyPrefix + "driver:target", Value: d.Target},
+			},
+		})
+	}
+
+	content, err := json.Marshal(bom)
+	if err != nil {
+		return fmt.Errorf("unable to encode CycloneDX document: %w", err)
+	}
+	bom.SerialNumber = "urn:uuid:" + documentID(doc, content)
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(bom); err != nil {
+		return fmt.Errorf("unable to encode CycloneDX document: %w", err)
+	}
+	return nil
+}
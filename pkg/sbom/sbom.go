@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"crypto/sha1" //nolint:gosec // SPDX mandates SHA1 file checksums.
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"oras.land/oras-go/v2/registry"
+)
+
+// Supported formats.
+const (
+	CycloneDX = "cyclonedx"
+	SPDX      = "spdx"
+)
+
+// Formats lists the supported formats.
+var Formats = []string{CycloneDX, SPDX}
+
+// Component types, besides the artifact types.
+const (
+	// TypeDriver is the type of the driver component.
+	TypeDriver = "driver"
+)
+
+// Document is the content of a software bill of materials.
+type Document struct {
+	// Name of the document, e.g. the host name.
+	Name string
+	// Timestamp is the creation time of the document.
+	Timestamp time.Time
+	// ToolVersion is the falcoctl version.
+	ToolVersion string
+	// Components are the installed artifacts.
+	Components []Component
+	// Driver is the loaded driver, if any.
+	Driver *Driver
+}
+
+// Component is an installed artifact.
+type Component struct {
+	Name    string
+	Version string
+	// Type is the artifact type: rulesfile, plugin or asset.
+	Type string
+	// Ref is the reference the artifact was installed from, e.g. ghcr.io/falcosecurity/rules/falco-rules:3.
+	Ref    string
+	Digest string
+	// License and Supplier come from the index entry of the artifact, if any.
+	License  string
+	Supplier string
+	Files    []File
+	// DependsOn lists the names of the components the artifact depends on.
+	DependsOn []string
+}
+
+// File is a file installed by an artifact.
+type File struct {
+	Path   string
+	SHA1   string
+	SHA256 string
+}
+
+// Driver is the loaded driver.
+type Driver struct {
+	Name    string
+	Version string
+	// Type is the driver type: kmod, ebpf or modern_ebpf.
+	Type          string
+	KernelRelease string
+	Arch          string
+	// Target is the target distro of the driver, as in "falcoctl driver printenv".
+	Target string
+}
+
+// HashFile returns the file at path with its checksums.
+func HashFile(path string) (File, error) {
+	f, err := os.Open(filepath.Clean(path))
+	if err != nil {
+		return File{}, fmt.Errorf("unable to open %q: %w", path, err)
+	}
+	defer f.Close()
+
+	h1, h256 := sha1.New(), sha256.New() //nolint:gosec // SPDX mandates SHA1 file checksums.
+	if _, err := io.Copy(io.MultiWriter(h1, h256), f); err != nil {
+		return File{}, fmt.Errorf("unable to read %q: %w", path, err)
+	}
+	return File{Path: path, SHA1: hex.EncodeToString(h1.Sum(nil)), SHA256: hex.EncodeToString(h256.Sum(nil))}, nil
+}
+
+// Encode writes the document in the given format.
+func Encode(w io.Writer, format string, doc *Document) error {
+	switch format {
+	case CycloneDX:
+		return encodeCycloneDX(w, doc)
+	case SPDX:
+		return encodeSPDX(w, doc)
+	default:
+		return fmt.Errorf("unsupported SBOM format %q, allowed values: %s", format, strings.Join(Formats, ", "))
+	}
+}
+
+// purl returns the package URL of a component, following the oci type of the purl specification:
+// pkg:oci/NAME@DIGEST?repository_url=REPOSITORY&tag=TAG.
+func (c *Component) purl() string {
+	if c.Digest == "" {
+		return ""
+	}
+	ref, err := registry.ParseReference(c.Ref)
+	if err != nil {
+		return ""
+	}
+	name := ref.Repository[strings.LastIndex(ref.Repository, "/")+1:]
+	query := url.Values{}
+	query.Set("repository_url", ref.Registry+"/"+ref.Repository)
+	if ref.ValidateReferenceAsTag() == nil {
+		query.Set("tag", ref.Reference)
+	}
+	return fmt.Sprintf("pkg:oci/%s@%s?%s", name, url.PathEscape(c.Digest), query.Encode())
+}
+
+// bomRef returns the identifier of a component within the document.
+func (c *Component) bomRef() string {
+	if p := c.purl(); p != "" {
+		return p
+	}
+	return fmt.Sprintf("%s-%s@%s", c.Type, c.Name, c.Version)
+}
+
+// digestHex returns the hex of a sha256 digest, or an empty string for other algorithms.
+func digestHex(digest string) string {
+	if h, ok := strings.CutPrefix(digest, "sha256:"); ok {
+		return h
+	}
+	return ""
+}
+
+// documentID returns an identifier of the document derived from its content, so that the same
+// content always yields the same document.
+func documentID(doc *Document, encoded []byte) string {
+	h := sha256.New()
+	_, _ = h.Write([]byte(doc.Name))
+	_, _ = h.Write([]byte(doc.Timestamp.UTC().Format(time.RFC3339)))
+	_, _ = h.Write(encoded)
+	sum := h.Sum(nil)
+	// Format the digest as a version 4 UUID.
+	sum[6] = (sum[6] & 0x0f) | 0x40
+	sum[8] = (sum[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
@@ -0,0 +1,219 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rulesDigest  = "sha256:5b1a2b6a7f9c3f8e0a4d6c2b1e9f8a7d6c5b4a3f2e1d0c9b8a7f6e5d4c3b2a10"
+	pluginDigest = "sha256:0f1e2d3c4b5a69788796a5b4c3d2e1f00f1e2d3c4b5a69788796a5b4c3d2e1f0"
+)
+
+func testDocument(t *testing.T) *Document {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "cloudtrail_rules.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("- rule: test\n"), 0o600))
+	file, err := HashFile(path)
+	require.NoError(t, err)
+
+	return &Document{
+		Name:        "node-1",
+		Timestamp:   time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC),
+		ToolVersion: "0.11.0",
+		Components: []Component{
+			{
+				Name:      "cloudtrail-rules",
+				Version:   "0.13.0",
+				Type:      "rulesfile",
+				Ref:       "ghcr.io/falcosecurity/plugins/ruleset/cloudtrail:0.13.0",
+				Digest:    rulesDigest,
+				License:   "Apache-2.0",
+				Supplier:  "The Falco Authors",
+				Files:     []File{file},
+				DependsOn: []string{"cloudtrail"},
+			},
+			{
+				Name:    "cloudtrail",
+				Version: "0.12.0",
+				Type:    "plugin",
+				Ref:     "ghcr.io/falcosecurity/plugins/plugin/cloudtrail:0.12.0",
+				Digest:  pluginDigest,
+			},
+		},
+		Driver: &Driver{
+			Name:          "falco",
+			Version:       "7.3.0+driver",
+			Type:          "kmod",
+			KernelRelease: "6.1.0-18-amd64",
+			Arch:          "x86_64",
+			Target:        "debian",
+		},
+	}
+}
+
+func TestHashFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "empty")
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+
+	file, err := HashFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, File{
+		Path:   path,
+		SHA1:   "da39a3ee5e6b4b0d3255bfef95601890afd80709",
+		SHA256: "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
+	}, file)
+
+	_, err = HashFile(filepath.Join(t.TempDir(), "missing"))
+	assert.Error(t, err)
+}
+
+func TestPurl(t *testing.T) {
+	testCases := []struct {
+		name     string
+		c        Component
+		expected string
+	}{
+		{
+			name: "tag",
+			c:    Component{Ref: "ghcr.io/falcosecurity/rules/falco-rules:3", Digest: rulesDigest},
+			expected: "pkg:oci/falco-rules@sha256:" + digestHex(rulesDigest) +
+				"?repository_url=ghcr.io%2Ffalcosecurity%2Frules%2Ffalco-rules&tag=3",
+		},
+		{
+			name:     "digest",
+			c:        Component{Ref: "ghcr.io/falcosecurity/rules/falco-rules@" + rulesDigest, Digest: rulesDigest},
+			expected: "pkg:oci/falco-rules@sha256:" + digestHex(rulesDigest) + "?repository_url=ghcr.io%2Ffalcosecurity%2Frules%2Ffalco-rules",
+		},
+		{
+			name: "no digest",
+			c:    Component{Ref: "ghcr.io/falcosecurity/rules/falco-rules:3"},
+		},
+		{
+			name: "invalid reference",
+			c:    Component{Ref: "falco-rules", Digest: rulesDigest},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.c.purl())
+		})
+	}
+}
+
+func TestEncodeCycloneDX(t *testing.T) {
+	doc := testDocument(t)
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, CycloneDX, doc))
+
+	var bom cdxBOM
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &bom))
+	assert.Equal(t, "CycloneDX", bom.BOMFormat)
+	assert.Equal(t, "1.5", bom.SpecVersion)
+	assert.Regexp(t, `^urn:uuid:[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, bom.SerialNumber)
+	assert.Equal(t, "2025-03-01T10:00:00Z", bom.Metadata.Timestamp)
+	assert.Equal(t, "node-1", bom.Metadata.Component.Name)
+
+	require.Len(t, bom.Components, 3)
+	rules, plugin, driver := bom.Components[0], bom.Components[1], bom.Components[2]
+	assert.Equal(t, "data", rules.Type)
+	assert.Equal(t, doc.Components[0].purl(), rules.Purl)
+	assert.Equal(t, rules.Purl, rules.BOMRef)
+	assert.Equal(t, []cdxLicense{{Expression: "Apache-2.0"}}, rules.Licenses)
+	assert.Equal(t, &cdxSupplier{Name: "The Falco Authors"}, rules.Supplier)
+	assert.Equal(t, []cdxHash{{Alg: "SHA-256", Content: digestHex(rulesDigest)}}, rules.Hashes)
+	require.Len(t, rules.Components, 1)
+	assert.Equal(t, "file", rules.Components[0].Type)
+	assert.Equal(t, doc.Components[0].Files[0].Path, rules.Components[0].Name)
+	assert.Equal(t, "library", plugin.Type)
+	assert.Nil(t, plugin.Licenses)
+	assert.Equal(t, "device-driver", driver.Type)
+	assert.Equal(t, "7.3.0+driver", driver.Version)
+	assert.Contains(t, driver.Properties, cdxProperty{Name: cdxPropertyPrefix + "driver:target", Value: "debian"})
+
+	assert.Equal(t, []cdxDependency{
+		{Ref: rules.BOMRef, DependsOn: []string{plugin.BOMRef}},
+		{Ref: plugin.BOMRef},
+	}, bom.Dependencies)
+}
+
+func TestEncodeSPDX(t *testing.T) {
+	doc := testDocument(t)
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, SPDX, doc))
+
+	var sd spdxDocument
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &sd))
+	assert.Equal(t, "SPDX-2.3", sd.SPDXVersion)
+	assert.Regexp(t, `^https://falco.org/spdxdocs/falcoctl-[0-9a-f-]{36}$`, sd.DocumentNamespace)
+	assert.Equal(t, []string{"Tool: falcoctl-0.11.0"}, sd.CreationInfo.Creators)
+
+	require.Len(t, sd.Packages, 3)
+	rules, plugin, driver := sd.Packages[0], sd.Packages[1], sd.Packages[2]
+	assert.Equal(t, "SPDXRef-Package-rulesfile-cloudtrail-rules", rules.SPDXID)
+	assert.Equal(t, "Apache-2.0", rules.LicenseDeclared)
+	assert.Equal(t, "Organization: The Falco Authors", rules.Supplier)
+	assert.True(t, rules.FilesAnalyzed)
+	require.NotNil(t, rules.VerificationCode)
+	assert.Equal(t, verificationCode([]string{doc.Components[0].Files[0].SHA1}), rules.VerificationCode.Value)
+	assert.Equal(t, []spdxExternalRef{{Category: "PACKAGE-MANAGER", Type: "purl", Locator: doc.Components[0].purl()}},
+		rules.ExternalRefs)
+	assert.Equal(t, spdxNoAssertion, plugin.LicenseDeclared)
+	assert.False(t, plugin.FilesAnalyzed)
+	assert.Equal(t, "SPDXRef-Package-driver-falco", driver.SPDXID)
+
+	require.Len(t, sd.Files, 1)
+	assert.Equal(t, []string{sd.Files[0].SPDXID}, rules.HasFiles)
+	assert.Equal(t, []spdxRelationship{
+		{Element: "SPDXRef-DOCUMENT", Type: "DESCRIBES", Related: rules.SPDXID},
+		{Element: rules.SPDXID, Type: "DEPENDS_ON", Related: plugin.SPDXID},
+		{Element: "SPDXRef-DOCUMENT", Type: "DESCRIBES", Related: plugin.SPDXID},
+		{Element: "SPDXRef-DOCUMENT", Type: "DESCRIBES", Related: driver.SPDXID},
+	}, sd.Relationships)
+}
+
+func TestEncodeDeterministic(t *testing.T) {
+	doc := testDocument(t)
+	for _, format := range Formats {
+		t.Run(format, func(t *testing.T) {
+			var first, second bytes.Buffer
+			require.NoError(t, Encode(&first, format, doc))
+			require.NoError(t, Encode(&second, format, doc))
+			assert.Equal(t, first.String(), second.String())
+
+			other := *doc
+			other.Name = "node-2"
+			var third bytes.Buffer
+			require.NoError(t, Encode(&third, format, &other))
+			assert.NotEqual(t, first.String(), third.String())
+		})
+	}
+}
+
+func TestEncodeUnsupported(t *testing.T) {
+	var buf bytes.Buffer
+	assert.EqualError(t, Encode(&buf, "swid", &Document{}), `unsupported SBOM format "swid", allowed values: cyclonedx, spdx`)
+}
@@ -0,0 +1,224 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sbom
+
+import (
+	"crypto/sha1" //nolint:gosec // SPDX mandates SHA1 file checksums.
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// spdxNoAssertion is the SPDX value for unknown fields.
+const spdxNoAssertion = "NOASSERTION"
+
+// spdxIDChars matches the characters not allowed in SPDX identifiers.
+var spdxIDChars = regexp.MustCompile(`[^a-zA-Z0-9.-]`)
+
+// spdxDocument is an SPDX 2.3 document.
+type spdxDocument struct {
+	SPDXVersion       string             `json:"spdxVersion"`
+	DataLicense       string             `json:"dataLicense"`
+	SPDXID            string             `json:"SPDXID"`
+	Name              string             `json:"name"`
+	DocumentNamespace string             `json:"documentNamespace"`
+	CreationInfo      spdxCreationInfo   `json:"creationInfo"`
+	Packages          []spdxPackage      `json:"packages"`
+	Files             []spdxFile         `json:"files,omitempty"`
+	Relationships     []spdxRelationship `json:"relationships"`
+}
+
+type spdxCreationInfo struct {
+	Created  string   `json:"created"`
+	Creators []string `json:"creators"`
+}
+
+type spdxPackage struct {
+	SPDXID                string                `json:"SPDXID"`
+	Name                  string                `json:"name"`
+	VersionInfo           string                `json:"versionInfo,omitempty"`
+	Supplier              string                `json:"supplier"`
+	DownloadLocation      string                `json:"downloadLocation"`
+	FilesAnalyzed         bool                  `json:"filesAnalyzed"`
+	VerificationCode      *spdxVerificationCode `json:"packageVerificationCode,omitempty"`
+	Checksums             []spdxChecksum        `json:"checksums,omitempty"`
+	LicenseConcluded      string                `json:"licenseConcluded"`
+	LicenseDeclared       string                `json:"licenseDeclared"`
+	CopyrightText         string                `json:"copyrightText"`
+	PrimaryPackagePurpose string                `json:"primaryPackagePurpose,omitempty"`
+	Comment               string                `json:"comment,omitempty"`
+	ExternalRefs          []spdxExternalRef     `json:"externalRefs,omitempty"`
+	HasFiles              []string              `json:"hasFiles,omitempty"`
+}
+
+type spdxVerificationCode struct {
+	Value string `json:"packageVerificationCodeValue"`
+}
+
+type spdxChecksum struct {
+	Algorithm string `json:"algorithm"`
+	Value     string `json:"checksumValue"`
+}
+
+type spdxExternalRef struct {
+	Category string `json:"referenceCategory"`
+	Type     string `json:"referenceType"`
+	Locator  string `json:"referenceLocator"`
+}
+
+type spdxFile struct {
+	SPDXID           string         `json:"SPDXID"`
+	FileName         string         `json:"fileName"`
+	Checksums        []spdxChecksum `json:"checksums"`
+	LicenseConcluded string         `json:"licenseConcluded"`
+	CopyrightText    string         `json:"copyrightText"`
+}
+
+type spdxRelationship struct {
+	Element string `json:"spdxElementId"`
+	Type    string `json:"relationshipType"`
+	Related string `json:"relatedSpdxElement"`
+}
+
+// spdxPurposes maps the artifact types to the SPDX package purposes.
+var spdxPurposes = map[string]string{
+	"plugin":    "LIBRARY",
+	"rulesfile": "FILE",
+	"asset":     "FILE",
+}
+
+func encodeSPDX(w io.Writer, doc *Document) error {
+	sd := spdxDocument{
+		SPDXVersion: "SPDX-2.3",
+		DataLicense: "CC0-1.0",
+		SPDXID:      "SPDXRef-DOCUMENT",
+		Name:        doc.Name,
+		CreationInfo: spdxCreationInfo{
+			Created:  doc.Timestamp.UTC().Format(time.RFC3339),
+			Creators: []string{"Tool: falcoctl-" + doc.ToolVersion},
+		},
+		Packages: []spdxPackage{},
+	}
+
+	ids := make(map[string]string, len(doc.Components))
+	for i := range doc.Components {
+		c := &doc.Components[i]
+		ids[c.Name] = spdxID("Package", c.Type, c.Name)
+	}
+
+	for i := range doc.Components {
+		c := &doc.Components[i]
+		pkg := spdxPackage{
+			SPDXID:                ids[c.Name],
+			Name:                  c.Name,
+			VersionInfo:           c.Version,
+			Supplier:              spdxNoAssertion,
+			DownloadLocation:      spdxNoAssertion,
+			LicenseConcluded:      spdxNoAssertion,
+			LicenseDeclared:       spdxNoAssertion,
+			CopyrightText:         spdxNoAssertion,
+			PrimaryPackagePurpose: spdxPurposes[c.Type],
+			Comment:               fmt.Sprintf("Falco %s artifact installed from %s", c.Type, c.Ref),
+		}
+		if c.Supplier != "" {
+			pkg.Supplier = "Organization: " + c.Supplier
+		}
+		if c.License != "" {
+			pkg.LicenseDeclared = c.License
+		}
+		if h := digestHex(c.Digest); h != "" {
+			pkg.Checksums = []spdxChecksum{{Algorithm: "SHA256", Value: h}}
+		}
+		if p := c.purl(); p != "" {
+			pkg.ExternalRefs = []spdxExternalRef{{Category: "PACKAGE-MANAGER", Type: "purl", Locator: p}}
+		}
+
+		if len(c.Files) > 0 {
+			pkg.FilesAnalyzed = true
+			sha1s := make([]string, 0, len(c.Files))
+			for j, f := range c.Files {
+				fileID := spdxID("File", c.Name, fmt.Sprint(j))
+				sd.Files = append(sd.Files, spdxFile{
+					SPDXID:           fileID,
+					FileName:         f.Path,
+					Checksums:        []spdxChecksum{{Algorithm: "SHA1", Value: f.SHA1}, {Algorithm: "SHA256", Value: f.SHA256}},
+					LicenseConcluded: spdxNoAssertion,
+					CopyrightText:    spdxNoAssertion,
+				})
+				pkg.HasFiles = append(pkg.HasFiles, fileID)
+				sha1s = append(sha1s, f.SHA1)
+			}
+			pkg.VerificationCode = &spdxVerificationCode{Value: verificationCode(sha1s)}
+		}
+		sd.Packages = append(sd.Packages, pkg)
+
+		sd.Relationships = append(sd.Relationships, spdxRelationship{Element: sd.SPDXID, Type: "DESCRIBES", Related: pkg.SPDXID})
+		for _, name := range c.DependsOn {
+			if id, ok := ids[name]; ok {
+				sd.Relationships = append(sd.Relationships, spdxRelationship{Element: pkg.SPDXID, Type: "DEPENDS_ON", Related: id})
+			}
+		}
+	}
+
+	if d := doc.Driver; d != nil {
+		pkg := spdxPackage{
+			SPDXID:                spdxID("Package", TypeDriver, d.Name),
+			Name:                  d.Name,
+			VersionInfo:           d.Version,
+			Supplier:              spdxNoAssertion,
+			DownloadLocation:      spdxNoAssertion,
+			LicenseConcluded:      spdxNoAssertion,
+			LicenseDeclared:       spdxNoAssertion,
+			CopyrightText:         spdxNoAssertion,
+			PrimaryPackagePurpose: "OPERATING-SYSTEM",
+			Comment: fmt.Sprintf("Falco %s driver for kernel %s (%s), target %s",
+				d.Type, d.KernelRelease, d.Arch, d.Target),
+		}
+		sd.Packages = append(sd.Packages, pkg)
+		sd.Relationships = append(sd.Relationships, spdxRelationship{Element: sd.SPDXID, Type: "DESCRIBES", Related: pkg.SPDXID})
+	}
+
+	content, err := json.Marshal(sd)
+	if err != nil {
+		return fmt.Errorf("unable to encode SPDX document: %w", err)
+	}
+	sd.DocumentNamespace = "https://falco.org/spdxdocs/falcoctl-" + documentID(doc, content)
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(sd); err != nil {
+		return fmt.Errorf("unable to encode SPDX document: %w", err)
+	}
+	return nil
+}
+
+// spdxID returns an SPDX identifier made of the given parts.
+func spdxID(parts ...string) string {
+	return "SPDXRef-" + spdxIDChars.ReplaceAllString(strings.Join(parts, "-"), "-")
+}
+
+// verificationCode computes the SPDX package verification code from the SHA1 of the package files.
+func verificationCode(sha1s []string) string {
+	sorted := append([]string(nil), sha1s...)
+	sort.Strings(sorted)
+	sum := sha1.Sum([]byte(strings.Join(sorted, ""))) //nolint:gosec // SPDX mandates SHA1 verification codes.
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfupdate
+
+import (
+	"github.com/falcosecurity/falcoctl/internal/config"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	ociutils "github.com/falcosecurity/falcoctl/pkg/oci/utils"
+)
+
+// NewFromConfig returns an Updater configured by the self-update section of the config file, which must
+// be loaded. The repository, if not empty, overrides the configured one.
+func NewFromConfig(repository string, plainHTTP, noVerify bool) (*Updater, error) {
+	conf := config.SelfUpdateConfig()
+	if repository == "" {
+		repository = conf.Repository
+	}
+	client, err := ociutils.Client(true)
+	if err != nil {
+		return nil, err
+	}
+	return New(&Config{
+		Repository:     repository,
+		Signature:      toIndexSignature(conf.Signature),
+		NoVerify:       noVerify,
+		RegistryClient: client,
+		PlainHTTP:      plainHTTP,
+	})
+}
+
+// toIndexSignature converts the configured cosign parameters to an index signature.
+func toIndexSignature(sig *config.SelfUpdateSignature) *index.Signature {
+	if sig == nil {
+		return nil
+	}
+	return &index.Signature{Cosign: &index.CosignSignature{
+		KeyRef:                      sig.Key,
+		CertificateIdentity:         sig.CertificateIdentity,
+		CertificateIdentityRegexp:   sig.CertificateIdentityRegexp,
+		CertificateOidcIssuer:       sig.CertificateOidcIssuer,
+		CertificateOidcIssuerRegexp: sig.CertificateOidcIssuerRegexp,
+	}}
+}
@@ -0,0 +1,357 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfupdate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"github.com/blang/semver"
+	"oras.land/oras-go/v2/registry"
+	"oras.land/oras-go/v2/registry/remote"
+
+	"github.com/falcosecurity/falcoctl/internal/signature"
+	"github.com/falcosecurity/falcoctl/internal/utils"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+	"github.com/falcosecurity/falcoctl/pkg/oci/repository"
+)
+
+const (
+	// BinaryName is the name of the falcoctl executable in the archive of the artifact.
+	BinaryName = "falcoctl"
+	// BackupSuffix is appended to the path of the replaced executable to name its backup.
+	BackupSuffix = ".bak"
+)
+
+// Verifier verifies the signature of a digest reference.
+type Verifier func(ctx context.Context, ref string, signature *index.Signature) error
+
+// Config configures the Updater.
+type Config struct {
+	// Repository is the OCI repository of the falcoctl binary artifacts, e.g. "ghcr.io/falcosecurity/falcoctl-bin".
+	// The artifacts are tagged with the falcoctl versions and hold, for each platform, a tar.gz archive
+	// containing the falcoctl executable.
+	Repository string
+	// Signature is the trust policy of the artifacts. Artifacts are verified against it, and refused
+	// when it is not set, unless NoVerify is set.
+	Signature *index.Signature
+	// NoVerify skips the signature verification.
+	NoVerify bool
+	// RegistryClient is the client used to access the registry.
+	RegistryClient remote.Client
+	// PlainHTTP enables plain http connections to the registry.
+	PlainHTTP bool
+	// Verifier verifies the signatures. Defaults to the cosign verification.
+	Verifier Verifier
+	// Executable is the path of the replaced executable. Defaults to the running one.
+	Executable string
+	// Prerelease makes the prerelease versions, e.g. 0.12.0-rc1, candidates for the latest version.
+	Prerelease bool
+}
+
+// Result describes a completed update.
+type Result struct {
+	// Version is the version reported by the new executable.
+	Version string
+	// Ref is the digest reference of the installed artifact.
+	Ref string
+	// Executable is the path of the replaced executable.
+	Executable string
+	// Backup is the path of the backup of the replaced executable.
+	Backup string
+}
+
+// Updater pulls falcoctl binary artifacts and replaces the running executable.
+type Updater struct {
+	*Config
+	puller *ocipuller.Puller
+}
+
+// New returns a new Updater.
+func New(conf *Config) (*Updater, error) {
+	if conf.Repository == "" {
+		return nil, fmt.Errorf("no falcoctl repository configured, please set it in the config file or through the --repository flag")
+	}
+	if _, err := registry.ParseReference(conf.Repository); err != nil {
+		return nil, fmt.Errorf("invalid falcoctl repository %q: %w", conf.Repository, err)
+	}
+	if conf.Verifier == nil {
+		conf.Verifier = signature.Verify
+	}
+	return &Updater{
+		Config: conf,
+		puller: ocipuller.NewPuller(conf.RegistryClient, conf.PlainHTTP, nil),
+	}, nil
+}
+
+// Latest returns the highest semver tag of the repository. Prerelease versions are skipped, unless
+// Prerelease is set.
+func (u *Updater) Latest(ctx context.Context) (string, error) {
+	r, err := repository.NewRepository(u.Repository, repository.WithClient(u.RegistryClient), repository.WithPlainHTTP(u.PlainHTTP))
+	if err != nil {
+		return "", err
+	}
+	tags, err := r.Tags(ctx)
+	if err != nil {
+		return "", fmt.Errorf("unable to list tags of %q: %w", u.Repository, errdefs.Classify(err))
+	}
+
+	var best *semver.Version
+	var bestTag string
+	for _, t := range tags {
+		v, err := semver.ParseTolerant(t)
+		if err != nil || (len(v.Pre) > 0 && !u.Prerelease) {
+			continue
+		}
+		if best == nil || v.GT(*best) {
+			best, bestTag = &v, t
+		}
+	}
+	if best == nil {
+		return "", errdefs.Errorf(errdefs.ErrNotFound, "no version found in %q", u.Repository)
+	}
+	return bestTag, nil
+}
+
+// Newer reports whether latest is a newer version than current. Versions that are not semver,
+// such as the ones of development builds, are never newer.
+func Newer(current, latest string) bool {
+	c, err := semver.ParseTolerant(current)
+	if err != nil {
+		return false
+	}
+	l, err := semver.ParseTolerant(latest)
+	if err != nil {
+		return false
+	}
+	return l.GT(c)
+}
+
+// Update replaces the executable with the falcoctl binary of the given version, the latest one if empty,
+// for the running platform. The artifact signature is verified, and the new binary must run its version
+// command and report the requested version, before it atomically replaces the executable. The replaced
+// executable is kept next to it with the BackupSuffix.
+func (u *Updater) Update(ctx context.Context, version string) (*Result, error) {
+	var err error
+	if version == "" {
+		if version, err = u.Latest(ctx); err != nil {
+			return nil, err
+		}
+	}
+	ref := fmt.Sprintf("%s:%s", u.Repository, version)
+
+	executable, err := u.executable()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "falcoctl-self-update")
+	if err != nil {
+		return nil, fmt.Errorf("cannot create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	result, err := u.puller.Pull(ctx, ref, tmpDir, runtime.GOOS, runtime.GOARCH)
+	if err != nil {
+		return nil, err
+	}
+	if result.Type == oci.Rulesfile {
+		return nil, fmt.Errorf("%q is a rulesfile artifact, not a falcoctl binary", ref)
+	}
+	digestRef := fmt.Sprintf("%s@%s", u.Repository, result.RootDigest)
+
+	if err := u.verify(ctx, digestRef); err != nil {
+		return nil, err
+	}
+
+	binary, err := extractBinary(ctx, filepath.Join(tmpDir, result.Filename), tmpDir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to extract %s from %q: %w", BinaryName, ref, err)
+	}
+
+	newVersion, err := binaryVersion(ctx, binary)
+	if err != nil {
+		return nil, fmt.Errorf("the falcoctl binary of %q does not run: %w", ref, err)
+	}
+	if !sameVersion(newVersion, version) {
+		return nil, fmt.Errorf("the falcoctl binary of %q reports version %q", ref, newVersion)
+	}
+
+	backup := executable + BackupSuffix
+	if err := replace(executable, binary, backup); err != nil {
+		return nil, err
+	}
+
+	return &Result{
+		Version:    newVersion,
+		Ref:        digestRef,
+		Executable: executable,
+		Backup:     backup,
+	}, nil
+}
+
+// executable returns the path of the replaced executable, resolving the symbolic links so that
+// the file they point to is replaced.
+func (u *Updater) executable() (string, error) {
+	executable := u.Executable
+	if executable == "" {
+		var err error
+		if executable, err = os.Executable(); err != nil {
+			return "", fmt.Errorf("unable to find the running executable: %w", err)
+		}
+	}
+	resolved, err := filepath.EvalSymlinks(executable)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve executable %q: %w", executable, err)
+	}
+	return resolved, nil
+}
+
+// verify applies the trust policy to a digest reference.
+func (u *Updater) verify(ctx context.Context, digestRef string) error {
+	if u.NoVerify {
+		return nil
+	}
+	if u.Signature == nil {
+		return errdefs.Errorf(errdefs.ErrSignatureInvalid, "no signature configured to verify %q, refusing to update", digestRef)
+	}
+	return u.Verifier(ctx, digestRef, u.Signature)
+}
+
+// extractBinary extracts the archive into dir and returns the path of the falcoctl executable it contains.
+func extractBinary(ctx context.Context, archive, dir string) (string, error) {
+	if err := utils.IsTarGz(archive); err != nil {
+		return "", err
+	}
+	f, err := os.Open(filepath.Clean(archive))
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	destDir := filepath.Join(dir, "extracted")
+	if err := os.MkdirAll(destDir, 0o700); err != nil {
+		return "", err
+	}
+	files, err := utils.ExtractTarGz(ctx, f, destDir, 0)
+	if err != nil {
+		return "", err
+	}
+	for _, file := range files {
+		if filepath.Base(file) == BinaryName {
+			return file, nil
+		}
+	}
+	return "", errdefs.Errorf(errdefs.ErrNotFound, "no %s executable found in the archive", BinaryName)
+}
+
+// binaryVersion runs the version command of a falcoctl binary and returns the version it reports.
+func binaryVersion(ctx context.Context, binary string) (string, error) {
+	if err := os.Chmod(binary, 0o755); err != nil { // #nosec G302 -- the binary must be executable
+		return "", err
+	}
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, binary, "version", "--output", "json") // #nosec G204 -- the binary has been verified
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	// The JSON document follows the "Client Version:" header.
+	out := stdout.Bytes()
+	start := bytes.IndexByte(out, '{')
+	if start < 0 {
+		return "", fmt.Errorf("unexpected version output %q", strings.TrimSpace(stdout.String()))
+	}
+	var v struct {
+		SemVersion string `json:"semVersion"`
+	}
+	if err := json.Unmarshal(out[start:], &v); err != nil {
+		return "", fmt.Errorf("unexpected version output: %w", err)
+	}
+	return v.SemVersion, nil
+}
+
+// sameVersion reports whether the version reported by a binary matches the requested tag.
+// Tags that are not semver, e.g. "latest", match any version.
+func sameVersion(reported, tag string) bool {
+	t, err := semver.ParseTolerant(tag)
+	if err != nil {
+		return true
+	}
+	r, err := semver.ParseTolerant(reported)
+	if err != nil {
+		return false
+	}
+	return r.Equals(t)
+}
+
+// replace atomically replaces executable with binary, keeping a copy of executable as backup.
+// The binary is first copied next to the executable so that the final rename happens within
+// the same filesystem.
+func replace(executable, binary, backup string) error {
+	info, err := os.Stat(executable)
+	if err != nil {
+		return err
+	}
+	if err := copyFile(executable, backup, info.Mode()); err != nil {
+		return fmt.Errorf("unable to back up %q: %w", executable, err)
+	}
+
+	staged := filepath.Join(filepath.Dir(executable), "."+filepath.Base(executable)+".new")
+	if err := copyFile(binary, staged, info.Mode()); err != nil {
+		return fmt.Errorf("unable to stage the new executable: %w", err)
+	}
+	if err := os.Rename(staged, executable); err != nil {
+		return errors.Join(fmt.Errorf("unable to replace %q: %w", executable, err), os.Remove(staged))
+	}
+	return nil
+}
+
+// copyFile copies src to dst, created with the given mode.
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(filepath.Clean(src))
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(filepath.Clean(dst), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, mode.Perm())
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		_ = out.Close()
+		return err
+	}
+	if err := out.Close(); err != nil {
+		return err
+	}
+	// OpenFile leaves the mode of an existing file as it is.
+	return os.Chmod(dst, mode.Perm())
+}
@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package selfupdate
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/distribution/distribution/v3/configuration"
+	_ "github.com/distribution/distribution/v3/registry/storage/driver/inmemory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	"github.com/falcosecurity/falcoctl/pkg/oci/authn"
+	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
+	testutils "github.com/falcosecurity/falcoctl/pkg/test"
+)
+
+// fakeBinary is a falcoctl executable reporting its version as the real one does.
+const fakeBinary = `#!/bin/sh
+echo "Client Version:"
+echo '{"semVersion": "%s"}'
+`
+
+func startRegistry(t *testing.T) string {
+	t.Helper()
+	port, err := testutils.FreePort()
+	require.NoError(t, err)
+	config := &configuration.Configuration{}
+	config.HTTP.Addr = fmt.Sprintf("localhost:%d", port)
+	go func() {
+		_ = testutils.StartRegistry(context.Background(), config)
+	}()
+	require.Eventually(t, func() bool {
+		res, err := http.Get("http://" + config.HTTP.Addr)
+		if err != nil {
+			return false
+		}
+		_ = res.Body.Close()
+		return res.StatusCode == http.StatusOK
+	}, 5*time.Second, 50*time.Millisecond)
+	return config.HTTP.Addr
+}
+
+// pushBinary pushes a falcoctl binary artifact reporting version for the running platform.
+func pushBinary(t *testing.T, repo, tag, version string) {
+	t.Helper()
+	archive := filepath.Join(t.TempDir(), "falcoctl.tar.gz")
+	f, err := os.Create(archive)
+	require.NoError(t, err)
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	content := fmt.Sprintf(fakeBinary, version)
+	require.NoError(t, tw.WriteHeader(&tar.Header{Name: BinaryName, Mode: 0o755, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+	_, err = tw.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+	require.NoError(t, f.Close())
+
+	pusher := ocipusher.NewPusher(authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)), true, nil)
+	_, err = pusher.Push(context.Background(), oci.Plugin, repo+":"+tag,
+		ocipusher.WithFilepathsAndPlatforms([]string{archive}, []string{runtime.GOOS + "/" + runtime.GOARCH}),
+		ocipusher.WithArtifactConfig(oci.ArtifactConfig{}))
+	require.NoError(t, err)
+}
+
+func newUpdater(t *testing.T, repo string, verifier Verifier) (*Updater, string) {
+	t.Helper()
+	executable := filepath.Join(t.TempDir(), "falcoctl")
+	require.NoError(t, os.WriteFile(executable, []byte("old"), 0o755))
+	updater, err := New(&Config{
+		Repository:     repo,
+		Signature:      &index.Signature{Cosign: &index.CosignSignature{KeyRef: "cosign.pub"}},
+		RegistryClient: authn.NewClient(authn.WithCredentials(&auth.EmptyCredential)),
+		PlainHTTP:      true,
+		Verifier:       verifier,
+		Executable:     executable,
+	})
+	require.NoError(t, err)
+	return updater, executable
+}
+
+func TestUpdate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the fake binary is a shell script")
+	}
+	ctx := context.Background()
+	repo := startRegistry(t) + "/falcoctl"
+	pushBinary(t, repo, "0.11.0", "0.11.0")
+	pushBinary(t, repo, "0.12.0", "0.12.0")
+	pushBinary(t, repo, "0.13.0", "0.12.0")
+	pushBinary(t, repo, "0.14.0-rc1", "0.14.0-rc1")
+
+	var verified string
+	verifier := func(ctx context.Context, ref string, signature *index.Signature) error {
+		verified = ref
+		return nil
+	}
+
+	t.Run("latest", func(t *testing.T) {
+		updater, _ := newUpdater(t, repo, verifier)
+		latest, err := updater.Latest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "0.13.0", latest)
+	})
+
+	t.Run("latest prerelease", func(t *testing.T) {
+		updater, _ := newUpdater(t, repo, verifier)
+		updater.Prerelease = true
+		latest, err := updater.Latest(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, "0.14.0-rc1", latest)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		updater, executable := newUpdater(t, repo, verifier)
+		result, err := updater.Update(ctx, "0.12.0")
+		require.NoError(t, err)
+		assert.Equal(t, "0.12.0", result.Version)
+		assert.Equal(t, verified, result.Ref)
+		assert.Contains(t, result.Ref, repo+"@sha256:")
+
+		content, err := os.ReadFile(executable)
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf(fakeBinary, "0.12.0"), string(content))
+		backup, err := os.ReadFile(result.Backup)
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(backup))
+	})
+
+	t.Run("version mismatch", func(t *testing.T) {
+		updater, executable := newUpdater(t, repo, verifier)
+		_, err := updater.Update(ctx, "0.13.0")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `reports version "0.12.0"`)
+		content, err := os.ReadFile(executable)
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(content))
+	})
+
+	t.Run("invalid signature", func(t *testing.T) {
+		updater, executable := newUpdater(t, repo, func(ctx context.Context, ref string, signature *index.Signature) error {
+			return errdefs.Wrap(errdefs.ErrSignatureInvalid, errors.New("bad signature"))
+		})
+		_, err := updater.Update(ctx, "0.12.0")
+		require.ErrorIs(t, err, errdefs.ErrSignatureInvalid)
+		content, err := os.ReadFile(executable)
+		require.NoError(t, err)
+		assert.Equal(t, "old", string(content))
+	})
+
+	t.Run("no signature configured", func(t *testing.T) {
+		updater, _ := newUpdater(t, repo, verifier)
+		updater.Signature = nil
+		_, err := updater.Update(ctx, "0.12.0")
+		require.ErrorIs(t, err, errdefs.ErrSignatureInvalid)
+
+		updater.NoVerify = true
+		_, err = updater.Update(ctx, "0.12.0")
+		require.NoError(t, err)
+	})
+}
+
+func TestNewer(t *testing.T) {
+	assert.True(t, Newer("v0.11.0", "0.12.0"))
+	assert.False(t, Newer("0.12.0", "v0.12.0"))
+	assert.False(t, Newer("0.13.0", "0.12.0"))
+	assert.False(t, Newer("v0.0.0-master+$Format:%H$", "0.12.0"))
+}
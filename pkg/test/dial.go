@@ -0,0 +1,47 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package test
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+)
+
+// DialCountingServer is an httptest.Server counting the connections it accepts, used to assert
+// that no network dial happens, e.g. in offline mode.
+type DialCountingServer struct {
+	*httptest.Server
+	dials atomic.Int64
+}
+
+// NewDialCountingServer starts a new DialCountingServer serving the given handler.
+func NewDialCountingServer(handler http.Handler) *DialCountingServer {
+	s := &DialCountingServer{Server: httptest.NewUnstartedServer(handler)}
+	s.Config.ConnState = func(_ net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			s.dials.Add(1)
+		}
+	}
+	s.Start()
+	return s
+}
+
+// Dials returns the number of connections accepted so far.
+func (s *DialCountingServer) Dials() int64 {
+	return s.dials.Load()
+}
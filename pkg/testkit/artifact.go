@@ -0,0 +1,207 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testkit
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocipusher "github.com/falcosecurity/falcoctl/pkg/oci/pusher"
+)
+
+// DefaultVersion is the version of the fixture artifacts that do not set one.
+const DefaultVersion = "1.0.0"
+
+// Artifact describes a fixture artifact published by Registry.Push.
+type Artifact struct {
+	// Type is the type of the artifact, rulesfile, plugin or asset.
+	Type oci.ArtifactType
+	// Repository is the repository the artifact is pushed to, e.g. "falcosecurity/rules/my-rules".
+	Repository string
+	// Name is the name of the artifact, stored in its config. Defaults to the last element of Repository.
+	Name string
+	// Version is the version of the artifact, stored in its config and used as tag. Defaults to DefaultVersion.
+	Version string
+	// Tags are the tags, in addition to Version, the artifact is pushed with.
+	Tags []string
+	// Files is the content of the archive of the artifact, keyed by file name. Defaults to a single
+	// file: an empty list for rulesfiles, a placeholder library for plugins and a text file for assets.
+	Files map[string][]byte
+	// Platforms are the "os/arch" platforms a plugin is pushed for, all with the same Files.
+	// Defaults to the platform the test is running on.
+	Platforms []string
+	// Dependencies are the dependencies stored in the config of the artifact.
+	Dependencies []oci.ArtifactDependency
+	// Requirements are the requirements stored in the config of the artifact.
+	Requirements []oci.ArtifactRequirement
+	// Signer, if set, signs the pushed artifact.
+	Signer *Signer
+}
+
+func (a *Artifact) name() string {
+	if a.Name != "" {
+		return a.Name
+	}
+	return path.Base(a.Repository)
+}
+
+func (a *Artifact) version() string {
+	if a.Version != "" {
+		return a.Version
+	}
+	return DefaultVersion
+}
+
+func (a *Artifact) files() map[string][]byte {
+	if len(a.Files) > 0 {
+		return a.Files
+	}
+	name := a.name()
+	switch a.Type {
+	case oci.Rulesfile:
+		return map[string][]byte{name + ".yaml": []byte(fmt.Sprintf("- list: %s\n  items: []\n", name))}
+	case oci.Plugin:
+		return map[string][]byte{"lib" + name + ".so": []byte(fmt.Sprintf("fixture plugin %s %s\n", name, a.version()))}
+	default:
+		return map[string][]byte{name + ".txt": []byte(fmt.Sprintf("fixture asset %s %s\n", name, a.version()))}
+	}
+}
+
+// Push publishes the artifact to the registry, signing it if a Signer is set.
+func (r *Registry) Push(ctx context.Context, a *Artifact) (*oci.RegistryResult, error) {
+	dir, err := os.MkdirTemp(r.dir, "artifact")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(dir)
+
+	archive, err := TarGz(a.files())
+	if err != nil {
+		return nil, err
+	}
+	archiveName := fmt.Sprintf("%s-%s.tar.gz", a.name(), a.version())
+
+	var filesOpt ocipusher.Option
+	if a.Type == oci.Plugin {
+		platforms := a.Platforms
+		if len(platforms) == 0 {
+			platforms = []string{runtime.GOOS + "/" + runtime.GOARCH}
+		}
+		paths := make([]string, len(platforms))
+		for i := range platforms {
+			// The archives share the same name, so each platform gets its own directory.
+			platformDir := filepath.Join(dir, fmt.Sprint(i))
+			if err := os.Mkdir(platformDir, 0o700); err != nil {
+				return nil, err
+			}
+			paths[i] = filepath.Join(platformDir, archiveName)
+			if err := os.WriteFile(paths[i], archive, 0o600); err != nil {
+				return nil, err
+			}
+		}
+		filesOpt = ocipusher.WithFilepathsAndPlatforms(paths, platforms)
+	} else {
+		archivePath := filepath.Join(dir, archiveName)
+		if err := os.WriteFile(archivePath, archive, 0o600); err != nil {
+			return nil, err
+		}
+		filesOpt = ocipusher.WithFilepaths([]string{archivePath})
+	}
+
+	pusher := ocipusher.NewPusher(r.Client(), r.PlainHTTP, nil)
+	res, err := pusher.Push(ctx, a.Type, r.Host+"/"+a.Repository,
+		filesOpt,
+		ocipusher.WithArtifactConfig(oci.ArtifactConfig{
+			Name:         a.name(),
+			Version:      a.version(),
+			Dependencies: a.Dependencies,
+			Requirements: a.Requirements,
+		}),
+		ocipusher.WithTags(append([]string{a.version()}, a.Tags...)...))
+	if err != nil {
+		return nil, fmt.Errorf("unable to push artifact %q: %w", a.name(), err)
+	}
+
+	if a.Signer != nil {
+		if err := r.Sign(ctx, a.Signer, a.Repository, res.RootDigest); err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+// Entry returns the index entry of the artifact pushed to the registry, carrying the signature
+// verification parameters when the artifact is signed.
+func (r *Registry) Entry(a *Artifact) *index.Entry {
+	entry := &index.Entry{
+		Name:        a.name(),
+		Type:        string(a.Type),
+		Registry:    r.Host,
+		Repository:  a.Repository,
+		Description: fmt.Sprintf("Fixture %s %s", a.Type, a.name()),
+		Keywords:    []string{a.name()},
+	}
+	if a.Signer != nil {
+		entry.Signature = a.Signer.Signature()
+	}
+	return entry
+}
+
+// TarGz returns a gzip compressed tar archive containing the given files, keyed by name.
+func TarGz(files map[string][]byte) ([]byte, error) {
+	names := make([]string, 0, len(files))
+	for name := range files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	for _, name := range names {
+		hdr := &tar.Header{
+			Name:     name,
+			Mode:     0o644,
+			Size:     int64(len(files[name])),
+			ModTime:  time.Unix(0, 0),
+			Typeflag: tar.TypeReg,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return nil, err
+		}
+		if _, err := tw.Write(files[name]); err != nil {
+			return nil, err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package testkit provides the building blocks to write hermetic end-to-end tests for tools built on
+// falcoctl. It starts an in-process OCI registry, optionally protected by basic authentication and
+// served over TLS, publishes fixture rulesfile, plugin and asset artifacts, with dependencies,
+// requirements and cosign signatures, and serves over HTTP an index, a Falco versions endpoint and
+// a driver repository.
+//
+// Everything runs in the test process on loopback addresses, and each component must be closed
+// when the test is done:
+//
+//	reg, err := testkit.StartRegistry(ctx, testkit.WithBasicAuth("user", "password"))
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer reg.Close()
+//
+//	signer, err := testkit.NewSigner(t.TempDir())
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	rules := &testkit.Artifact{
+//		Type:       oci.Rulesfile,
+//		Repository: "falcosecurity/rules/my-rules",
+//		Version:    "1.0.0",
+//		Signer:     signer,
+//	}
+//	if _, err := reg.Push(ctx, rules); err != nil {
+//		t.Fatal(err)
+//	}
+//
+//	idx := index.New("test")
+//	idx.Upsert(reg.Entry(rules))
+//	indexServer, err := testkit.ServeIndex(idx)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer indexServer.Close()
+//
+// The registry is reached at reg.Host, with reg.Client() and reg.PlainHTTP, while the index is
+// available at indexServer.URL.
+package testkit
@@ -0,0 +1,164 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testkit
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	driverversion "github.com/falcosecurity/falcoctl/pkg/driver/version"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/index/serve"
+)
+
+// FalcoVersionsPath is the path the Falco versions are served at, as by the Falco webserver.
+const FalcoVersionsPath = "/versions"
+
+// IndexServer serves an index over HTTP, as consumed by the http index backend.
+type IndexServer struct {
+	// URL is the URL of the served index, to be added with "falcoctl index add".
+	URL string
+
+	server  *httptest.Server
+	handler *serve.Server
+}
+
+// ServeIndex starts serving idx on a random loopback port. The server is stopped by Close.
+func ServeIndex(idx *index.Index) (*IndexServer, error) {
+	handler := serve.New(nil)
+	if err := handler.Set(idx); err != nil {
+		return nil, err
+	}
+	server := httptest.NewServer(handler)
+	return &IndexServer{
+		URL:     server.URL + serve.IndexPath,
+		server:  server,
+		handler: handler,
+	}, nil
+}
+
+// Set replaces the served index, e.g. to simulate the release of a new version.
+func (s *IndexServer) Set(idx *index.Index) error {
+	return s.handler.Set(idx)
+}
+
+// Close stops the server.
+func (s *IndexServer) Close() {
+	s.server.Close()
+}
+
+// ServeFalcoVersions starts serving versions at FalcoVersionsPath, like the Falco webserver does,
+// e.g. {"engine_version": "0.40.0", "driver_api_version": "8.0.0", "driver_schema_version": "2.15.0"}.
+// The server is stopped by Close.
+func ServeFalcoVersions(versions map[string]string) (*httptest.Server, error) {
+	body, err := json.Marshal(versions)
+	if err != nil {
+		return nil, err
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc(FalcoVersionsPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write(body)
+	})
+	return httptest.NewServer(mux), nil
+}
+
+type driverMetadata struct {
+	Version       string `json:"version"`
+	APIVersion    string `json:"api_version"`
+	SchemaVersion string `json:"schema_version"`
+}
+
+// DriverRepository is a driver repository, serving prebuilt drivers at
+// <URL>/<driver version>/<arch>/<file name>, and the metadata file listing the driver versions.
+type DriverRepository struct {
+	// URL is the URL of the repository, to be passed to "falcoctl driver" as repo.
+	URL string
+
+	server   *httptest.Server
+	mu       sync.RWMutex
+	files    map[string][]byte
+	drivers  []driverMetadata
+	requests []string
+}
+
+// ServeDriverRepository starts serving an empty driver repository on a random loopback port.
+// The server is stopped by Close.
+func ServeDriverRepository() *DriverRepository {
+	d := &DriverRepository{files: map[string][]byte{}}
+	d.server = httptest.NewServer(http.HandlerFunc(d.serveHTTP))
+	d.URL = d.server.URL
+	return d
+}
+
+// AddDriver lists the driver version in the metadata file, along with the API and schema versions it implements.
+func (d *DriverRepository) AddDriver(version, apiVersion, schemaVersion string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.drivers = append(d.drivers, driverMetadata{Version: version, APIVersion: apiVersion, SchemaVersion: schemaVersion})
+}
+
+// AddFile serves content as the prebuilt driver fileName, e.g. "falco_ubuntu-generic_6.5.0-1-generic_1.ko",
+// for the given driver version and architecture, e.g. "x86_64".
+func (d *DriverRepository) AddFile(version, arch, fileName string, content []byte) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.files["/"+version+"/"+arch+"/"+fileName] = content
+}
+
+// Requests returns the paths requested so far, in order.
+func (d *DriverRepository) Requests() []string {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return append([]string(nil), d.requests...)
+}
+
+// Close stops the server.
+func (d *DriverRepository) Close() {
+	d.server.Close()
+}
+
+func (d *DriverRepository) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	d.mu.Lock()
+	d.requests = append(d.requests, r.URL.Path)
+	d.mu.Unlock()
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if strings.TrimPrefix(r.URL.Path, "/") == driverversion.MetadataFile {
+		if len(d.drivers) == 0 {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(struct {
+			Drivers []driverMetadata `json:"drivers"`
+		}{d.drivers})
+		return
+	}
+
+	// The driver version is query escaped by falcoctl, and unescaped in the path.
+	content, ok := d.files[r.URL.Path]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	_, _ = w.Write(content)
+}
@@ -0,0 +1,177 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testkit
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/distribution/distribution/v3/configuration"
+	"github.com/distribution/distribution/v3/registry/handlers"
+	"golang.org/x/crypto/bcrypt"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	// Register the htpasswd access controller and the inmemory storage driver used by the registry.
+	_ "github.com/distribution/distribution/v3/registry/auth/htpasswd"
+	_ "github.com/distribution/distribution/v3/registry/storage/driver/inmemory"
+)
+
+type registryOptions struct {
+	username string
+	password string
+	tls      bool
+}
+
+// RegistryOption configures a registry started by StartRegistry.
+type RegistryOption func(*registryOptions)
+
+// WithBasicAuth protects the registry with basic authentication, accepting only the given credentials.
+func WithBasicAuth(username, password string) RegistryOption {
+	return func(o *registryOptions) {
+		o.username = username
+		o.password = password
+	}
+}
+
+// WithTLS serves the registry over TLS, using a self-signed certificate trusted by Registry.Client.
+func WithTLS() RegistryOption {
+	return func(o *registryOptions) {
+		o.tls = true
+	}
+}
+
+// Registry is an in-process OCI registry, storing the artifacts in memory.
+type Registry struct {
+	// Host is the "address:port" the registry is listening on, to be used in references.
+	Host string
+	// PlainHTTP is true when the registry is not served over TLS.
+	PlainHTTP bool
+	// Credential holds the credentials accepted by the registry, empty when authentication is disabled.
+	Credential auth.Credential
+
+	server *httptest.Server
+	dir    string
+}
+
+// StartRegistry starts a new registry listening on a random loopback port. The registry is stopped
+// by Close.
+func StartRegistry(ctx context.Context, opts ...RegistryOption) (*Registry, error) {
+	o := &registryOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	dir, err := os.MkdirTemp("", "falcoctl-testkit")
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &configuration.Configuration{
+		Storage: configuration.Storage{"inmemory": configuration.Parameters{}},
+	}
+	cfg.Log.AccessLog.Disabled = true
+
+	r := &Registry{PlainHTTP: !o.tls, dir: dir}
+	if o.username != "" {
+		htpasswdPath, err := writeHtpasswd(dir, o.username, o.password)
+		if err != nil {
+			os.RemoveAll(dir)
+			return nil, err
+		}
+		cfg.Auth = configuration.Auth{
+			"htpasswd": configuration.Parameters{
+				"realm": "falcoctl-testkit",
+				"path":  htpasswdPath,
+			},
+		}
+		r.Credential = auth.Credential{Username: o.username, Password: o.password}
+	}
+
+	app := handlers.NewApp(ctx, cfg)
+	if o.tls {
+		r.server = httptest.NewTLSServer(app)
+	} else {
+		r.server = httptest.NewServer(app)
+	}
+
+	u, err := url.Parse(r.server.URL)
+	if err != nil {
+		r.Close()
+		return nil, err
+	}
+	r.Host = u.Host
+
+	return r, nil
+}
+
+// writeHtpasswd writes an htpasswd file, containing only the given user, in dir.
+func writeHtpasswd(dir, username, password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", fmt.Errorf("unable to hash password: %w", err)
+	}
+	path := filepath.Join(dir, "htpasswd")
+	if err := os.WriteFile(path, []byte(fmt.Sprintf("%s:%s\n", username, hash)), 0o600); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// Close stops the registry and discards the stored artifacts.
+func (r *Registry) Close() {
+	r.server.Close()
+	os.RemoveAll(r.dir)
+}
+
+// Ref returns the reference of the given repository and tag, or digest, in the registry.
+func (r *Registry) Ref(repository, tagOrDigest string) string {
+	// Tags cannot contain colons, while digests always do.
+	separator := ":"
+	if strings.Contains(tagOrDigest, ":") {
+		separator = "@"
+	}
+	return r.Host + "/" + repository + separator + tagOrDigest
+}
+
+// HTTPClient returns an HTTP client trusting the certificate of the registry.
+func (r *Registry) HTTPClient() *http.Client {
+	return r.server.Client()
+}
+
+// Certificate returns the certificate the registry is served with, or nil if it is served over plain HTTP.
+func (r *Registry) Certificate() *x509.Certificate {
+	return r.server.Certificate()
+}
+
+// Client returns a client authenticated against the registry, and trusting its certificate, to be used
+// with the falcoctl puller and pusher.
+func (r *Registry) Client() *auth.Client {
+	client := &auth.Client{
+		Client: r.HTTPClient(),
+		Cache:  auth.NewCache(),
+	}
+	if r.Credential != auth.EmptyCredential {
+		client.Credential = auth.StaticCredential(r.Host, r.Credential)
+	}
+	return client
+}
@@ -0,0 +1,158 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testkit
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/opencontainers/image-spec/specs-go"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+	"oras.land/oras-go/v2"
+	"oras.land/oras-go/v2/registry/remote"
+
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+)
+
+const (
+	// PublicKeyFile is the name of the file the public key of a Signer is written to.
+	PublicKeyFile = "cosign.pub"
+
+	cosignPayloadMediaType    = "application/vnd.dev.cosign.simplesigning.v1+json"
+	cosignSignatureAnnotation = "dev.cosignproject.cosign/signature"
+	cosignSignatureType       = "cosign container image signature"
+)
+
+// Signer signs artifacts the way "cosign sign --key --tlog-upload=false" does, so that they can be
+// verified by falcoctl with the signature returned by Signature.
+type Signer struct {
+	// PublicKeyPath is the path of the PEM encoded public key verifying the signatures.
+	PublicKeyPath string
+
+	key *ecdsa.PrivateKey
+}
+
+// NewSigner generates a new key pair and writes the public key to PublicKeyFile in dir.
+func NewSigner(dir string) (*Signer, error) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("unable to generate signing key: %w", err)
+	}
+	der, err := x509.MarshalPKIXPublicKey(key.Public())
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal public key: %w", err)
+	}
+	publicKeyPath := filepath.Join(dir, PublicKeyFile)
+	if err := os.WriteFile(publicKeyPath, pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}), 0o600); err != nil {
+		return nil, err
+	}
+	return &Signer{PublicKeyPath: publicKeyPath, key: key}, nil
+}
+
+// Signature returns the parameters verifying the signatures of the signer, as set in index entries.
+// The transparency log is ignored since the signatures are not uploaded to it.
+func (s *Signer) Signature() *index.Signature {
+	return &index.Signature{
+		Cosign: &index.CosignSignature{
+			KeyRef:     s.PublicKeyPath,
+			IgnoreTlog: true,
+		},
+	}
+}
+
+// cosignPayload is the simple signing payload signed by cosign.
+type cosignPayload struct {
+	Critical struct {
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Type string `json:"type"`
+	} `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+// Sign signs the manifest with the given digest in repository, pushing the signature with the tag
+// cosign looks it up with.
+func (r *Registry) Sign(ctx context.Context, s *Signer, repository, digest string) error {
+	var p cosignPayload
+	p.Critical.Identity.DockerReference = r.Host + "/" + repository
+	p.Critical.Image.DockerManifestDigest = digest
+	p.Critical.Type = cosignSignatureType
+	payload, err := json.Marshal(p)
+	if err != nil {
+		return err
+	}
+
+	hash := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, s.key, hash[:])
+	if err != nil {
+		return fmt.Errorf("unable to sign %q: %w", digest, err)
+	}
+
+	repo, err := remote.NewRepository(r.Host + "/" + repository)
+	if err != nil {
+		return err
+	}
+	repo.Client = r.Client()
+	repo.PlainHTTP = r.PlainHTTP
+
+	layer, err := oras.PushBytes(ctx, repo, cosignPayloadMediaType, payload)
+	if err != nil {
+		return fmt.Errorf("unable to push signature payload: %w", err)
+	}
+	layer.Annotations = map[string]string{cosignSignatureAnnotation: base64.StdEncoding.EncodeToString(sig)}
+
+	image := v1.Image{RootFS: v1.RootFS{Type: "layers"}}
+	image.RootFS.DiffIDs = append(image.RootFS.DiffIDs, layer.Digest)
+	imageConfig, err := json.Marshal(image)
+	if err != nil {
+		return err
+	}
+	config, err := oras.PushBytes(ctx, repo, v1.MediaTypeImageConfig, imageConfig)
+	if err != nil {
+		return fmt.Errorf("unable to push signature config: %w", err)
+	}
+
+	manifest, err := json.Marshal(v1.Manifest{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: v1.MediaTypeImageManifest,
+		Config:    config,
+		Layers:    []v1.Descriptor{layer},
+	})
+	if err != nil {
+		return err
+	}
+	// cosign looks up the signature of sha256:<hex> at the tag sha256-<hex>.sig.
+	tag := strings.Replace(digest, ":", "-", 1) + ".sig"
+	if _, err := oras.TagBytes(ctx, repo, v1.MediaTypeImageManifest, manifest, tag); err != nil {
+		return fmt.Errorf("unable to push signature: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package testkit
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"oras.land/oras-go/v2/registry/remote/auth"
+
+	"github.com/falcosecurity/falcoctl/internal/signature"
+	driverversion "github.com/falcosecurity/falcoctl/pkg/driver/version"
+	"github.com/falcosecurity/falcoctl/pkg/errdefs"
+	"github.com/falcosecurity/falcoctl/pkg/index/index"
+	"github.com/falcosecurity/falcoctl/pkg/oci"
+	ocipuller "github.com/falcosecurity/falcoctl/pkg/oci/puller"
+)
+
+func TestRegistryWithAuthAndTLS(t *testing.T) {
+	ctx := context.Background()
+	reg, err := StartRegistry(ctx, WithBasicAuth("user", "password"), WithTLS())
+	require.NoError(t, err)
+	defer reg.Close()
+	require.False(t, reg.PlainHTTP)
+	require.NotNil(t, reg.Certificate())
+
+	rules := &Artifact{
+		Type:         oci.Rulesfile,
+		Repository:   "falcosecurity/rules/my-rules",
+		Tags:         []string{"1", "latest"},
+		Dependencies: []oci.ArtifactDependency{{Name: "my-plugin", Version: "0.1.0"}},
+		Requirements: []oci.ArtifactRequirement{{Name: "engine_version_semver", Version: "0.40.0"}},
+	}
+	res, err := reg.Push(ctx, rules)
+	require.NoError(t, err)
+	assert.Equal(t, oci.Rulesfile, res.Type)
+
+	puller := ocipuller.NewPuller(reg.Client(), reg.PlainHTTP, nil)
+	cfg, err := puller.ArtifactConfig(ctx, reg.Ref(rules.Repository, "latest"), runtime.GOOS, runtime.GOARCH)
+	require.NoError(t, err)
+	assert.Equal(t, "my-rules", cfg.Name)
+	assert.Equal(t, DefaultVersion, cfg.Version)
+	assert.Equal(t, rules.Dependencies, cfg.Dependencies)
+	assert.Equal(t, rules.Requirements, cfg.Requirements)
+
+	dest := t.TempDir()
+	_, err = puller.Pull(ctx, reg.Ref(rules.Repository, res.RootDigest), dest, runtime.GOOS, runtime.GOARCH)
+	require.NoError(t, err)
+	_, err = os.Stat(filepath.Join(dest, "my-rules-"+DefaultVersion+".tar.gz"))
+	require.NoError(t, err)
+
+	anonymous := &auth.Client{Client: reg.HTTPClient()}
+	_, err = ocipuller.NewPuller(anonymous, reg.PlainHTTP, nil).Descriptor(ctx, reg.Ref(rules.Repository, "latest"))
+	require.Error(t, err)
+}
+
+func TestPushPlugin(t *testing.T) {
+	ctx := context.Background()
+	reg, err := StartRegistry(ctx)
+	require.NoError(t, err)
+	defer reg.Close()
+
+	plugin := &Artifact{
+		Type:       oci.Plugin,
+		Repository: "falcosecurity/plugins/my-plugin",
+		Version:    "0.2.0",
+		Platforms:  []string{"linux/amd64", "linux/arm64"},
+	}
+	_, err = reg.Push(ctx, plugin)
+	require.NoError(t, err)
+
+	puller := ocipuller.NewPuller(reg.Client(), reg.PlainHTTP, nil)
+	for _, arch := range []string{"amd64", "arm64"} {
+		cfg, err := puller.ArtifactConfig(ctx, reg.Ref(plugin.Repository, "0.2.0"), "linux", arch)
+		require.NoError(t, err)
+		assert.Equal(t, "0.2.0", cfg.Version)
+	}
+	_, err = puller.ArtifactConfig(ctx, reg.Ref(plugin.Repository, "0.2.0"), "windows", "amd64")
+	require.Error(t, err)
+}
+
+func TestSignedArtifact(t *testing.T) {
+	ctx := context.Background()
+	reg, err := StartRegistry(ctx)
+	require.NoError(t, err)
+	defer reg.Close()
+
+	signer, err := NewSigner(t.TempDir())
+	require.NoError(t, err)
+	other, err := NewSigner(t.TempDir())
+	require.NoError(t, err)
+
+	asset := &Artifact{Type: oci.Asset, Repository: "falcosecurity/assets/my-asset", Signer: signer}
+	res, err := reg.Push(ctx, asset)
+	require.NoError(t, err)
+	ref := reg.Ref(asset.Repository, res.RootDigest)
+
+	entry := reg.Entry(asset)
+	assert.Equal(t, signer.Signature(), entry.Signature)
+	require.NoError(t, signature.Verify(ctx, ref, entry.Signature))
+
+	err = signature.Verify(ctx, ref, other.Signature())
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, errdefs.ErrSignatureInvalid))
+}
+
+func TestServeIndex(t *testing.T) {
+	idx := index.New("test")
+	idx.Upsert(&index.Entry{Name: "my-rules", Type: "rulesfile", Registry: "localhost:5000", Repository: "my-rules"})
+	s, err := ServeIndex(idx)
+	require.NoError(t, err)
+	defer s.Close()
+
+	served := fetchIndex(t, s.URL)
+	_, ok := served.EntryByName("my-rules")
+	assert.True(t, ok)
+
+	idx.Upsert(&index.Entry{Name: "my-plugin", Type: "plugin", Registry: "localhost:5000", Repository: "my-plugin"})
+	require.NoError(t, s.Set(idx))
+	served = fetchIndex(t, s.URL)
+	_, ok = served.EntryByName("my-plugin")
+	assert.True(t, ok)
+}
+
+func fetchIndex(t *testing.T, url string) *index.Index {
+	t.Helper()
+	resp, err := http.Get(url) //nolint:gosec,noctx // test server URL
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	data, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	idx := index.New("served")
+	require.NoError(t, idx.ReadBytes(data))
+	return idx
+}
+
+func TestServeFalcoVersions(t *testing.T) {
+	s, err := ServeFalcoVersions(map[string]string{
+		"engine_version":        "0.40.0",
+		"driver_api_version":    "8.0.0",
+		"driver_schema_version": "2.15.0",
+	})
+	require.NoError(t, err)
+	defer s.Close()
+
+	req, err := driverversion.FromURL(context.Background(), s.Client(), s.URL+FalcoVersionsPath)
+	require.NoError(t, err)
+	assert.Equal(t, "8.0.0", req.APIVersion.String())
+	assert.Equal(t, "2.15.0", req.SchemaVersion.String())
+}
+
+func TestDriverRepository(t *testing.T) {
+	ctx := context.Background()
+	repo := ServeDriverRepository()
+	defer repo.Close()
+
+	drivers, err := driverversion.RepoDrivers(ctx, http.DefaultClient, repo.URL)
+	require.NoError(t, err)
+	assert.Empty(t, drivers)
+
+	repo.AddDriver("7.0.0+driver", "8.0.0", "2.15.0")
+	repo.AddFile("7.0.0+driver", "x86_64", "falco_ubuntu-generic_6.5.0-1-generic_1.ko", []byte("kmod"))
+
+	drivers, err = driverversion.RepoDrivers(ctx, http.DefaultClient, repo.URL)
+	require.NoError(t, err)
+	require.Len(t, drivers, 1)
+	assert.Equal(t, "7.0.0+driver", drivers[0].Version)
+
+	resp, err := http.Get(repo.URL + "/7.0.0%2Bdriver/x86_64/falco_ubuntu-generic_6.5.0-1-generic_1.ko") //nolint:noctx // test server URL
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "kmod", string(body))
+	assert.Contains(t, repo.Requests(), "/7.0.0+driver/x86_64/falco_ubuntu-generic_6.5.0-1-generic_1.ko")
+}
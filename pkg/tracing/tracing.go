@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
+)
+
+// Supported exporters.
+const (
+	// ExporterNone disables tracing.
+	ExporterNone = "none"
+	// ExporterOTLPGRPC exports the spans to an OTLP gRPC endpoint.
+	ExporterOTLPGRPC = "otlp-grpc"
+	// ExporterOTLPHTTP exports the spans to an OTLP HTTP endpoint.
+	ExporterOTLPHTTP = "otlp-http"
+	// ExporterStdout writes the spans as JSON to the standard output.
+	ExporterStdout = "stdout"
+	// ExporterFile writes the spans as JSON to a file.
+	ExporterFile = "file"
+)
+
+// Exporters lists the supported exporters.
+var Exporters = []string{ExporterNone, ExporterOTLPGRPC, ExporterOTLPHTTP, ExporterStdout, ExporterFile}
+
+// tracerName is the instrumentation scope of the falcoctl spans.
+const tracerName = "github.com/falcosecurity/falcoctl"
+
+// Config is the tracing configuration.
+type Config struct {
+	// Exporter is one of Exporters. Tracing is disabled if empty.
+	Exporter string
+	// Endpoint is the OTLP endpoint, e.g. "localhost:4317". The OTEL_EXPORTER_OTLP_* environment
+	// variables are used if empty.
+	Endpoint string
+	// Insecure disables TLS towards the OTLP endpoint.
+	Insecure bool
+	// File is the path of the file written by ExporterFile.
+	File string
+}
+
+var (
+	mu       sync.Mutex
+	provider *sdktrace.TracerProvider
+	closer   io.Closer
+)
+
+// Setup installs the global tracer provider exporting the spans as configured. It does nothing if tracing
+// is disabled or already set up, so that it can be called each time the configuration is loaded.
+func Setup(ctx context.Context, conf *Config) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if provider != nil || conf.Exporter == "" || conf.Exporter == ExporterNone {
+		return nil
+	}
+
+	exporter, c, err := newExporter(ctx, conf)
+	if err != nil {
+		return err
+	}
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(semconv.ServiceName("falcoctl")))
+	if err != nil {
+		return fmt.Errorf("unable to create tracing resource: %w", err)
+	}
+
+	provider = sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	closer = c
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+	return nil
+}
+
+func newExporter(ctx context.Context, conf *Config) (sdktrace.SpanExporter, io.Closer, error) {
+	switch conf.Exporter {
+	case ExporterOTLPGRPC:
+		var opts []otlptracegrpc.Option
+		if conf.Endpoint != "" {
+			opts = append(opts, otlptracegrpc.WithEndpoint(conf.Endpoint))
+		}
+		if conf.Insecure {
+			opts = append(opts, otlptracegrpc.WithInsecure())
+		}
+		exporter, err := otlptracegrpc.New(ctx, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to create OTLP gRPC trace exporter: %w", err)
+		}
+		return exporter, nil, nil
+	case ExporterOTLPHTTP:
+		var opts []otlptracehttp.Option
+		if conf.Endpoint != "" {
+			opts = append(opts, otlptracehttp.WithEndpoint(conf.Endpoint))
+		}
+		if conf.Insecure {
+			opts = append(opts, otlptracehttp.WithInsecure())
+		}
+		exporter, err := otlptracehttp.New(ctx, opts...)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to create OTLP HTTP trace exporter: %w", err)
+		}
+		return exporter, nil, nil
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(os.Stdout))
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to create stdout trace exporter: %w", err)
+		}
+		return exporter, nil, nil
+	case ExporterFile:
+		if conf.File == "" {
+			return nil, nil, errors.New("the file trace exporter requires a file path")
+		}
+		f, err := os.OpenFile(filepath.Clean(conf.File), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to open trace file %q: %w", conf.File, err)
+		}
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(f))
+		if err != nil {
+			_ = f.Close()
+			return nil, nil, fmt.Errorf("unable to create file trace exporter: %w", err)
+		}
+		return exporter, f, nil
+	default:
+		return nil, nil, fmt.Errorf("unsupported trace exporter %q, allowed values: %s", conf.Exporter, strings.Join(Exporters, ", "))
+	}
+}
+
+// Shutdown flushes the pending spans and stops the exporter. It does nothing if tracing is not set up.
+func Shutdown(ctx context.Context) error {
+	mu.Lock()
+	defer mu.Unlock()
+	if provider == nil {
+		return nil
+	}
+	err := provider.Shutdown(ctx)
+	if closer != nil {
+		err = errors.Join(err, closer.Close())
+	}
+	provider, closer = nil, nil
+	otel.SetTracerProvider(noop.NewTracerProvider())
+	return err
+}
+
+// Start starts a span child of the one in ctx, if any.
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End ends a span, recording err if not nil.
+func End(span trace.Span, err error) {
+	recordError(span, err)
+	span.End()
+}
+
+// RecordError records err, if not nil, on the span in ctx, for spans ended elsewhere.
+func RecordError(ctx context.Context, err error) {
+	recordError(trace.SpanFromContext(ctx), err)
+}
+
+func recordError(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+}
+
+// Transport wraps an HTTP transport so that each request gets its own span, such as the token exchanges,
+// the manifest resolutions and the blob downloads of the registry clients. It returns the transport unchanged
+// if tracing is disabled, so that callers can still inspect it.
+func Transport(rt http.RoundTripper) http.RoundTripper {
+	mu.Lock()
+	enabled := provider != nil
+	mu.Unlock()
+	if !enabled {
+		return rt
+	}
+	return otelhttp.NewTransport(rt)
+}
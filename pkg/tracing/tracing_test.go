@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+// Copyright (C) 2025 The Falco Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+type exportedSpan struct {
+	Name   string
+	Parent struct {
+		SpanID string
+	}
+	SpanContext struct {
+		TraceID string
+		SpanID  string
+	}
+	Status struct {
+		Code        string
+		Description string
+	}
+}
+
+func readSpans(t *testing.T, path string) map[string]exportedSpan {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	spans := map[string]exportedSpan{}
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		var s exportedSpan
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &s))
+		spans[s.Name] = s
+	}
+	require.NoError(t, scanner.Err())
+	return spans
+}
+
+func TestFileExporter(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	ctx := context.Background()
+	require.NoError(t, Setup(ctx, &Config{Exporter: ExporterFile, File: path}))
+
+	parentCtx, parent := Start(ctx, "parent", attribute.String("ref", "ghcr.io/falcosecurity/rules/falco-rules:latest"))
+	_, child := Start(parentCtx, "child")
+	_, ok := Transport(http.DefaultTransport).(*http.Transport)
+	assert.False(t, ok, "the transport must be wrapped when tracing is enabled")
+	End(child, errors.New("boom"))
+	End(parent, nil)
+	require.NoError(t, Shutdown(ctx))
+
+	spans := readSpans(t, path)
+	require.Contains(t, spans, "parent")
+	require.Contains(t, spans, "child")
+	assert.Equal(t, spans["parent"].SpanContext.TraceID, spans["child"].SpanContext.TraceID)
+	assert.Equal(t, spans["parent"].SpanContext.SpanID, spans["child"].Parent.SpanID)
+	assert.Equal(t, "Error", spans["child"].Status.Code)
+	assert.Equal(t, "boom", spans["child"].Status.Description)
+	assert.Equal(t, "Unset", spans["parent"].Status.Code)
+}
+
+func TestSetup(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("disabled", func(t *testing.T) {
+		require.NoError(t, Setup(ctx, &Config{}))
+		require.NoError(t, Setup(ctx, &Config{Exporter: ExporterNone}))
+		assert.Nil(t, provider)
+		// Spans are no-ops when tracing is disabled.
+		_, span := Start(ctx, "noop")
+		assert.False(t, span.SpanContext().IsValid())
+		End(span, errors.New("boom"))
+		// Transports are not wrapped, so that callers can still inspect them.
+		transport := &http.Transport{}
+		assert.Same(t, transport, Transport(transport))
+		require.NoError(t, Shutdown(ctx))
+	})
+
+	t.Run("unsupported exporter", func(t *testing.T) {
+		err := Setup(ctx, &Config{Exporter: "zipkin"})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), `unsupported trace exporter "zipkin"`)
+	})
+
+	t.Run("file exporter without file", func(t *testing.T) {
+		require.Error(t, Setup(ctx, &Config{Exporter: ExporterFile}))
+	})
+}
+
+func TestTransportConcurrentSetup(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "traces.json")
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 10; i++ {
+			assert.NoError(t, Setup(ctx, &Config{Exporter: ExporterFile, File: path}))
+			assert.NoError(t, Shutdown(ctx))
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		assert.NotNil(t, Transport(http.DefaultTransport))
+	}
+	<-done
+}